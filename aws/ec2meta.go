@@ -2,6 +2,8 @@ package aws
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -157,3 +159,31 @@ func (e *Ec2Meta) Region(def ...string) (string, error) {
 
 	return region, nil
 }
+
+// AccountID - the ID of the AWS account that owns the instance, as reported in
+// the instance identity document
+func (e *Ec2Meta) AccountID(def ...string) (string, error) {
+	doc, err := e.retrieveDynamicdata("instance-identity/document")
+	if err != nil {
+		return "", err
+	}
+
+	if doc == "" {
+		return returnDefault(def), nil
+	}
+
+	identity := struct {
+		AccountID string `json:"accountId"`
+	}{}
+
+	err = json.Unmarshal([]byte(doc), &identity)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse instance identity document: %w", err)
+	}
+
+	if identity.AccountID == "" {
+		return returnDefault(def), nil
+	}
+
+	return identity.AccountID, nil
+}
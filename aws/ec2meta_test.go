@@ -60,6 +60,27 @@ func TestRegion_KnownRegion(t *testing.T) {
 	assert.Equal(t, "us-east-1", must(ec2meta.Region()))
 }
 
+func TestAccountID(t *testing.T) {
+	ec2meta := MockEC2Meta(nil, nil, "")
+
+	assert.Empty(t, must(ec2meta.AccountID()))
+	assert.Equal(t, "foo", must(ec2meta.AccountID("foo")))
+
+	ec2meta = MockEC2Meta(nil, map[string]string{
+		"instance-identity/document": `{"accountId": "123456789012", "region": "us-east-1"}`,
+	}, "")
+
+	assert.Equal(t, "123456789012", must(ec2meta.AccountID()))
+	assert.Equal(t, "123456789012", must(ec2meta.AccountID("foo")))
+
+	ec2meta = MockEC2Meta(nil, map[string]string{
+		"instance-identity/document": `not json`,
+	}, "")
+
+	_, err := ec2meta.AccountID()
+	assert.Error(t, err)
+}
+
 func TestUnreachable(t *testing.T) {
 	assert.False(t, unreachable(errors.New("foo")))
 	assert.True(t, unreachable(errors.New("host is down")))
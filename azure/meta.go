@@ -0,0 +1,277 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hairyhenderson/gomplate/v4/env"
+	"github.com/rs/zerolog"
+)
+
+// DefaultEndpoint is the address of the Azure Instance Metadata Service.
+var DefaultEndpoint = "http://169.254.169.254"
+
+const (
+	// the IMDS API versions used for instance metadata and identity tokens
+	instanceAPIVersion = "2021-02-01"
+	identityAPIVersion = "2018-02-01"
+)
+
+var (
+	// co is a ClientOptions populated from the environment.
+	co ClientOptions
+	// coInit ensures that `co` is only set once.
+	coInit sync.Once
+)
+
+// ClientOptions contains various user-specifiable options for a MetaClient.
+type ClientOptions struct {
+	Timeout time.Duration
+}
+
+// defaultTimeout is used when AZURE_TIMEOUT isn't set, or is invalid
+const defaultTimeout = 500 * time.Millisecond
+
+// GetClientOptions - Centralised reading of AZURE_TIMEOUT. An invalid value is
+// logged as a warning, and the default timeout is used instead.
+func GetClientOptions(ctx context.Context) ClientOptions {
+	coInit.Do(func() {
+		co.Timeout = defaultTimeout
+
+		timeout := env.Getenv("AZURE_TIMEOUT")
+		if timeout == "" {
+			return
+		}
+
+		t, err := strconv.Atoi(timeout)
+		if err != nil || t <= 0 {
+			zerolog.Ctx(ctx).Warn().Str("value", timeout).
+				Msgf("invalid AZURE_TIMEOUT - must be a positive integer number of milliseconds, using default of %s", defaultTimeout)
+			return
+		}
+
+		co.Timeout = time.Duration(t) * time.Millisecond
+	})
+	return co
+}
+
+// MetaClient is used to access metadata accessible via the Azure Instance
+// Metadata Service (IMDS).
+type MetaClient struct {
+	ctx      context.Context
+	client   *http.Client
+	cache    map[string]string
+	endpoint string
+	options  ClientOptions
+}
+
+// NewMetaClient constructs a new MetaClient with the given ClientOptions. If the environment
+// contains a variable named `AZURE_META_ENDPOINT`, the client will address that, if not the
+// value of `DefaultEndpoint` is used.
+func NewMetaClient(ctx context.Context, options ClientOptions) *MetaClient {
+	endpoint := env.Getenv("AZURE_META_ENDPOINT")
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+
+	return &MetaClient{
+		ctx:      ctx,
+		cache:    make(map[string]string),
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		options:  options,
+	}
+}
+
+// Meta retrieves a value from the instance metadata, like "compute/name" or
+// "network/interface/0/ipv4/ipAddress/0/privateIpAddress", returning the
+// given default if the service is unavailable or the key does not exist.
+func (c *MetaClient) Meta(key string, def ...string) (string, error) {
+	q := url.Values{}
+	q.Set("api-version", instanceAPIVersion)
+	q.Set("format", "text")
+	u := c.endpoint + "/metadata/instance/" + strings.TrimPrefix(key, "/") + "?" + q.Encode()
+
+	value, ok, err := c.retrieveMetadata(u)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return returnDefault(def), nil
+	}
+	return value, nil
+}
+
+// Name returns the name of the VM.
+func (c *MetaClient) Name(def ...string) (string, error) {
+	return c.Meta("compute/name", def...)
+}
+
+// ResourceGroup returns the name of the VM's resource group.
+func (c *MetaClient) ResourceGroup(def ...string) (string, error) {
+	return c.Meta("compute/resourceGroupName", def...)
+}
+
+// SubscriptionID returns the ID of the VM's subscription.
+func (c *MetaClient) SubscriptionID(def ...string) (string, error) {
+	return c.Meta("compute/subscriptionId", def...)
+}
+
+// Location returns the Azure region the VM is running in, like "westeurope".
+func (c *MetaClient) Location(def ...string) (string, error) {
+	return c.Meta("compute/location", def...)
+}
+
+// Tags returns the VM's tags. When the service is unavailable, no tags are
+// returned.
+func (c *MetaClient) Tags() (map[string]string, error) {
+	q := url.Values{}
+	q.Set("api-version", instanceAPIVersion)
+	q.Set("format", "json")
+	u := c.endpoint + "/metadata/instance/compute/tagsList?" + q.Encode()
+
+	body, ok, err := c.retrieveMetadata(u)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := map[string]string{}
+	if !ok {
+		return tags, nil
+	}
+
+	list := []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}{}
+	err = json.Unmarshal([]byte(body), &list)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse tags: %w", err)
+	}
+
+	for _, t := range list {
+		tags[t.Name] = t.Value
+	}
+	return tags, nil
+}
+
+// Tag returns the value of the given tag, or the default if the tag isn't
+// set (or the service is unavailable).
+func (c *MetaClient) Tag(name string, def ...string) (string, error) {
+	tags, err := c.Tags()
+	if err != nil {
+		return "", err
+	}
+	if v, ok := tags[name]; ok {
+		return v, nil
+	}
+	return returnDefault(def), nil
+}
+
+// Token requests an access token for the given resource (like
+// "https://vault.azure.net") from the VM's managed identity. When the VM has
+// more than one user-assigned identity, the client ID of the identity to use
+// can be given.
+//
+// Unlike the other functions, an error is returned when the token can't be
+// retrieved.
+func (c *MetaClient) Token(resource string, clientID ...string) (string, error) {
+	q := url.Values{}
+	q.Set("api-version", identityAPIVersion)
+	q.Set("resource", resource)
+	if len(clientID) > 0 && clientID[0] != "" {
+		q.Set("client_id", clientID[0])
+	}
+	u := c.endpoint + "/metadata/identity/oauth2/token?" + q.Encode()
+
+	// tokens expire, so they're never cached
+	body, status, err := c.get(u)
+	if err != nil {
+		return "", fmt.Errorf("failed to request managed identity token: %w", err)
+	}
+
+	if status > 399 {
+		return "", fmt.Errorf("failed to request managed identity token for %q: %d %s: %s",
+			resource, status, http.StatusText(status), strings.TrimSpace(body))
+	}
+
+	token := struct {
+		AccessToken string `json:"access_token"`
+	}{}
+	err = json.Unmarshal([]byte(body), &token)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse managed identity token response: %w", err)
+	}
+
+	return token.AccessToken, nil
+}
+
+// retrieveMetadata gets the value at the given URL, returning false when the
+// service is unavailable or the value doesn't exist.
+func (c *MetaClient) retrieveMetadata(u string) (string, bool, error) {
+	if value, ok := c.cache[u]; ok {
+		return value, true, nil
+	}
+
+	body, status, err := c.get(u)
+	if err != nil || status > 399 {
+		//nolint:nilerr
+		return "", false, nil
+	}
+
+	value := strings.TrimSpace(body)
+	c.cache[u] = value
+
+	return value, true, nil
+}
+
+// get executes an HTTP request to the Azure Instance Metadata Service with the
+// required header set, and returns the body and status code.
+func (c *MetaClient) get(u string) (string, int, error) {
+	if c.client == nil {
+		timeout := c.options.Timeout
+		if timeout == 0 {
+			timeout = defaultTimeout
+		}
+		// IMDS requests must not go through a proxy
+		c.client = &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{Proxy: nil},
+		}
+	}
+
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Metadata", "true")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read response body from %s: %w", u, err)
+	}
+
+	return string(body), resp.StatusCode, nil
+}
+
+// returnDefault returns the first element of the given slice (often taken from varargs)
+// if there is one, or returns an empty string if the slice has no elements.
+func returnDefault(def []string) string {
+	if len(def) > 0 {
+		return def[0]
+	}
+	return ""
+}
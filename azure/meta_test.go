@@ -0,0 +1,172 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMetaServer(t *testing.T) *MetaClient {
+	t.Helper()
+
+	data := map[string]string{
+		"/metadata/instance/compute/name":              "my-vm",
+		"/metadata/instance/compute/resourceGroupName": "my-rg\n",
+		"/metadata/instance/compute/subscriptionId":    "8d10da13-8125-4ba9-a717-bf7490507b3d",
+		"/metadata/instance/compute/location":          "westeurope",
+		"/metadata/instance/compute/tagsList":          `[{"name":"env","value":"prod"},{"name":"team","value":"ops"}]`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" || r.URL.Query().Get("api-version") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if r.URL.Path == "/metadata/identity/oauth2/token" {
+			switch r.URL.Query().Get("resource") {
+			case "https://vault.azure.net":
+				token := "vault-token"
+				if id := r.URL.Query().Get("client_id"); id != "" {
+					token += "-" + id
+				}
+				_, _ = w.Write([]byte(`{"access_token":"` + token + `","token_type":"Bearer"}`))
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"invalid_resource"}`))
+			}
+			return
+		}
+
+		v, ok := data[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(v))
+	}))
+	t.Cleanup(srv.Close)
+
+	t.Setenv("AZURE_META_ENDPOINT", srv.URL)
+	return NewMetaClient(context.Background(), ClientOptions{})
+}
+
+func TestMeta(t *testing.T) {
+	c := setupMetaServer(t)
+
+	out, err := c.Meta("compute/name")
+	require.NoError(t, err)
+	assert.Equal(t, "my-vm", out)
+
+	out, err = c.Meta("compute/missing", "default")
+	require.NoError(t, err)
+	assert.Equal(t, "default", out)
+
+	out, err = c.Name()
+	require.NoError(t, err)
+	assert.Equal(t, "my-vm", out)
+
+	out, err = c.ResourceGroup()
+	require.NoError(t, err)
+	assert.Equal(t, "my-rg", out)
+
+	out, err = c.SubscriptionID()
+	require.NoError(t, err)
+	assert.Equal(t, "8d10da13-8125-4ba9-a717-bf7490507b3d", out)
+
+	out, err = c.Location()
+	require.NoError(t, err)
+	assert.Equal(t, "westeurope", out)
+}
+
+func TestTags(t *testing.T) {
+	c := setupMetaServer(t)
+
+	tags, err := c.Tags()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod", "team": "ops"}, tags)
+
+	out, err := c.Tag("env")
+	require.NoError(t, err)
+	assert.Equal(t, "prod", out)
+
+	out, err = c.Tag("missing", "none")
+	require.NoError(t, err)
+	assert.Equal(t, "none", out)
+}
+
+func TestToken(t *testing.T) {
+	c := setupMetaServer(t)
+
+	out, err := c.Token("https://vault.azure.net")
+	require.NoError(t, err)
+	assert.Equal(t, "vault-token", out)
+
+	out, err = c.Token("https://vault.azure.net", "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, "vault-token-abc123", out)
+
+	_, err = c.Token("https://bogus.example.com")
+	assert.ErrorContains(t, err, "invalid_resource")
+}
+
+func TestOutsideAzure(t *testing.T) {
+	t.Setenv("AZURE_META_ENDPOINT", "http://127.0.0.1:1")
+	c := NewMetaClient(context.Background(), ClientOptions{})
+
+	out, err := c.Name("unknown")
+	require.NoError(t, err)
+	assert.Equal(t, "unknown", out)
+
+	tags, err := c.Tags()
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+
+	out, err = c.Tag("env", "dev")
+	require.NoError(t, err)
+	assert.Equal(t, "dev", out)
+
+	_, err = c.Token("https://vault.azure.net")
+	assert.Error(t, err)
+}
+
+func TestGetClientOptions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("default", func(t *testing.T) {
+		coInit = sync.Once{}
+		assert.Equal(t, ClientOptions{Timeout: 500 * time.Millisecond}, GetClientOptions(ctx))
+	})
+
+	t.Run("valid AZURE_TIMEOUT", func(t *testing.T) {
+		t.Setenv("AZURE_TIMEOUT", "42")
+		coInit = sync.Once{}
+		assert.Equal(t, ClientOptions{Timeout: 42 * time.Millisecond}, GetClientOptions(ctx))
+
+		// without resetting the Once, expect to be reused
+		t.Setenv("AZURE_TIMEOUT", "123")
+		assert.Equal(t, ClientOptions{Timeout: 42 * time.Millisecond}, GetClientOptions(ctx))
+	})
+
+	t.Run("invalid AZURE_TIMEOUT", func(t *testing.T) {
+		for _, v := range []string{"foo", "-1"} {
+			t.Setenv("AZURE_TIMEOUT", v)
+			coInit = sync.Once{}
+
+			buf := &bytes.Buffer{}
+			ctx := zerolog.New(buf).WithContext(ctx)
+
+			assert.Equal(t, ClientOptions{Timeout: 500 * time.Millisecond}, GetClientOptions(ctx))
+			assert.Contains(t, buf.String(), `"level":"warn"`)
+			assert.Contains(t, buf.String(), `"value":"`+v+`"`)
+		}
+	})
+}
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
 	iconv "github.com/hairyhenderson/gomplate/v4/internal/conv"
@@ -40,6 +42,55 @@ func Has(in interface{}, key interface{}) bool {
 	return false
 }
 
+// Dig returns the value found by following the given dot-separated path of
+// keys through nested maps, or the default value if any part of the path is
+// missing. Path elements that are integers can also be used to index into
+// slices and arrays.
+func Dig(path string, def interface{}, in interface{}) interface{} {
+	if path == "" {
+		return in
+	}
+
+	item := reflect.ValueOf(in)
+	for _, k := range strings.Split(path, ".") {
+		var isNil bool
+		if item, isNil = indirect(item); isNil {
+			return def
+		}
+
+		switch item.Kind() {
+		case reflect.Map:
+			keyType := item.Type().Key()
+
+			var kv reflect.Value
+			switch keyType.Kind() {
+			case reflect.String:
+				kv = reflect.ValueOf(k).Convert(keyType)
+			case reflect.Interface:
+				kv = reflect.ValueOf(k)
+			default:
+				return def
+			}
+
+			v := item.MapIndex(kv)
+			if !v.IsValid() {
+				return def
+			}
+			item = v
+		case reflect.Slice, reflect.Array:
+			i, err := strconv.Atoi(k)
+			if err != nil || i < 0 || i >= item.Len() {
+				return def
+			}
+			item = item.Index(i)
+		default:
+			return def
+		}
+	}
+
+	return item.Interface()
+}
+
 // Dict is a convenience function that creates a map with string keys.
 // Provide arguments as key/value pairs. If an odd number of arguments
 // is provided, the last is used as the key, and an empty string is
@@ -204,7 +255,8 @@ func copyMap(m map[string]interface{}) map[string]interface{} {
 	return n
 }
 
-// Merges a default and override map
+// Merges a default and override map. Nested maps are merged recursively, while
+// all other values (including slices) in the override map replace the default.
 func mergeValues(d map[string]interface{}, o map[string]interface{}) map[string]interface{} {
 	def := copyMap(d)
 	over := copyMap(o)
@@ -299,6 +351,133 @@ func lessThan(key string) func(left, right interface{}) bool {
 	}
 }
 
+// SortBy sorts a list of maps (or structs) by the value found at the given
+// dot-separated key path (see Dig). Numbers of different types are compared
+// numerically, and elements where the path is missing are sorted last. The
+// sort is stable, so elements with equal values keep their original order.
+//
+// Does not modify the input list.
+func SortBy(path string, list interface{}) ([]interface{}, error) {
+	if list == nil {
+		return nil, nil
+	}
+
+	ia, err := iconv.InterfaceSlice(list)
+	if err != nil {
+		return nil, err
+	}
+
+	type keyed struct {
+		item  interface{}
+		value interface{}
+		found bool
+	}
+
+	k := make([]keyed, len(ia))
+	for i, v := range ia {
+		val := Dig(path, missing, v)
+		k[i] = keyed{item: v, value: val, found: val != missing}
+	}
+
+	sort.SliceStable(k, func(i, j int) bool {
+		if !k[i].found || !k[j].found {
+			return k[i].found && !k[j].found
+		}
+		return lessThanValue(k[i].value, k[j].value)
+	})
+
+	out := make([]interface{}, len(k))
+	for i, v := range k {
+		out[i] = v.item
+	}
+	return out, nil
+}
+
+// missing is a sentinel default value, to distinguish missing keys from keys
+// with nil values
+var missing = new(byte)
+
+// lessThanValue compares two values, converting numbers of different types as
+// necessary. Values that can't be compared are considered equal.
+func lessThanValue(left, right interface{}) bool {
+	lv := reflect.Indirect(reflect.ValueOf(left))
+	rv := reflect.Indirect(reflect.ValueOf(right))
+
+	lf, lok := numberValue(lv)
+	rf, rok := numberValue(rv)
+	if lok && rok {
+		return lf < rf
+	}
+
+	if lv.Kind() == reflect.String && rv.Kind() == reflect.String {
+		return lv.String() < rv.String()
+	}
+
+	return false
+}
+
+func numberValue(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// GroupBy groups the elements of a list by the value found at the given
+// dot-separated key path (see Dig). The result is a map of the values
+// (converted to strings) to lists of the elements with that value, in their
+// original order. Elements where the path is missing are grouped under the
+// empty string.
+func GroupBy(path string, list interface{}) (map[string]interface{}, error) {
+	ia, err := iconv.InterfaceSlice(list)
+	if err != nil {
+		return nil, err
+	}
+
+	out := map[string]interface{}{}
+	for _, v := range ia {
+		key := ""
+		if val := Dig(path, missing, v); val != missing {
+			key = conv.ToString(val)
+		}
+
+		group, _ := out[key].([]interface{})
+		out[key] = append(group, v)
+	}
+	return out, nil
+}
+
+// Chunk splits a list into lists of (at most) the given size. The last list
+// will be shorter if the input can't be divided evenly.
+func Chunk(size int, list interface{}) ([]interface{}, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("chunk size must be at least 1, got %d", size)
+	}
+
+	ia, err := iconv.InterfaceSlice(list)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]interface{}, 0, (len(ia)+size-1)/size)
+	for i := 0; i < len(ia); i += size {
+		end := i + size
+		if end > len(ia) {
+			end = len(ia)
+		}
+
+		c := make([]interface{}, end-i)
+		copy(c, ia[i:end])
+		out = append(out, c)
+	}
+	return out, nil
+}
+
 func sameTypes(a []interface{}) bool {
 	var t reflect.Type
 	for _, v := range a {
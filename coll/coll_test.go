@@ -42,6 +42,53 @@ func TestHas(t *testing.T) {
 	}
 }
 
+func TestDig(t *testing.T) {
+	in := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": map[string]interface{}{
+				"c": "found",
+				"n": nil,
+			},
+			"list": []interface{}{
+				map[string]interface{}{"name": "first"},
+				map[string]interface{}{"name": "second"},
+			},
+		},
+		"typed": map[string]string{"k": "v"},
+		"ikeys": map[interface{}]interface{}{"k": "iv"},
+		"ints":  map[int]string{1: "one"},
+		"str":   "hello",
+	}
+
+	testdata := []struct {
+		expected interface{}
+		path     string
+	}{
+		{"found", "a.b.c"},
+		{nil, "a.b.n"},
+		{in["a"].(map[string]interface{})["b"], "a.b"},
+		{"second", "a.list.1.name"},
+		{"v", "typed.k"},
+		{"iv", "ikeys.k"},
+		{"default", "a.b.missing"},
+		{"default", "a.missing.c"},
+		{"default", "a.list.2.name"},
+		{"default", "a.list.-1.name"},
+		{"default", "a.list.first"},
+		{"default", "ints.1"},
+		{"default", "str.length"},
+		{in, ""},
+	}
+
+	for _, d := range testdata {
+		assert.Equal(t, d.expected, Dig(d.path, "default", in), d.path)
+	}
+
+	assert.Equal(t, "default", Dig("a", "default", nil))
+	assert.Equal(t, "default", Dig("a.b", "default", map[string]*struct{}{"a": nil}))
+	assert.Equal(t, 2, Dig("1", "default", [3]int{1, 2, 3}))
+}
+
 func TestDict(t *testing.T) {
 	testdata := []struct {
 		expected map[string]interface{}
@@ -471,6 +518,99 @@ func TestSort(t *testing.T) {
 	}
 }
 
+func TestSortBy(t *testing.T) {
+	out, err := SortBy("a", 42)
+	require.Error(t, err)
+	assert.Nil(t, out)
+
+	out, err = SortBy("a", nil)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+
+	in := []interface{}{
+		map[string]interface{}{"name": "c", "meta": map[string]interface{}{"size": 3.5}},
+		map[string]interface{}{"name": "none"},
+		map[string]interface{}{"name": "a", "meta": map[string]interface{}{"size": 10}},
+		map[string]interface{}{"name": "b", "meta": map[string]interface{}{"size": uint8(1)}},
+		map[string]interface{}{"name": "d", "meta": map[string]interface{}{"size": 3.5}},
+	}
+
+	out, err = SortBy("meta.size", in)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{in[3], in[0], in[4], in[2], in[1]}, out)
+
+	out, err = SortBy("name", in)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{in[2], in[3], in[0], in[4], in[1]}, out)
+
+	// the input isn't modified
+	assert.Equal(t, "c", in[0].(map[string]interface{})["name"])
+
+	out, err = SortBy("1", [][]int{{1, 5}, {2, 3}, {3}})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]int{2, 3}, []int{1, 5}, []int{3}}, out)
+}
+
+func TestGroupBy(t *testing.T) {
+	_, err := GroupBy("a", 42)
+	require.Error(t, err)
+
+	in := []map[string]interface{}{
+		{"name": "web1", "role": "web", "zone": 1},
+		{"name": "db1", "role": "db", "zone": 2},
+		{"name": "web2", "role": "web", "zone": 2},
+		{"name": "other"},
+	}
+
+	out, err := GroupBy("role", in)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"web": []interface{}{in[0], in[2]},
+		"db":  []interface{}{in[1]},
+		"":    []interface{}{in[3]},
+	}, out)
+
+	out, err = GroupBy("zone", in)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"1": []interface{}{in[0]},
+		"2": []interface{}{in[1], in[2]},
+		"":  []interface{}{in[3]},
+	}, out)
+
+	out, err = GroupBy("role", []interface{}{})
+	require.NoError(t, err)
+	assert.Empty(t, out)
+}
+
+func TestChunk(t *testing.T) {
+	_, err := Chunk(0, []int{1})
+	require.Error(t, err)
+
+	_, err = Chunk(2, 42)
+	require.Error(t, err)
+
+	out, err := Chunk(2, []int{1, 2, 3, 4, 5})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		[]interface{}{1, 2},
+		[]interface{}{3, 4},
+		[]interface{}{5},
+	}, out)
+
+	out, err = Chunk(3, []string{"a", "b", "c"})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{"a", "b", "c"}}, out)
+
+	out, err = Chunk(10, []string{"a"})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{"a"}}, out)
+
+	out, err = Chunk(2, []string{})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{}, out)
+}
+
 func TestFlatten(t *testing.T) {
 	data := []struct {
 		in       interface{}
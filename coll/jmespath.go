@@ -0,0 +1,52 @@
+package coll
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jmespath/go-jmespath"
+)
+
+// JMESPath - query the input with the given JMESPath expression
+func JMESPath(expr string, in interface{}) (interface{}, error) {
+	jp, err := jmespath.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse JMESPath expression %q: %w", expr, err)
+	}
+
+	in, err = jmespathConvertType(in)
+	if err != nil {
+		return nil, fmt.Errorf("JMESPath type conversion: %w", err)
+	}
+
+	out, err := jp.Search(in)
+	if err != nil {
+		return nil, fmt.Errorf("executing JMESPath expression %q failed: %w", expr, err)
+	}
+
+	return out, nil
+}
+
+// jmespathConvertType converts the input to the plain JSON types that the
+// JMESPath implementation understands. This is necessary mainly because
+// comparisons and functions only work with float64 numbers, and typed maps and
+// slices would otherwise not be traversed.
+func jmespathConvertType(in interface{}) (interface{}, error) {
+	switch in.(type) {
+	case nil, bool, string, float64:
+		return in, nil
+	}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("json marshal: %w", err)
+	}
+
+	var out interface{}
+	err = json.Unmarshal(b, &out)
+	if err != nil {
+		return nil, fmt.Errorf("json unmarshal: %w", err)
+	}
+
+	return out, nil
+}
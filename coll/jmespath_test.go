@@ -0,0 +1,60 @@
+package coll
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJMESPath(t *testing.T) {
+	in := map[string]interface{}{
+		"locations": []map[string]interface{}{
+			{"name": "Seattle", "state": "WA", "population": 737015},
+			{"name": "New York", "state": "NY", "population": 8804190},
+			{"name": "Bellevue", "state": "WA", "population": 151854},
+			{"name": "Olympia", "state": "WA", "population": 55605},
+		},
+	}
+
+	out, err := JMESPath("locations[?state == 'WA'].name | sort(@) | join(', ', @)", in)
+	require.NoError(t, err)
+	assert.Equal(t, "Bellevue, Olympia, Seattle", out)
+
+	out, err = JMESPath("locations[?population > `500000`].name", in)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"Seattle", "New York"}, out)
+
+	out, err = JMESPath("max_by(locations, &population).state", in)
+	require.NoError(t, err)
+	assert.Equal(t, "NY", out)
+
+	out, err = JMESPath("locations[0].{city: name, pop: population}", in)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"city": "Seattle", "pop": 737015.0}, out)
+
+	out, err = JMESPath("length(locations)", in)
+	require.NoError(t, err)
+	assert.Equal(t, 4.0, out)
+
+	// missing keys result in nil
+	out, err = JMESPath("foo.bar", in)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+
+	type loc struct {
+		Name string `json:"name"`
+	}
+	out, err = JMESPath("[*].name", []loc{{"Seattle"}, {"Olympia"}})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"Seattle", "Olympia"}, out)
+
+	_, err = JMESPath("locations[?", in)
+	require.Error(t, err)
+
+	_, err = JMESPath("abs(locations)", in)
+	require.Error(t, err)
+
+	_, err = JMESPath("foo", map[string]interface{}{"foo": make(chan int)})
+	require.Error(t, err)
+}
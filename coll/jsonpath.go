@@ -3,6 +3,7 @@ package coll
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"k8s.io/client-go/util/jsonpath"
 )
@@ -44,9 +45,16 @@ func JSONPath(p string, in interface{}) (interface{}, error) {
 	return out, nil
 }
 
+// parsePath parses the expression, which may be either a bare path (like
+// `$.spec.containers[*].image`) or a kubectl-style template with one or more
+// brace-delimited actions (like `{.spec.containers[*].image}`).
 func parsePath(p string) (*jsonpath.JSONPath, error) {
+	if !strings.HasPrefix(strings.TrimSpace(p), "{") {
+		p = "{" + p + "}"
+	}
+
 	jp := jsonpath.New("<jsonpath>")
-	err := jp.Parse("{" + p + "}")
+	err := jp.Parse(p)
 	if err != nil {
 		return nil, err
 	}
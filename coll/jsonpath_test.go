@@ -75,6 +75,15 @@ func TestJSONPath(t *testing.T) {
 	assert.Contains(t, out, "Herman Melville")
 	assert.Contains(t, out, "J. R. R. Tolkien")
 
+	// kubectl-style expressions wrapped in braces are also supported
+	out, err = JSONPath("{.store.bicycle.color}", in)
+	require.NoError(t, err)
+	assert.Equal(t, "red", out)
+
+	out, err = JSONPath("{range .store.book[?(@.price > 10.0)]}{.title}{end}", in)
+	require.NoError(t, err)
+	assert.Equal(t, ar{"Sword of Honour", "The Lord of the Rings"}, out)
+
 	out, err = JSONPath("$..book[?( @.price < 10.0 )]", in)
 	require.NoError(t, err)
 	expected := ar{
@@ -4,6 +4,7 @@ package conv
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"reflect"
 	"strconv"
 	"strings"
@@ -27,15 +28,15 @@ func Bool(in string) bool {
 
 // ToBool converts an arbitrary input into a boolean.
 // Possible non-boolean true values are: 1 or the strings "t", "true", or "yes"
-// (any capitalizations)
-// All other values are considered false.
+// (any capitalizations, ignoring surrounding whitespace)
+// All other values (including nil) are considered false.
 func ToBool(in interface{}) bool {
 	if b, ok := in.(bool); ok {
 		return b
 	}
 
-	if str, ok := in.(string); ok {
-		str = strings.ToLower(str)
+	if str, ok := stringValue(in); ok {
+		str = strings.ToLower(strings.TrimSpace(str))
 		switch str {
 		case "1", "t", "true", "yes":
 			return true
@@ -180,10 +181,20 @@ func MustAtoi(s string) int {
 	return i
 }
 
-// ToInt64 - convert input to an int64, if convertible. Otherwise, returns 0.
+// ToInt64 - convert input to an int64, if convertible. Otherwise (including
+// for nil and empty strings), returns 0.
 func ToInt64(v interface{}) int64 {
-	if str, ok := v.(string); ok {
-		return strToInt64(str)
+	if s, ok := stringValue(v); ok {
+		return strToInt64(s)
+	}
+
+	switch t := v.(type) {
+	case *big.Int:
+		// like with uint64, this can overflow
+		if t != nil {
+			return t.Int64()
+		}
+		return 0
 	}
 
 	val := reflect.Indirect(reflect.ValueOf(v))
@@ -214,7 +225,7 @@ func ToInt(in interface{}) int {
 	// Protect against CWE-190 and CWE-681
 	// https://cwe.mitre.org/data/definitions/190.html
 	// https://cwe.mitre.org/data/definitions/681.html
-	if i := ToInt64(in); i <= math.MaxInt || i >= math.MinInt {
+	if i := ToInt64(in); i <= math.MaxInt && i >= math.MinInt {
 		return int(i)
 	}
 
@@ -240,10 +251,20 @@ func ToInts(in ...interface{}) []int {
 	return out
 }
 
-// ToFloat64 - convert input to a float64, if convertible. Otherwise, returns 0.
+// ToFloat64 - convert input to a float64, if convertible. Otherwise (including
+// for nil and empty strings), returns 0.
 func ToFloat64(v interface{}) float64 {
-	if str, ok := v.(string); ok {
-		return strToFloat64(str)
+	if s, ok := stringValue(v); ok {
+		return strToFloat64(s)
+	}
+
+	switch t := v.(type) {
+	case *big.Int:
+		if t != nil {
+			f, _ := new(big.Float).SetInt(t).Float64()
+			return f
+		}
+		return 0
 	}
 
 	val := reflect.Indirect(reflect.ValueOf(v))
@@ -266,7 +287,20 @@ func ToFloat64(v interface{}) float64 {
 	}
 }
 
+// stringValue returns the string value of in, if it's a string, a type
+// derived from string (like json.Number), or a pointer to one
+func stringValue(in interface{}) (string, bool) {
+	if s, ok := in.(string); ok {
+		return s, true
+	}
+	if v := reflect.Indirect(reflect.ValueOf(in)); v.Kind() == reflect.String {
+		return v.String(), true
+	}
+	return "", false
+}
+
 func strToInt64(str string) int64 {
+	str = strings.TrimSpace(str)
 	if strings.Contains(str, ",") {
 		str = strings.ReplaceAll(str, ",", "")
 	}
@@ -284,6 +318,7 @@ func strToInt64(str string) int64 {
 }
 
 func strToFloat64(str string) float64 {
+	str = strings.TrimSpace(str)
 	if strings.Contains(str, ",") {
 		str = strings.ReplaceAll(str, ",", "")
 	}
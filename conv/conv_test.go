@@ -1,8 +1,10 @@
 package conv
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -135,6 +137,7 @@ func TestToInt64(t *testing.T) {
 	assert.Equal(t, int64(3), ToInt64("3.5"))
 	assert.Equal(t, int64(-1), ToInt64(uint64(math.MaxUint64)))
 	assert.Equal(t, int64(0xFF), ToInt64(uint8(math.MaxUint8)))
+	assert.Equal(t, int64(42), ToInt64(big.NewInt(42)))
 
 	assert.Equal(t, int64(0), ToInt64(nil))
 	assert.Equal(t, int64(0), ToInt64(false))
@@ -145,6 +148,13 @@ func TestToInt64(t *testing.T) {
 	assert.Equal(t, int64(8), ToInt64("010"))
 	assert.Equal(t, int64(4096), ToInt64("4,096"))
 	assert.Equal(t, int64(-4096), ToInt64("-4,096.00"))
+
+	// whitespace is ignored, and string-like types are converted
+	assert.Equal(t, int64(42), ToInt64(" 42\n"))
+	assert.Equal(t, int64(42), ToInt64(json.Number("42")))
+	s := "42"
+	assert.Equal(t, int64(42), ToInt64(&s))
+	assert.Equal(t, int64(0), ToInt64((*string)(nil)))
 }
 
 func TestToInt(t *testing.T) {
@@ -166,6 +176,7 @@ func TestToInt(t *testing.T) {
 	assert.Equal(t, 8, ToInt("010"))
 	assert.Equal(t, 4096, ToInt("4,096"))
 	assert.Equal(t, -4096, ToInt("-4,096.00"))
+	assert.Equal(t, 8080, ToInt(json.Number("8080")))
 }
 
 func TestToInt64s(t *testing.T) {
@@ -194,11 +205,12 @@ func TestToFloat64(t *testing.T) {
 		assert.Equal(t, 0.0, ToFloat64(n))
 	}
 	assert.Equal(t, 1.0, ToFloat64(true))
+	assert.Equal(t, 0x1p64, ToFloat64(new(big.Int).Lsh(big.NewInt(1), 64)))
 	z = []interface{}{42, 42.0, float32(42), "42", "42.0", uint8(42), "0x2A", "052"}
 	for _, n := range z {
 		assert.Equal(t, 42.0, ToFloat64(n))
 	}
-	z = []interface{}{1000.34, "1000.34", "1,000.34"}
+	z = []interface{}{1000.34, "1000.34", "1,000.34", " 1000.34 ", json.Number("1000.34")}
 	for _, n := range z {
 		assert.Equal(t, 1000.34, ToFloat64(n))
 	}
@@ -280,6 +292,8 @@ func TestToBool(t *testing.T) {
 		"TrUe",
 		"yes",
 		"YES",
+		" true\n",
+		json.Number("1"),
 	}
 	for _, d := range trueData {
 		out := ToBool(d)
@@ -5,6 +5,7 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"fmt"
 	"io"
 )
 
@@ -86,3 +87,61 @@ func DecryptAESCBC(key []byte, in []byte) ([]byte, error) {
 
 	return out, nil
 }
+
+// EncryptAESGCM - use a 128, 192, or 256 bit key to encrypt and authenticate
+// the given content using AES-GCM. The output will not be encoded. Usually the
+// output would be base64-encoded for display.
+//
+// A random 96-bit nonce is generated for each call, and is stored at the
+// beginning of the output, followed by the ciphertext and the authentication
+// tag.
+func EncryptAESGCM(key []byte, in []byte) ([]byte, error) {
+	aead, err := newAESGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(in)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, in, nil), nil
+}
+
+// DecryptAESGCM - use a 128, 192, or 256 bit key to decrypt and verify the
+// given content using AES-GCM. The input must be in the format produced by
+// EncryptAESGCM (the nonce, followed by the ciphertext and authentication tag).
+// An error is returned if the content has been tampered with, or if the key is
+// wrong.
+func DecryptAESGCM(key []byte, in []byte) ([]byte, error) {
+	aead, err := newAESGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	ns := aead.NonceSize()
+	if len(in) < ns+aead.Overhead() {
+		return nil, fmt.Errorf("AES-GCM ciphertext too short: %d bytes", len(in))
+	}
+
+	// open into a non-nil slice, so an empty plaintext decrypts to an empty
+	// (rather than nil) slice
+	out := make([]byte, 0, len(in)-ns-aead.Overhead())
+
+	out, err = aead.Open(out, in[:ns], in[ns:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("AES-GCM decryption failed: %w", err)
+	}
+
+	return out, nil
+}
+
+func newAESGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -2,6 +2,7 @@ package crypto
 
 import (
 	"bytes"
+	"encoding/base64"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -66,3 +67,65 @@ func TestEncryptDecryptAESCBC(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, []byte("foo"), out)
 }
+
+func TestEncryptDecryptAESGCM(t *testing.T) {
+	// empty key is invalid
+	_, err := EncryptAESGCM([]byte{}, []byte("foo"))
+	require.Error(t, err)
+
+	// wrong-length keys are invalid
+	_, err = EncryptAESGCM(bytes.Repeat([]byte{'a'}, 15), []byte("foo"))
+	require.Error(t, err)
+
+	_, err = DecryptAESGCM(bytes.Repeat([]byte{'a'}, 15), []byte("foo"))
+	require.Error(t, err)
+
+	key := make([]byte, 32)
+	copy(key, []byte("password"))
+
+	testdata := [][]byte{
+		{},
+		bytes.Repeat([]byte{'a'}, 1),
+		bytes.Repeat([]byte{'a'}, 16),
+		bytes.Repeat([]byte{'a'}, 33),
+	}
+
+	for _, d := range testdata {
+		out, err := EncryptAESGCM(key, d)
+		require.NoError(t, err)
+		assert.Len(t, out, 12+len(d)+16)
+
+		out, err = DecryptAESGCM(key, out)
+		require.NoError(t, err)
+		assert.Equal(t, d, out)
+	}
+
+	// a random nonce is used every time
+	out1, err := EncryptAESGCM(key, []byte("foo"))
+	require.NoError(t, err)
+	out2, err := EncryptAESGCM(key, []byte("foo"))
+	require.NoError(t, err)
+	assert.NotEqual(t, out1, out2)
+
+	// tampered content is rejected
+	out1[len(out1)-1] ^= 0xff
+	_, err = DecryptAESGCM(key, out1)
+	require.Error(t, err)
+
+	// the wrong key is rejected
+	_, err = DecryptAESGCM(bytes.Repeat([]byte{'a'}, 32), out2)
+	require.Error(t, err)
+
+	// truncated content is rejected
+	_, err = DecryptAESGCM(key, out2[:20])
+	require.Error(t, err)
+
+	// known ciphertext
+	key = make([]byte, 32)
+	copy(key, []byte("swordfish"))
+	in, err := base64.StdEncoding.DecodeString("HABuB9MmKxEXpYZ6mbsp6U3UHFa9LC0HtytwaNgTcjrhbPiObjyb")
+	require.NoError(t, err)
+	out, err := DecryptAESGCM(key, in)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello world"), out)
+}
@@ -0,0 +1,160 @@
+package crypto
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// The age functions are thin wrappers around filippo.io/age, which implements
+// the age file encryption format (https://age-encryption.org/v1). Only the
+// native X25519 and scrypt recipient types are supported.
+
+const (
+	// AgeDefaultWorkFactor is the default scrypt work factor (log2 of the
+	// scrypt N parameter) for passphrase encryption, as used by the age CLI
+	AgeDefaultWorkFactor = 18
+
+	// ageMaxWorkFactor limits the work factor accepted when decrypting, so
+	// that untrusted files can't cause excessive CPU and memory use
+	ageMaxWorkFactor = 22
+)
+
+// AgeGenerateKey - generate a new age X25519 identity, in the same format as
+// age-keygen ("AGE-SECRET-KEY-1...").
+func AgeGenerateKey() (string, error) {
+	k, err := age.GenerateX25519Identity()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate age identity: %w", err)
+	}
+
+	return k.String(), nil
+}
+
+// AgeRecipient - return the recipient ("age1...") for the given age identity.
+// The identity may be given as the content of an identity file, as created by
+// age-keygen, in which case it must contain exactly one identity.
+func AgeRecipient(identity string) (string, error) {
+	ids, err := age.ParseIdentities(strings.NewReader(identity))
+	if err != nil {
+		return "", fmt.Errorf("invalid age identity: %w", err)
+	}
+
+	if len(ids) != 1 {
+		return "", fmt.Errorf("expected exactly one age identity, found %d", len(ids))
+	}
+
+	id, ok := ids[0].(*age.X25519Identity)
+	if !ok {
+		return "", fmt.Errorf("unsupported age identity type %T", ids[0])
+	}
+
+	return id.Recipient().String(), nil
+}
+
+// AgeEncrypt - encrypt the input to the given X25519 recipients ("age1..."),
+// returning an ASCII-armored age file. Each recipient may also be given as the
+// content of a recipients file, with one recipient per line.
+func AgeEncrypt(recipients []string, in []byte) ([]byte, error) {
+	var rs []age.Recipient
+
+	for _, r := range recipients {
+		parsed, err := age.ParseRecipients(strings.NewReader(r))
+		if err != nil {
+			return nil, fmt.Errorf("invalid age recipient: %w", err)
+		}
+
+		rs = append(rs, parsed...)
+	}
+
+	if len(rs) == 0 {
+		return nil, fmt.Errorf("at least one age recipient is required")
+	}
+
+	return ageEncrypt(in, rs...)
+}
+
+// AgeEncryptWithPassphrase - encrypt the input with the given passphrase,
+// returning an ASCII-armored age file. The work factor is the log2 of the
+// scrypt N parameter - use AgeDefaultWorkFactor unless there's a good reason
+// not to.
+func AgeEncryptWithPassphrase(passphrase string, workFactor int, in []byte) ([]byte, error) {
+	if workFactor < 1 || workFactor > ageMaxWorkFactor {
+		return nil, fmt.Errorf("invalid scrypt work factor %d: must be between 1 and %d", workFactor, ageMaxWorkFactor)
+	}
+
+	r, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	r.SetWorkFactor(workFactor)
+
+	return ageEncrypt(in, r)
+}
+
+func ageEncrypt(in []byte, recipients ...age.Recipient) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	aw := armor.NewWriter(buf)
+
+	w, err := age.Encrypt(aw, recipients...)
+	if err != nil {
+		return nil, fmt.Errorf("age encryption failed: %w", err)
+	}
+
+	if _, err := w.Write(in); err != nil {
+		return nil, fmt.Errorf("age encryption failed: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("age encryption failed: %w", err)
+	}
+
+	if err := aw.Close(); err != nil {
+		return nil, fmt.Errorf("age encryption failed: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// AgeDecrypt - decrypt an age file, which may be ASCII-armored or binary.
+//
+// If the file was encrypted with a passphrase, key is the passphrase.
+// Otherwise, key must contain one or more X25519 identities, one per line -
+// the content of an identity file created by age-keygen can be used directly.
+func AgeDecrypt(key string, in []byte) ([]byte, error) {
+	ids, err := age.ParseIdentities(strings.NewReader(key))
+	if err != nil {
+		// not an identity file, so it must be a passphrase
+		id, serr := age.NewScryptIdentity(key)
+		if serr != nil {
+			return nil, serr
+		}
+
+		id.SetMaxWorkFactor(ageMaxWorkFactor)
+		ids = []age.Identity{id}
+	}
+
+	var src io.Reader = bytes.NewReader(in)
+
+	// armored files are often indented or preceded by newlines in templates
+	if trimmed := bytes.TrimLeft(in, " \t\r\n"); bytes.HasPrefix(trimmed, []byte(armor.Header)) {
+		src = armor.NewReader(bytes.NewReader(trimmed))
+	}
+
+	r, err := age.Decrypt(src, ids...)
+	if err != nil {
+		return nil, fmt.Errorf("age decryption failed: %w", err)
+	}
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("age decryption failed: %w", err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,200 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"filippo.io/age/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ageChunkSize is the size of the age payload's encrypted chunks
+const ageChunkSize = 64 * 1024
+
+func dearmor(t *testing.T, in []byte) []byte {
+	t.Helper()
+
+	out, err := io.ReadAll(armor.NewReader(bytes.NewReader(in)))
+	require.NoError(t, err)
+
+	return out
+}
+
+func TestAgeGenerateKey(t *testing.T) {
+	id, err := AgeGenerateKey()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(id, "AGE-SECRET-KEY-1"))
+	assert.Len(t, id, 74)
+
+	r, err := AgeRecipient(id)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(r, "age1"))
+	assert.Len(t, r, 62)
+
+	// age-keygen output is accepted
+	r2, err := AgeRecipient("# created: 2024-01-01T00:00:00Z\n# public key: " + r + "\n" + id + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, r, r2)
+
+	id2, err := AgeGenerateKey()
+	require.NoError(t, err)
+
+	_, err = AgeRecipient(id + "\n" + id2)
+	require.Error(t, err)
+
+	_, err = AgeRecipient("")
+	require.Error(t, err)
+
+	_, err = AgeRecipient(r)
+	require.Error(t, err)
+}
+
+func TestAgeEncryptDecrypt(t *testing.T) {
+	id1, err := AgeGenerateKey()
+	require.NoError(t, err)
+	r1, err := AgeRecipient(id1)
+	require.NoError(t, err)
+
+	id2, err := AgeGenerateKey()
+	require.NoError(t, err)
+	r2, err := AgeRecipient(id2)
+	require.NoError(t, err)
+
+	// sizes around the payload chunk boundaries
+	for _, n := range []int{0, 1, ageChunkSize - 1, ageChunkSize, ageChunkSize + 1, 3 * ageChunkSize} {
+		in := make([]byte, n)
+		_, err := rand.Read(in)
+		require.NoError(t, err)
+
+		enc, err := AgeEncrypt([]string{r1, "# a recipients file\n" + r2 + "\n"}, in)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(enc), armor.Header+"\n"))
+
+		for _, id := range []string{id1, id2, "# created: 2024-01-01T00:00:00Z\n" + id2 + "\n"} {
+			out, err := AgeDecrypt(id, enc)
+			require.NoError(t, err)
+			assert.True(t, bytes.Equal(in, out), "size %d", n)
+		}
+
+		// binary (non-armored) input is also supported
+		bin := dearmor(t, enc)
+		assert.True(t, bytes.HasPrefix(bin, []byte("age-encryption.org/v1\n-> X25519 ")))
+
+		out, err := AgeDecrypt(id1, bin)
+		require.NoError(t, err)
+		assert.True(t, bytes.Equal(in, out), "size %d", n)
+
+		if n > 0 {
+			_, err = AgeDecrypt(id1, bin[:len(bin)-1])
+			require.Error(t, err, "size %d", n)
+		}
+
+		// truncating at a chunk boundary must be detected
+		if n > ageChunkSize && n%ageChunkSize == 0 {
+			_, err = AgeDecrypt(id1, bin[:len(bin)-ageChunkSize-16])
+			require.Error(t, err, "size %d", n)
+		}
+	}
+
+	enc, err := AgeEncrypt([]string{r1}, []byte("hello"))
+	require.NoError(t, err)
+
+	_, err = AgeDecrypt(id2, enc)
+	require.Error(t, err)
+
+	// tampering with the header is detected
+	bin := dearmor(t, enc)
+	tampered := bytes.Replace(bin, []byte("-> X25519 "), []byte("-> extra\n\n-> X25519 "), 1)
+	_, err = AgeDecrypt(id1, tampered)
+	require.Error(t, err)
+
+	_, err = AgeEncrypt(nil, []byte("hello"))
+	require.Error(t, err)
+
+	_, err = AgeEncrypt([]string{"age1invalid"}, []byte("hello"))
+	require.Error(t, err)
+
+	_, err = AgeEncrypt([]string{id1}, []byte("hello"))
+	require.Error(t, err)
+
+	_, err = AgeDecrypt(id1, []byte("not encrypted"))
+	require.Error(t, err)
+
+	_, err = AgeDecrypt(id1, []byte(armor.Header+"\n!!!\n"+armor.Footer))
+	require.Error(t, err)
+}
+
+func TestAgePassphrase(t *testing.T) {
+	enc, err := AgeEncryptWithPassphrase("correct horse battery staple", 10, []byte("hello"))
+	require.NoError(t, err)
+
+	bin := dearmor(t, enc)
+	assert.True(t, bytes.HasPrefix(bin, []byte("age-encryption.org/v1\n-> scrypt ")))
+	assert.Contains(t, string(bin), " 10\n")
+
+	out, err := AgeDecrypt("correct horse battery staple", enc)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(out))
+
+	_, err = AgeDecrypt("wrong", enc)
+	require.Error(t, err)
+
+	_, err = AgeEncryptWithPassphrase("", 10, []byte("hello"))
+	require.Error(t, err)
+
+	_, err = AgeEncryptWithPassphrase("pass", 0, []byte("hello"))
+	require.Error(t, err)
+
+	_, err = AgeEncryptWithPassphrase("pass", ageMaxWorkFactor+1, []byte("hello"))
+	require.Error(t, err)
+}
+
+// TestAgeInterop decrypts files created by the reference age CLI (see
+// testdata/age), and checks that files we create can be decrypted by the same
+// identities.
+func TestAgeInterop(t *testing.T) {
+	key, err := os.ReadFile("testdata/age/key.txt")
+	require.NoError(t, err)
+
+	// the public key is recorded in the age-keygen output
+	r, err := AgeRecipient(string(key))
+	require.NoError(t, err)
+	assert.Contains(t, string(key), "# public key: "+r+"\n")
+
+	for _, f := range []string{"x25519.age", "x25519-armor.age"} {
+		in, err := os.ReadFile("testdata/age/" + f)
+		require.NoError(t, err)
+
+		out, err := AgeDecrypt(string(key), in)
+		require.NoError(t, err, f)
+		assert.Equal(t, "hello from the age CLI\n", string(out), f)
+	}
+
+	// the CLI uses the default work factor for passphrases
+	in, err := os.ReadFile("testdata/age/scrypt.age")
+	require.NoError(t, err)
+
+	out, err := AgeDecrypt("correct horse battery staple", in)
+	require.NoError(t, err)
+	assert.Equal(t, "hello from the age CLI\n", string(out))
+
+	// armored input may be indented or preceded by blank lines
+	in, err = os.ReadFile("testdata/age/x25519-armor.age")
+	require.NoError(t, err)
+
+	out, err = AgeDecrypt(string(key), append([]byte("\n  "), in...))
+	require.NoError(t, err)
+	assert.Equal(t, "hello from the age CLI\n", string(out))
+
+	enc, err := AgeEncrypt([]string{r}, []byte("hello from gomplate"))
+	require.NoError(t, err)
+
+	out, err = AgeDecrypt(string(key), enc)
+	require.NoError(t, err)
+	assert.Equal(t, "hello from gomplate", string(out))
+}
@@ -0,0 +1,54 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Recommended Argon2id parameters, from the second recommended option in
+// RFC 9106, section 4
+const (
+	Argon2DefaultTime    = 3
+	Argon2DefaultMemory  = 64 * 1024
+	Argon2DefaultThreads = 4
+
+	argon2SaltLen = 16
+	argon2KeyLen  = 32
+)
+
+// Argon2id hashes a password with the Argon2id function defined in RFC 9106,
+// using a random salt. The memory cost is given in KiB. The result is encoded
+// in the PHC string format used by the reference implementation (and most
+// password-verification libraries), i.e.:
+//
+//	$argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>
+func Argon2id(password []byte, time, memory uint32, threads uint8) (string, error) {
+	if time < 1 {
+		return "", fmt.Errorf("argon2: time cost must be at least 1")
+	}
+	if threads < 1 {
+		return "", fmt.Errorf("argon2: parallelism must be at least 1")
+	}
+	if memory < 8*uint32(threads) {
+		return "", fmt.Errorf("argon2: memory cost must be at least %d KiB with %d threads", 8*uint32(threads), threads)
+	}
+
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("argon2: generating salt: %w", err)
+	}
+
+	return argon2idEncode(password, salt, time, memory, threads), nil
+}
+
+func argon2idEncode(password, salt []byte, time, memory uint32, threads uint8) string {
+	key := argon2.IDKey(password, salt, time, memory, threads, argon2KeyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, memory, time, threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key))
+}
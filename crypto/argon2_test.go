@@ -0,0 +1,57 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/argon2"
+)
+
+func TestArgon2id(t *testing.T) {
+	t.Parallel()
+
+	out, err := Argon2id([]byte("password"), 1, 64, 2)
+	require.NoError(t, err)
+
+	parts := strings.Split(out, "$")
+	require.Len(t, parts, 6)
+	assert.Equal(t, "argon2id", parts[1])
+	assert.Equal(t, fmt.Sprintf("v=%d", argon2.Version), parts[2])
+	assert.Equal(t, "m=64,t=1,p=2", parts[3])
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	require.NoError(t, err)
+	assert.Len(t, salt, 16)
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	require.NoError(t, err)
+	assert.Equal(t, argon2.IDKey([]byte("password"), salt, 1, 64, 2, 32), key)
+
+	// salts are random
+	out2, err := Argon2id([]byte("password"), 1, 64, 2)
+	require.NoError(t, err)
+	assert.NotEqual(t, out, out2)
+
+	_, err = Argon2id([]byte("password"), 0, 64, 2)
+	require.Error(t, err)
+
+	_, err = Argon2id([]byte("password"), 1, 64, 0)
+	require.Error(t, err)
+
+	_, err = Argon2id([]byte("password"), 1, 8, 2)
+	require.Error(t, err)
+}
+
+func TestArgon2idEncode(t *testing.T) {
+	t.Parallel()
+
+	out := argon2idEncode([]byte("password"), []byte("somesalt"), 2, 64, 1)
+	assert.True(t, strings.HasPrefix(out, "$argon2id$v=19$m=64,t=2,p=1$c29tZXNhbHQ$"), out)
+
+	// deterministic for the same salt
+	assert.Equal(t, out, argon2idEncode([]byte("password"), []byte("somesalt"), 2, 64, 1))
+}
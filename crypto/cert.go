@@ -0,0 +1,181 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+)
+
+// CertOptions - options for generating certificates
+type CertOptions struct {
+	// CommonName - the subject's common name (CN)
+	CommonName string
+	// Organization - the subject's organization (O)
+	Organization []string
+	// SANs - subject alternative names - IP addresses are detected
+	// automatically, and all other names are treated as DNS names
+	SANs []string
+	// TTL - how long the certificate is valid for
+	TTL time.Duration
+}
+
+// Certificate - a PEM-encoded certificate, along with its PEM-encoded private
+// key
+type Certificate struct {
+	Cert string
+	Key  string
+}
+
+// GenerateCA - generate a self-signed certificate authority (CA) certificate,
+// with a new ECDSA P-256 key, which can be used to sign other certificates.
+func GenerateCA(opts CertOptions) (*Certificate, error) {
+	tmpl, err := certTemplate(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl.IsCA = true
+	tmpl.BasicConstraintsValid = true
+	tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+
+	return createCert(tmpl, nil, nil)
+}
+
+// GenerateCert - generate a TLS certificate, with a new ECDSA P-256 key,
+// usable for both server and client authentication. When ca is nil, the
+// certificate is self-signed, otherwise it's signed by the given CA.
+func GenerateCert(opts CertOptions, ca *Certificate) (*Certificate, error) {
+	tmpl, err := certTemplate(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+
+	for _, san := range opts.SANs {
+		if ip := net.ParseIP(san); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else {
+			tmpl.DNSNames = append(tmpl.DNSNames, san)
+		}
+	}
+
+	if ca == nil {
+		return createCert(tmpl, nil, nil)
+	}
+
+	parent, signer, err := parseCA(ca)
+	if err != nil {
+		return nil, err
+	}
+
+	return createCert(tmpl, parent, signer)
+}
+
+func certTemplate(opts CertOptions) (*x509.Certificate, error) {
+	if opts.TTL <= 0 {
+		return nil, fmt.Errorf("certificate TTL must be positive, got %s", opts.TTL)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	now := time.Now()
+
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   opts.CommonName,
+			Organization: opts.Organization,
+		},
+		NotBefore: now.Add(-time.Minute),
+		NotAfter:  now.Add(opts.TTL),
+	}, nil
+}
+
+// createCert creates a certificate from the template with a new key, signed
+// by the parent certificate's key, or self-signed when parent is nil
+func createCert(tmpl, parent *x509.Certificate, signer crypto.Signer) (*Certificate, error) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ECDSA private key: %w", err)
+	}
+
+	if parent == nil {
+		parent = tmpl
+		signer = priv
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, priv.Public(), signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal private key: %w", err)
+	}
+
+	return &Certificate{
+		Cert: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		Key:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})),
+	}, nil
+}
+
+// parseCA parses the CA certificate and private key
+func parseCA(ca *Certificate) (*x509.Certificate, crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(ca.Cert))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, nil, fmt.Errorf("failed to read CA certificate: no certificate found")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+
+	if !cert.IsCA {
+		return nil, nil, fmt.Errorf("certificate %q is not a CA", cert.Subject.CommonName)
+	}
+
+	block, _ = pem.Decode([]byte(ca.Key))
+	if block == nil {
+		return nil, nil, fmt.Errorf("failed to read CA key: no key found")
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse CA key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported CA key type %T", key)
+	}
+
+	// all public key types in the standard library implement Equal
+	if pub, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool }); ok && !pub.Equal(cert.PublicKey) {
+		return nil, nil, fmt.Errorf("CA key does not match CA certificate")
+	}
+
+	return cert, signer, nil
+}
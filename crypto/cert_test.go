@@ -0,0 +1,103 @@
+package crypto
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseTestCert(t *testing.T, in string) *x509.Certificate {
+	t.Helper()
+
+	block, _ := pem.Decode([]byte(in))
+	require.NotNil(t, block)
+	require.Equal(t, "CERTIFICATE", block.Type)
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	return cert
+}
+
+func TestGenerateCA(t *testing.T) {
+	t.Parallel()
+
+	_, err := GenerateCA(CertOptions{CommonName: "Test CA"})
+	require.Error(t, err)
+
+	ca, err := GenerateCA(CertOptions{
+		CommonName:   "Test CA",
+		Organization: []string{"Example"},
+		TTL:          24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	cert := parseTestCert(t, ca.Cert)
+	assert.True(t, cert.IsCA)
+	assert.Equal(t, "Test CA", cert.Subject.CommonName)
+	assert.Equal(t, []string{"Example"}, cert.Subject.Organization)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), cert.NotAfter, time.Minute)
+	require.NoError(t, cert.CheckSignatureFrom(cert))
+
+	block, _ := pem.Decode([]byte(ca.Key))
+	require.NotNil(t, block)
+	assert.Equal(t, "PRIVATE KEY", block.Type)
+}
+
+func TestGenerateCert(t *testing.T) {
+	t.Parallel()
+
+	opts := CertOptions{
+		CommonName: "example.com",
+		SANs:       []string{"example.com", "*.example.com", "127.0.0.1", "::1"},
+		TTL:        time.Hour,
+	}
+
+	// self-signed
+	c, err := GenerateCert(opts, nil)
+	require.NoError(t, err)
+
+	cert := parseTestCert(t, c.Cert)
+	assert.False(t, cert.IsCA)
+	assert.Equal(t, []string{"example.com", "*.example.com"}, cert.DNSNames)
+	require.Len(t, cert.IPAddresses, 2)
+	assert.True(t, cert.IPAddresses[0].Equal(net.ParseIP("127.0.0.1")))
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}, cert.ExtKeyUsage)
+
+	// signed by a CA
+	ca, err := GenerateCA(CertOptions{CommonName: "Test CA", TTL: 24 * time.Hour})
+	require.NoError(t, err)
+
+	c, err = GenerateCert(opts, ca)
+	require.NoError(t, err)
+
+	cert = parseTestCert(t, c.Cert)
+	caCert := parseTestCert(t, ca.Cert)
+	assert.Equal(t, "Test CA", cert.Issuer.CommonName)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+	_, err = cert.Verify(x509.VerifyOptions{Roots: roots, DNSName: "www.example.com"})
+	require.NoError(t, err)
+
+	// a non-CA certificate can't sign
+	_, err = GenerateCert(opts, c)
+	require.Error(t, err)
+
+	// the CA key must match the certificate
+	other, err := GenerateCA(CertOptions{CommonName: "Other CA", TTL: time.Hour})
+	require.NoError(t, err)
+	_, err = GenerateCert(opts, &Certificate{Cert: ca.Cert, Key: other.Key})
+	require.Error(t, err)
+
+	_, err = GenerateCert(opts, &Certificate{Cert: "bogus", Key: ca.Key})
+	require.Error(t, err)
+
+	_, err = GenerateCert(opts, &Certificate{Cert: ca.Cert, Key: "bogus"})
+	require.Error(t, err)
+}
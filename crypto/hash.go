@@ -0,0 +1,56 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"fmt"
+	"io"
+)
+
+// Blake2b - compute a BLAKE2b checksum (as defined in RFC 7693), with the
+// given digest size in bits (256, 384, or 512)
+func Blake2b(bits int, input []byte) ([]byte, error) {
+	var h crypto.Hash
+	switch bits {
+	case 256:
+		h = crypto.BLAKE2b_256
+	case 384:
+		h = crypto.BLAKE2b_384
+	case 512:
+		h = crypto.BLAKE2b_512
+	default:
+		return nil, fmt.Errorf("unsupported BLAKE2b size %d, must be 256, 384, or 512", bits)
+	}
+
+	hf := hashFuncs[h]()
+	hf.Write(input)
+	return hf.Sum(nil), nil
+}
+
+// HMAC - compute a keyed-hash message authentication code (as defined in
+// RFC 2104) with the given hash function
+func HMAC(hashFunc crypto.Hash, key, input []byte) ([]byte, error) {
+	h, ok := hashFuncs[hashFunc]
+	if !ok {
+		return nil, fmt.Errorf("hashFunc not supported: %v", hashFunc)
+	}
+
+	mac := hmac.New(h, key)
+	mac.Write(input)
+	return mac.Sum(nil), nil
+}
+
+// HashReader - compute a checksum of all data read from r with the given hash
+// function, without buffering it all in memory
+func HashReader(hashFunc crypto.Hash, r io.Reader) ([]byte, error) {
+	h, ok := hashFuncs[hashFunc]
+	if !ok {
+		return nil, fmt.Errorf("hashFunc not supported: %v", hashFunc)
+	}
+
+	hf := h()
+	if _, err := io.Copy(hf, r); err != nil {
+		return nil, fmt.Errorf("read failed: %w", err)
+	}
+	return hf.Sum(nil), nil
+}
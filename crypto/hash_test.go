@@ -0,0 +1,70 @@
+package crypto
+
+import (
+	"crypto"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlake2b(t *testing.T) {
+	t.Parallel()
+
+	// RFC 7693 test vector
+	out, err := Blake2b(512, []byte("abc"))
+	require.NoError(t, err)
+	assert.Equal(t, "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923", hex.EncodeToString(out))
+
+	out, err = Blake2b(384, []byte("abc"))
+	require.NoError(t, err)
+	assert.Equal(t, "6f56a82c8e7ef526dfe182eb5212f7db9df1317e57815dbda46083fc30f54ee6c66ba83be64b302d7cba6ce15bb556f4", hex.EncodeToString(out))
+
+	out, err = Blake2b(256, []byte("abc"))
+	require.NoError(t, err)
+	assert.Equal(t, "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319", hex.EncodeToString(out))
+
+	_, err = Blake2b(128, []byte("abc"))
+	require.Error(t, err)
+}
+
+func TestHMAC(t *testing.T) {
+	t.Parallel()
+
+	// RFC 4231 test case 2
+	out, err := HMAC(crypto.SHA256, []byte("Jefe"), []byte("what do ya want for nothing?"))
+	require.NoError(t, err)
+	assert.Equal(t, "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", hex.EncodeToString(out))
+
+	out, err = HMAC(crypto.SHA1, []byte("Jefe"), []byte("what do ya want for nothing?"))
+	require.NoError(t, err)
+	assert.Equal(t, "effcdf6ae5eb2fa2d27416d5f184df9c259a7c79", hex.EncodeToString(out))
+
+	out, err = HMAC(crypto.BLAKE2b_256, []byte("key"), []byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, "7d87249e1e79d419a9128d43e81b7f3de24b035b119cf042e403025e429d5bed", hex.EncodeToString(out))
+
+	_, err = HMAC(crypto.MD5, []byte("key"), []byte("hello"))
+	require.Error(t, err)
+}
+
+func TestHashReader(t *testing.T) {
+	t.Parallel()
+
+	out, err := HashReader(crypto.SHA256, strings.NewReader("hello world\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "a948904f2f0f479b8f8197694b30184b0d2ed1c1cd2a1ec0fb85d299a192a447", hex.EncodeToString(out))
+
+	out, err = HashReader(crypto.BLAKE2b_256, strings.NewReader("hello world\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "c71b05fd1d1c7bf7e928ff18e58db5193e9316416cc26ba9cc9094da80d7011e", hex.EncodeToString(out))
+
+	_, err = HashReader(crypto.MD5, strings.NewReader("hello"))
+	require.Error(t, err)
+
+	_, err = HashReader(crypto.SHA256, iotest.ErrReader(assert.AnError))
+	require.ErrorIs(t, err, assert.AnError)
+}
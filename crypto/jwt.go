@@ -0,0 +1,158 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTSign - create a JSON Web Token (RFC 7519) with the given claims, signed
+// with the given algorithm (such as HS256, RS256, ES256, or EdDSA). For HMAC
+// algorithms, the key is the shared secret, otherwise it must be a PEM-encoded
+// private key of the appropriate type.
+//
+// Claims which are time.Time values are converted to numeric dates (seconds
+// since the epoch), as is required for standard claims like "exp".
+func JWTSign(alg string, key []byte, claims map[string]interface{}) (string, error) {
+	method := jwt.GetSigningMethod(alg)
+	if method == nil || alg == "none" {
+		return "", fmt.Errorf("unsupported JWT signing algorithm %q", alg)
+	}
+
+	k, err := jwtSigningKey(method, key)
+	if err != nil {
+		return "", err
+	}
+
+	c := make(jwt.MapClaims, len(claims))
+	for name, v := range claims {
+		if t, ok := v.(time.Time); ok {
+			v = t.Unix()
+		}
+		c[name] = v
+	}
+
+	s, err := jwt.NewWithClaims(method, c).SignedString(k)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT: %w", err)
+	}
+
+	return s, nil
+}
+
+// JWTVerify - verify the signature of the given JSON Web Token, which must use
+// the given algorithm, and return its claims. Time-based claims ("exp", "nbf",
+// and "iat") are also validated, when present. For HMAC algorithms, the key is
+// the shared secret, otherwise it must be a PEM-encoded public key (or
+// certificate, or private key) of the appropriate type.
+func JWTVerify(alg string, key []byte, token string) (map[string]interface{}, error) {
+	method := jwt.GetSigningMethod(alg)
+	if method == nil || alg == "none" {
+		return nil, fmt.Errorf("unsupported JWT signing algorithm %q", alg)
+	}
+
+	k, err := jwtVerificationKey(method, key)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return k, nil
+	}, jwt.WithValidMethods([]string{alg}), jwt.WithIssuedAt())
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT: %w", err)
+	}
+
+	return claims, nil
+}
+
+// JWTDecode - decode the header and claims of the given JSON Web Token,
+// _without_ verifying it
+func JWTDecode(token string) (header, claims map[string]interface{}, err error) {
+	c := jwt.MapClaims{}
+	t, _, err := jwt.NewParser().ParseUnverified(token, c)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode JWT: %w", err)
+	}
+
+	return t.Header, c, nil
+}
+
+func jwtSigningKey(method jwt.SigningMethod, key []byte) (interface{}, error) {
+	var (
+		k   interface{}
+		err error
+	)
+
+	switch method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if len(key) == 0 {
+			return nil, fmt.Errorf("JWT signing key must not be empty")
+		}
+		return key, nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		k, err = jwt.ParseRSAPrivateKeyFromPEM(key)
+	case *jwt.SigningMethodECDSA:
+		k, err = jwt.ParseECPrivateKeyFromPEM(key)
+	case *jwt.SigningMethodEd25519:
+		k, err = jwt.ParseEdPrivateKeyFromPEM(key)
+	default:
+		return nil, fmt.Errorf("unsupported JWT signing algorithm %q", method.Alg())
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s signing key: %w", method.Alg(), err)
+	}
+
+	return k, nil
+}
+
+func jwtVerificationKey(method jwt.SigningMethod, key []byte) (interface{}, error) {
+	var (
+		k   interface{}
+		err error
+	)
+
+	// public keys are preferred, but private keys are also accepted
+	switch method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if len(key) == 0 {
+			return nil, fmt.Errorf("JWT verification key must not be empty")
+		}
+		return key, nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		k, err = jwt.ParseRSAPublicKeyFromPEM(key)
+		if err != nil {
+			if priv, perr := jwt.ParseRSAPrivateKeyFromPEM(key); perr == nil {
+				k, err = &priv.PublicKey, nil
+			}
+		}
+	case *jwt.SigningMethodECDSA:
+		k, err = jwt.ParseECPublicKeyFromPEM(key)
+		if err != nil {
+			if priv, perr := jwt.ParseECPrivateKeyFromPEM(key); perr == nil {
+				k, err = &priv.PublicKey, nil
+			}
+		}
+	case *jwt.SigningMethodEd25519:
+		k, err = jwt.ParseEdPublicKeyFromPEM(key)
+		if err != nil {
+			if priv, perr := jwt.ParseEdPrivateKeyFromPEM(key); perr == nil {
+				if edPriv, ok := priv.(ed25519.PrivateKey); ok {
+					k, err = edPriv.Public(), nil
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported JWT signing algorithm %q", method.Alg())
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s verification key: %w", method.Alg(), err)
+	}
+
+	return k, nil
+}
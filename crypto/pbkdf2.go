@@ -7,8 +7,10 @@ import (
 	"crypto/sha512"
 	"fmt"
 	"hash"
+	"strings"
 	"sync"
 
+	"golang.org/x/crypto/blake2b"
 	"golang.org/x/crypto/pbkdf2"
 )
 
@@ -21,13 +23,25 @@ var hashFuncs = sync.OnceValue[map[crypto.Hash]func() hash.Hash](func() map[cryp
 	h[crypto.SHA512] = sha512.New
 	h[crypto.SHA512_224] = sha512.New512_224
 	h[crypto.SHA512_256] = sha512.New512_256
+	h[crypto.BLAKE2b_256] = unkeyedBlake2b(blake2b.New256)
+	h[crypto.BLAKE2b_384] = unkeyedBlake2b(blake2b.New384)
+	h[crypto.BLAKE2b_512] = unkeyedBlake2b(blake2b.New512)
 
 	return h
 })()
 
-// StrToHash - find a hash given a certain string
+// unkeyedBlake2b adapts a BLAKE2b constructor to a plain hash constructor - it
+// never fails when no key is given
+func unkeyedBlake2b(f func(key []byte) (hash.Hash, error)) func() hash.Hash {
+	return func() hash.Hash {
+		h, _ := f(nil)
+		return h
+	}
+}
+
+// StrToHash - find a hash given a certain string (case-insensitive)
 func StrToHash(hash string) (crypto.Hash, error) {
-	switch hash {
+	switch strings.ToUpper(hash) {
 	case "SHA1", "SHA-1":
 		return crypto.SHA1, nil
 	case "SHA224", "SHA-224":
@@ -42,6 +56,12 @@ func StrToHash(hash string) (crypto.Hash, error) {
 		return crypto.SHA512_224, nil
 	case "SHA512_256", "SHA512/256", "SHA-512_256", "SHA-512/256":
 		return crypto.SHA512_256, nil
+	case "BLAKE2B_256", "BLAKE2B-256":
+		return crypto.BLAKE2b_256, nil
+	case "BLAKE2B_384", "BLAKE2B-384":
+		return crypto.BLAKE2b_384, nil
+	case "BLAKE2B", "BLAKE2B_512", "BLAKE2B-512":
+		return crypto.BLAKE2b_512, nil
 	}
 	return 0, fmt.Errorf("no such hash %s", hash)
 }
@@ -85,4 +85,16 @@ func TestStrToHash(t *testing.T) {
 	h, err = StrToHash("SHA512/256")
 	assert.Equal(t, crypto.SHA512_256, h)
 	require.NoError(t, err)
+	h, err = StrToHash("sha256")
+	assert.Equal(t, crypto.SHA256, h)
+	require.NoError(t, err)
+	h, err = StrToHash("BLAKE2b-256")
+	assert.Equal(t, crypto.BLAKE2b_256, h)
+	require.NoError(t, err)
+	h, err = StrToHash("blake2b_384")
+	assert.Equal(t, crypto.BLAKE2b_384, h)
+	require.NoError(t, err)
+	h, err = StrToHash("BLAKE2b")
+	assert.Equal(t, crypto.BLAKE2b_512, h)
+	require.NoError(t, err)
 }
@@ -0,0 +1,35 @@
+package crypto
+
+import (
+	"encoding/pem"
+	"fmt"
+)
+
+// PEMEncode - encode the given data as a PEM block of the given type (e.g.
+// "CERTIFICATE" or "PRIVATE KEY"), with optional headers
+func PEMEncode(blockType string, headers map[string]string, data []byte) ([]byte, error) {
+	if blockType == "" {
+		return nil, fmt.Errorf("PEM block type must not be empty")
+	}
+
+	b := pem.EncodeToMemory(&pem.Block{
+		Type:    blockType,
+		Headers: headers,
+		Bytes:   data,
+	})
+	if b == nil {
+		return nil, fmt.Errorf("invalid PEM headers")
+	}
+
+	return b, nil
+}
+
+// PEMDecode - decode the first PEM block found in the input
+func PEMDecode(in []byte) (*pem.Block, error) {
+	block, _ := pem.Decode(in)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+
+	return block, nil
+}
@@ -0,0 +1,38 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPEMEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	out, err := PEMEncode("TEST DATA", nil, []byte("hello world"))
+	require.NoError(t, err)
+	assert.Equal(t, "-----BEGIN TEST DATA-----\naGVsbG8gd29ybGQ=\n-----END TEST DATA-----\n", string(out))
+
+	block, err := PEMDecode(append([]byte("leading junk\n"), out...))
+	require.NoError(t, err)
+	assert.Equal(t, "TEST DATA", block.Type)
+	assert.Equal(t, []byte("hello world"), block.Bytes)
+
+	out, err = PEMEncode("TEST DATA", map[string]string{"Proc-Type": "4,ENCRYPTED"}, []byte("hi"))
+	require.NoError(t, err)
+	assert.Equal(t, "-----BEGIN TEST DATA-----\nProc-Type: 4,ENCRYPTED\n\naGk=\n-----END TEST DATA-----\n", string(out))
+
+	block, err = PEMDecode(out)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Proc-Type": "4,ENCRYPTED"}, block.Headers)
+
+	_, err = PEMEncode("", nil, []byte("hi"))
+	require.Error(t, err)
+
+	_, err = PEMEncode("TEST", map[string]string{"bad:key": "v"}, []byte("hi"))
+	require.Error(t, err)
+
+	_, err = PEMDecode([]byte("not PEM"))
+	require.Error(t, err)
+}
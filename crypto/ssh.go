@@ -0,0 +1,209 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// SSHGenerateKey - generate a new SSH private key, encoded in the OpenSSH
+// private key format. The key type must be one of "ed25519", "rsa", or
+// "ecdsa". For RSA keys, bits is the key size (default 4096), and for ECDSA
+// keys it is the curve size - 256 (the default), 384, or 521. It is ignored
+// for Ed25519 keys.
+func SSHGenerateKey(keyType string, bits int) ([]byte, error) {
+	var (
+		key interface{}
+		err error
+	)
+
+	switch strings.ToLower(keyType) {
+	case "ed25519":
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	case "rsa":
+		if bits == 0 {
+			bits = 4096
+		}
+		key, err = rsa.GenerateKey(rand.Reader, bits)
+	case "ecdsa":
+		var curve elliptic.Curve
+		switch bits {
+		case 0, 256:
+			curve = elliptic.P256()
+		case 384:
+			curve = elliptic.P384()
+		case 521:
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA key size %d: must be 256, 384, or 521", bits)
+		}
+		key, err = ecdsa.GenerateKey(curve, rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported SSH key type %q: must be ed25519, rsa, or ecdsa", keyType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate %s key: %w", keyType, err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal SSH private key: %w", err)
+	}
+
+	return pem.EncodeToMemory(block), nil
+}
+
+// SSHPublicKey - return the public key for the given key as a single line in
+// the OpenSSH authorized_keys format ("<type> <base64> [comment]").
+//
+// The key can be an unencrypted private key (in the OpenSSH, PKCS#1, PKCS#8,
+// or SEC 1 formats), a PEM-encoded public key or certificate, or an existing
+// OpenSSH public key. When comment is empty, the existing key's comment (if
+// any) is kept.
+func SSHPublicKey(key []byte, comment string) (string, error) {
+	pub, c, err := parseSSHPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	if comment == "" {
+		comment = c
+	}
+
+	return authorizedKeyLine(pub, comment), nil
+}
+
+// SSHAuthorizedKey - format an authorized_keys entry for the given key, with
+// the given options (such as "no-pty" or `from="10.0.0.0/8"`). See
+// SSHPublicKey for the supported key formats.
+func SSHAuthorizedKey(options []string, key []byte) (string, error) {
+	pub, comment, err := parseSSHPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	line := authorizedKeyLine(pub, comment)
+	if len(options) == 0 {
+		return line, nil
+	}
+
+	for _, o := range options {
+		if o == "" || strings.ContainsAny(o, "\r\n") {
+			return "", fmt.Errorf("invalid authorized_keys option %q", o)
+		}
+	}
+
+	return strings.Join(options, ",") + " " + line, nil
+}
+
+// SSHKnownHosts - format a known_hosts entry for the given hosts and key.
+// Hosts may be given as "host" or "host:port", and are normalized the same way
+// OpenSSH does. When hash is true, the host names are hashed (like with
+// 'ssh-keygen -H'), in which case one line is output per host.
+func SSHKnownHosts(hosts []string, key []byte, hash bool) (string, error) {
+	if len(hosts) == 0 {
+		return "", fmt.Errorf("at least one host is required")
+	}
+
+	pub, _, err := parseSSHPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	if !hash {
+		return knownhosts.Line(hosts, pub), nil
+	}
+
+	k := authorizedKeyLine(pub, "")
+	lines := make([]string, len(hosts))
+	for i, h := range hosts {
+		lines[i] = knownhosts.HashHostname(knownhosts.Normalize(h)) + " " + k
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// SSHFingerprint - return the SHA256 fingerprint of the given key, in the
+// format used by OpenSSH ("SHA256:<base64>"). See SSHPublicKey for the
+// supported key formats.
+func SSHFingerprint(key []byte) (string, error) {
+	pub, _, err := parseSSHPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	return ssh.FingerprintSHA256(pub), nil
+}
+
+func authorizedKeyLine(pub ssh.PublicKey, comment string) string {
+	line := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pub)))
+	if comment != "" {
+		line += " " + comment
+	}
+
+	return line
+}
+
+// parseSSHPublicKey extracts the public key (and comment, if any) from an
+// OpenSSH public key, a private key, or a PEM-encoded public key or
+// certificate.
+func parseSSHPublicKey(key []byte) (ssh.PublicKey, string, error) {
+	if pub, comment, _, _, err := ssh.ParseAuthorizedKey(key); err == nil {
+		return pub, comment, nil
+	}
+
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, "", fmt.Errorf("failed to read key: no SSH or PEM-encoded key found")
+	}
+
+	var (
+		pk  interface{}
+		err error
+	)
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		pk, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		pk, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		cert, err = x509.ParseCertificate(block.Bytes)
+		if err == nil {
+			pk = cert.PublicKey
+		}
+	default:
+		var priv interface{}
+		priv, err = ssh.ParseRawPrivateKey(key)
+		if err == nil {
+			signer, ok := priv.(crypto.Signer)
+			if !ok {
+				return nil, "", fmt.Errorf("unsupported private key type %T", priv)
+			}
+			pk = signer.Public()
+		}
+	}
+
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s: %w", strings.ToLower(block.Type), err)
+	}
+
+	pub, err := ssh.NewPublicKey(pk)
+	if err != nil {
+		return nil, "", fmt.Errorf("unsupported SSH key: %w", err)
+	}
+
+	return pub, "", nil
+}
@@ -0,0 +1,239 @@
+package data
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+)
+
+// cachedContent returns the cached content for the given key (see
+// contentCacheKey), if present. When the in-process cache misses, the on-disk
+// cache (if configured and persist is true) is checked. Expired content is still returned (with fresh set to
+// false) so that it can be revalidated with a conditional request. When a
+// refresh is requested in the context, content from the on-disk cache is
+// always treated as expired.
+func (d *Data) cachedContent(ctx context.Context, key string, persist bool) (fc *fileContent, fresh bool) {
+	d.cacheMu.Lock()
+	defer d.cacheMu.Unlock()
+
+	if d.cache == nil {
+		d.cache = make(map[string]*fileContent)
+	}
+
+	if fc, ok := d.cache[key]; ok {
+		return fc, !d.expired(fc)
+	}
+
+	if d.CacheDir == "" || !persist {
+		return nil, false
+	}
+
+	fc, err := readDiskCache(d.CacheDir, key)
+	if err != nil {
+		return nil, false
+	}
+
+	d.cache[key] = fc
+
+	return fc, !d.expired(fc) && !config.DatasourceRefresh(ctx)
+}
+
+// storeContent caches the content for the given key, also writing it to the
+// on-disk cache if configured and persist is true
+func (d *Data) storeContent(key string, persist bool, fc *fileContent) error {
+	fc.fetched = time.Now()
+
+	d.cacheMu.Lock()
+	if d.cache == nil {
+		d.cache = make(map[string]*fileContent)
+	}
+	d.cache[key] = fc
+	d.cacheMu.Unlock()
+
+	if d.CacheDir == "" || !persist {
+		return nil
+	}
+
+	return writeDiskCache(d.CacheDir, key, fc)
+}
+
+// expired returns true when the content was fetched longer ago than the
+// cache TTL. With no TTL, content is cached for the whole run.
+func (d *Data) expired(fc *fileContent) bool {
+	return d.CacheTTL > 0 && time.Since(fc.fetched) > d.CacheTTL
+}
+
+// diskCacheable returns true for schemes whose content may be persisted in the
+// on-disk cache. Secret stores and local sources are never written to disk.
+func diskCacheable(scheme string) bool {
+	switch scheme {
+	case "http", "https", "s3", "gs", "azblob", "oci", "oci+http",
+		"webdav+http", "webdav+https", "ftp", "sftp":
+		return true
+	default:
+		return false
+	}
+}
+
+// persistable returns true when content read from the given URL with the given
+// request headers may be written to the on-disk cache. Content fetched with
+// credentials is only ever cached in memory, so that it's not readable by
+// other users or later runs with different credentials.
+func persistable(u *url.URL, hdr http.Header) bool {
+	if !diskCacheable(u.Scheme) {
+		return false
+	}
+
+	if _, ok := u.User.Password(); ok {
+		return false
+	}
+
+	for k := range hdr {
+		if credentialHeader(k) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// credentialHeader returns true for request headers that usually carry
+// credentials
+func credentialHeader(name string) bool {
+	name = strings.ToLower(name)
+
+	switch name {
+	case "authorization", "proxy-authorization", "cookie":
+		return true
+	}
+
+	for _, s := range []string{"auth", "token", "secret", "api-key", "apikey", "password"} {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contentCacheKey returns the cache key for content read from the given URL
+// with the given request headers. Headers can change the response, so a hash
+// of the headers is part of the key.
+func contentCacheKey(u *url.URL, hdr http.Header) string {
+	if len(hdr) == 0 {
+		return u.String()
+	}
+
+	canonical := make(map[string][]string, len(hdr))
+	for k, v := range hdr {
+		k = http.CanonicalHeaderKey(k)
+		canonical[k] = append(canonical[k], v...)
+	}
+
+	keys := make([]string, 0, len(canonical))
+	for k := range canonical {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s: %q\n", k, canonical[k])
+	}
+
+	return u.String() + " " + hex.EncodeToString(h.Sum(nil))
+}
+
+type diskCacheEntry struct {
+	Fetched      time.Time `json:"fetched"`
+	ContentType  string    `json:"contentType"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Body         []byte    `json:"body"`
+}
+
+func diskCachePath(dir, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func readDiskCache(dir, key string) (*fileContent, error) {
+	b, err := os.ReadFile(diskCachePath(dir, key))
+	if err != nil {
+		return nil, err
+	}
+
+	entry := diskCacheEntry{}
+
+	err = json.Unmarshal(b, &entry)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache entry: %w", err)
+	}
+
+	return &fileContent{
+		contentType:  entry.ContentType,
+		b:            entry.Body,
+		fetched:      entry.Fetched,
+		etag:         entry.ETag,
+		lastModified: entry.LastModified,
+	}, nil
+}
+
+// writeDiskCache writes the cache entry atomically, so that concurrent runs
+// never see partial entries
+func writeDiskCache(dir, key string, fc *fileContent) error {
+	err := os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return fmt.Errorf("create datasource cache dir: %w", err)
+	}
+
+	b, err := json.Marshal(diskCacheEntry{
+		Fetched:      fc.fetched,
+		ContentType:  fc.contentType,
+		ETag:         fc.etag,
+		LastModified: fc.lastModified,
+		Body:         fc.b,
+	})
+	if err != nil {
+		return err
+	}
+
+	// entries may hold sensitive content, so must only be readable by the
+	// current user
+	f, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("write datasource cache: %w", err)
+	}
+
+	err = f.Chmod(0o600)
+	if err == nil {
+		_, err = f.Write(b)
+	}
+
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err == nil {
+		err = os.Rename(f.Name(), diskCachePath(dir, key))
+	}
+
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return fmt.Errorf("write datasource cache: %w", err)
+	}
+
+	return nil
+}
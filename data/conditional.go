@@ -0,0 +1,115 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// conditionalFetchable returns true when content from the URL can be
+// revalidated with a conditional request, rather than fetched in full. This is
+// only done when the on-disk cache is enabled, as otherwise there's nothing to
+// revalidate across runs.
+func (d *Data) conditionalFetchable(u *url.URL) bool {
+	return d.CacheDir != "" && (u.Scheme == "http" || u.Scheme == "https")
+}
+
+// revalidation tracks the cache validators for a single HTTP datasource read.
+// The validators from a stale cache entry (prev) are sent with the request, and
+// those in the response are recorded so they can be cached with the content.
+type revalidation struct {
+	prev         *fileContent
+	etag         string
+	lastModified string
+}
+
+type revalidationCtxKey struct{}
+
+func contextWithRevalidation(ctx context.Context, rv *revalidation) context.Context {
+	return context.WithValue(ctx, revalidationCtxKey{}, rv)
+}
+
+func revalidationFromContext(ctx context.Context) *revalidation {
+	rv, _ := ctx.Value(revalidationCtxKey{}).(*revalidation)
+	return rv
+}
+
+// client returns a copy of the given HTTP client (or the default client), with
+// a transport that makes conditional requests
+func (rv *revalidation) client(base *http.Client) *http.Client {
+	if base == nil {
+		base = http.DefaultClient
+	}
+
+	next := base.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	c := *base
+	c.Transport = &revalidatingTransport{next: next, rv: rv}
+
+	return &c
+}
+
+// revalidatingTransport sends conditional GET requests when validators are
+// known from a previous (stale) cache entry. When the server responds with
+// 304 Not Modified, the previous content is returned as if it had been
+// fetched again, so that callers don't need to handle conditional requests.
+type revalidatingTransport struct {
+	next http.RoundTripper
+	rv   *revalidation
+}
+
+func (t *revalidatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.next.RoundTrip(req)
+	}
+
+	prev := t.rv.prev
+	if prev != nil && (prev.etag != "" || prev.lastModified != "") {
+		// RoundTrippers must not modify the request
+		req = req.Clone(req.Context())
+
+		if prev.etag != "" {
+			req.Header.Set("If-None-Match", prev.etag)
+		}
+
+		if prev.lastModified != "" {
+			req.Header.Set("If-Modified-Since", prev.lastModified)
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	t.rv.etag = resp.Header.Get("ETag")
+	t.rv.lastModified = resp.Header.Get("Last-Modified")
+
+	if resp.StatusCode != http.StatusNotModified || prev == nil {
+		return resp, nil
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if t.rv.etag == "" {
+		t.rv.etag = prev.etag
+	}
+
+	if t.rv.lastModified == "" {
+		t.rv.lastModified = prev.lastModified
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Status = strconv.Itoa(http.StatusOK) + " " + http.StatusText(http.StatusOK)
+	resp.Body = io.NopCloser(bytes.NewReader(prev.b))
+	resp.ContentLength = int64(len(prev.b))
+
+	return resp, nil
+}
@@ -0,0 +1,215 @@
+package data
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/hairyhenderson/go-fsimpl/httpfs"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionalFetch(t *testing.T) {
+	hits, notModified := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// only count reads, not the HEAD requests used to stat the file
+		if r.Method == http.MethodGet {
+			hits++
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		if r.Method == http.MethodGet && r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", jsonMimetype)
+		w.Write([]byte(`{"foo": "bar"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	fsp := fsimpl.NewMux()
+	fsp.Add(httpfs.FS)
+
+	ctx := datafs.ContextWithFSProvider(context.Background(), fsp)
+
+	dir := t.TempDir()
+	newData := func() *Data {
+		return FromConfig(ctx, &config.Config{
+			DataSources: map[string]config.DataSource{
+				"foo": {URL: mustParseURL(srv.URL + "/foo.json")},
+			},
+			DatasourceCacheTTL: time.Minute,
+			DatasourceCacheDir: dir,
+		})
+	}
+
+	d := newData()
+	out, err := d.Datasource("foo")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"foo": "bar"}, out)
+	assert.Equal(t, 1, hits)
+	assert.Equal(t, 0, notModified)
+
+	// expire the entry, so it's revalidated on the next read
+	d.cache[srv.URL+"/foo.json"].fetched = time.Now().Add(-time.Hour)
+
+	out, err = d.Datasource("foo")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"foo": "bar"}, out)
+	assert.Equal(t, 2, hits)
+	assert.Equal(t, 1, notModified)
+
+	// validators are persisted in the on-disk cache too
+	entry, err := readDiskCache(dir, srv.URL+"/foo.json")
+	require.NoError(t, err)
+	assert.Equal(t, `"v1"`, entry.etag)
+
+	d = newData()
+	d.CacheTTL = time.Nanosecond
+
+	out, err = d.Datasource("foo")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"foo": "bar"}, out)
+	assert.Equal(t, 3, hits)
+	assert.Equal(t, 2, notModified)
+
+	// unexpired content from the on-disk cache is used as-is...
+	d = newData()
+
+	out, err = d.Datasource("foo")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"foo": "bar"}, out)
+	assert.Equal(t, 3, hits)
+
+	// ...unless a refresh is requested, when it's revalidated
+	d = newData()
+	d.Ctx = config.SetDatasourceRefresh(ctx)
+
+	out, err = d.Datasource("foo")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"foo": "bar"}, out)
+	assert.Equal(t, 4, hits)
+	assert.Equal(t, 3, notModified)
+
+	// content fetched during the run is still cached
+	out, err = d.Datasource("foo")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"foo": "bar"}, out)
+	assert.Equal(t, 4, hits)
+}
+
+func TestRevalidatingTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+		if r.Header.Get("If-Modified-Since") == "Wed, 21 Oct 2015 07:28:00 GMT" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Write([]byte(`hello`))
+	}))
+	t.Cleanup(srv.Close)
+
+	get := func(t *testing.T, rv *revalidation, hdr http.Header) *http.Response {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+		req.Header = hdr
+
+		resp, err := rv.client(nil).Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+
+		return resp
+	}
+
+	t.Run("no previous content", func(t *testing.T) {
+		rv := &revalidation{}
+		resp := get(t, rv, http.Header{})
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(b))
+		assert.Equal(t, "Wed, 21 Oct 2015 07:28:00 GMT", rv.lastModified)
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		rv := &revalidation{prev: &fileContent{
+			b:            []byte("cached"),
+			etag:         `"v1"`,
+			lastModified: "Wed, 21 Oct 2015 07:28:00 GMT",
+		}}
+
+		// the request's headers must not be modified, as they may be shared
+		hdr := http.Header{}
+		resp := get(t, rv, hdr)
+		assert.Empty(t, hdr)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "cached", string(b))
+
+		// validators missing from the 304 response are kept
+		assert.Equal(t, `"v1"`, rv.etag)
+		assert.Equal(t, "Wed, 21 Oct 2015 07:28:00 GMT", rv.lastModified)
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		rv := &revalidation{prev: &fileContent{
+			b:            []byte("cached"),
+			lastModified: "Tue, 20 Oct 2015 07:28:00 GMT",
+		}}
+		resp := get(t, rv, http.Header{})
+
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(b))
+		assert.Equal(t, "Wed, 21 Oct 2015 07:28:00 GMT", rv.lastModified)
+	})
+}
+
+func TestReadFileContentRevalidation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", jsonMimetype)
+		w.Header().Set("ETag", `"v2"`)
+		if r.Method == http.MethodGet && r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Write([]byte(`{"foo": "baz"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	fsp := fsimpl.NewMux()
+	fsp.Add(httpfs.FS)
+
+	ctx := datafs.ContextWithFSProvider(context.Background(), fsp)
+
+	d := &Data{}
+	u := mustParseURL(srv.URL + "/foo.json")
+
+	rv := &revalidation{prev: &fileContent{b: []byte(`{"foo": "bar"}`), etag: `"v1"`}}
+	fc, err := d.readFileContent(contextWithRevalidation(ctx, rv), u, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{"foo": "bar"}`, string(fc.b))
+	assert.Equal(t, jsonMimetype, fc.contentType)
+	assert.Equal(t, `"v2"`, rv.etag)
+
+	rv = &revalidation{prev: &fileContent{b: []byte(`{"foo": "bar"}`), etag: `"v0"`}}
+	fc, err = d.readFileContent(contextWithRevalidation(ctx, rv), u, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{"foo": "baz"}`, string(fc.b))
+}
@@ -1,6 +1,7 @@
 package data
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -11,12 +12,15 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/hairyhenderson/go-fsimpl"
 	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 	"github.com/hairyhenderson/gomplate/v4/internal/parsers"
 	"github.com/hairyhenderson/gomplate/v4/internal/urlhelpers"
+	"github.com/rs/zerolog"
 )
 
 // Data -
@@ -28,15 +32,35 @@ type Data struct {
 	// TODO: remove this before 4.0
 	Sources map[string]config.DataSource
 
-	cache map[string]*fileContent
+	cache   map[string]*fileContent
+	cacheMu sync.Mutex
+
+	// CacheTTL - how long datasource content is cached for. Zero means content
+	// is cached for the lifetime of the Data value.
+	CacheTTL time.Duration
+	// CacheDir - optional directory to persist cached content in
+	CacheDir string
+
+	// Timeout - the default timeout for each attempt to read a datasource
+	// (zero means no timeout). Can be overridden with the "timeout" query
+	// parameter.
+	Timeout time.Duration
+	// Retries - the default number of times to retry failed reads. Can be
+	// overridden with the "retries" query parameter.
+	Retries int
 
 	// headers from the --datasource-header/-H option that don't reference datasources from the commandline
 	ExtraHeaders map[string]http.Header
 }
 
 type fileContent struct {
+	fetched     time.Time
 	contentType string
 	b           []byte
+
+	// validators for conditional requests, when supported by the source
+	etag         string
+	lastModified string
 }
 
 // NewData - constructor for Data
@@ -70,6 +94,10 @@ func FromConfig(ctx context.Context, cfg *config.Config) *Data {
 		Ctx:          ctx,
 		Sources:      sources,
 		ExtraHeaders: cfg.ExtraHeaders,
+		CacheTTL:     cfg.DatasourceCacheTTL,
+		CacheDir:     cfg.DatasourceCacheDir,
+		Timeout:      cfg.DatasourceTimeout,
+		Retries:      cfg.DatasourceRetries,
 	}
 }
 
@@ -163,7 +191,17 @@ func (d *Data) Datasource(alias string, args ...string) (interface{}, error) {
 		return nil, err
 	}
 
-	return parsers.ParseData(fc.contentType, string(fc.b))
+	out, err := parsers.ParseData(fc.contentType, string(fc.b))
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.validateSchema(d.Ctx, alias, out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
 }
 
 // DatasourceReachable - Determines if the named datasource is reachable with
@@ -180,18 +218,6 @@ func (d *Data) DatasourceReachable(alias string, args ...string) bool {
 // readSource returns the (possibly cached) data from the given source,
 // as referenced by the given args
 func (d *Data) readSource(ctx context.Context, alias string, source *config.DataSource, args ...string) (*fileContent, error) {
-	if d.cache == nil {
-		d.cache = make(map[string]*fileContent)
-	}
-	cacheKey := alias
-	for _, v := range args {
-		cacheKey += v
-	}
-	cached, ok := d.cache[cacheKey]
-	if ok {
-		return cached, nil
-	}
-
 	arg := ""
 	if len(args) > 0 {
 		arg = args[0]
@@ -201,16 +227,34 @@ func (d *Data) readSource(ctx context.Context, alias string, source *config.Data
 		return nil, err
 	}
 
-	fc, err := d.readFileContent(ctx, u, source.Header)
+	cacheKey := contentCacheKey(u, source.Header)
+	persist := persistable(u, source.Header)
+
+	cached, fresh := d.cachedContent(ctx, cacheKey, persist)
+	if fresh {
+		return cached, nil
+	}
+
+	// a stale entry may still be revalidated with a conditional request
+	fc, err := d.readWithRetries(ctx, u, source.Header, cached)
 	if err != nil {
 		return nil, fmt.Errorf("reading %s: %w", u, err)
 	}
-	d.cache[cacheKey] = fc
+
+	fc.contentType = extensionContentType(u, fc.contentType)
+	fc.contentType = sniffContentType(u, fc.contentType, fc.b)
+	fc.contentType = paramContentType(u, fc.contentType)
+
+	// failing to persist the cache shouldn't fail the render
+	if err := d.storeContent(cacheKey, persist, fc); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("couldn't cache datasource content")
+	}
+
 	return fc, nil
 }
 
 // readFileContent returns content from the given URL
-func (d Data) readFileContent(ctx context.Context, u *url.URL, hdr http.Header) (*fileContent, error) {
+func (d *Data) readFileContent(ctx context.Context, u *url.URL, hdr http.Header) (*fileContent, error) {
 	fsys, err := datafs.FSysForPath(ctx, u.String())
 	if err != nil {
 		return nil, fmt.Errorf("fsys for path %v: %w", u, err)
@@ -227,6 +271,20 @@ func (d Data) readFileContent(ctx context.Context, u *url.URL, hdr http.Header)
 	fsys = fsimpl.WithContextFS(ctx, fsys)
 	fsys = fsimpl.WithHeaderFS(hdr, fsys)
 
+	// use the custom HTTP client (if any) for plain HTTP-based datasources
+	// only, as other filesystems may need their own (e.g. authenticated)
+	// clients
+	client := datafs.HTTPClientFromContext(ctx)
+
+	// stale cache entries are revalidated through the same client
+	if rv := revalidationFromContext(ctx); rv != nil {
+		client = rv.client(client)
+	}
+
+	if client != nil && useCustomHTTPClient(u.Scheme) {
+		fsys = fsimpl.WithHTTPClientFS(client, fsys)
+	}
+
 	// convert d.Sources to a map[string]config.DataSources
 	// TODO: remove this when d.Sources is removed
 	ds := make(map[string]config.DataSource)
@@ -253,9 +311,10 @@ func (d Data) readFileContent(ctx context.Context, u *url.URL, hdr http.Header)
 	// possible type hint in the type query param. Contrary to spec, we allow
 	// unescaped '+' characters to make it simpler to provide types like
 	// "application/array+json"
-	mimeType := u.Query().Get("type")
-	mimeType = strings.ReplaceAll(mimeType, " ", "+")
+	typeHint := u.Query().Get("type")
+	typeHint = strings.ReplaceAll(typeHint, " ", "+")
 
+	mimeType := typeHint
 	if mimeType == "" {
 		mimeType = fsimpl.ContentType(fi)
 	}
@@ -286,6 +345,12 @@ func (d Data) readFileContent(ctx context.Context, u *url.URL, hdr http.Header)
 		}
 	}
 
+	// secrets managers often store key/value secrets as JSON objects, but
+	// have no content type - parse them as JSON unless told otherwise
+	if (u.Scheme == "aws+sm" || u.Scheme == "gcp+sm") && typeHint == "" && isJSONObject(data) {
+		mimeType = jsonMimetype
+	}
+
 	if mimeType == "" {
 		// default to text/plain
 		mimeType = textMimetype
@@ -294,6 +359,23 @@ func (d Data) readFileContent(ctx context.Context, u *url.URL, hdr http.Header)
 	return &fileContent{contentType: mimeType, b: data}, nil
 }
 
+// useCustomHTTPClient returns true for schemes read with plain HTTP requests,
+// which should use the client configured with --tls-cert, etc.
+func useCustomHTTPClient(scheme string) bool {
+	switch scheme {
+	case "http", "https", "webdav+http", "webdav+https":
+		return true
+	default:
+		return false
+	}
+}
+
+// isJSONObject returns true if b is a valid JSON document containing an object
+func isJSONObject(b []byte) bool {
+	b = bytes.TrimSpace(b)
+	return len(b) > 0 && b[0] == '{' && json.Valid(b)
+}
+
 // Show all datasources  -
 func (d *Data) ListDatasources() []string {
 	datasources := make([]string, 0, len(d.Sources))
@@ -304,6 +386,20 @@ func (d *Data) ListDatasources() []string {
 	return datasources
 }
 
+// DatasourceURLs returns a map of all defined datasource aliases to their
+// URLs. Passwords in URLs are redacted.
+func (d *Data) DatasourceURLs() map[string]string {
+	urls := make(map[string]string, len(d.Sources))
+	for alias, source := range d.Sources {
+		if source.URL == nil {
+			urls[alias] = ""
+			continue
+		}
+		urls[alias] = source.URL.Redacted()
+	}
+	return urls
+}
+
 // resolveURL parses the relative URL rel against base, and returns the
 // resolved URL. Differs from url.ResolveReference in that query parameters are
 // added. In case of duplicates, params from rel are used.
@@ -1,25 +1,45 @@
 package data
 
 import (
+	"bytes"
+	"encoding/json"
 	"mime"
+	"net/url"
+	"path"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/parsers"
 )
 
 const (
 	textMimetype      = "text/plain"
 	csvMimetype       = "text/csv"
+	tsvMimetype       = "text/tab-separated-values"
 	jsonMimetype      = "application/json"
 	jsonArrayMimetype = "application/array+json"
 	tomlMimetype      = "application/toml"
 	yamlMimetype      = "application/yaml"
 	envMimetype       = "application/x-env"
 	cueMimetype       = "application/cue"
+	xmlMimetype       = "application/xml"
+	iniMimetype       = "application/x-ini"
+	propsMimetype     = "text/x-java-properties"
+	ndjsonMimetype    = "application/x-ndjson"
+	protobufMimetype  = "application/x-protobuf"
+	msgpackMimetype   = "application/msgpack"
+	cborMimetype      = "application/cbor"
+	binaryMimetype    = "application/octet-stream"
 )
 
 // mimeTypeAliases defines a mapping for non-canonical mime types that are
 // sometimes seen in the wild
 var mimeTypeAliases = map[string]string{
-	"application/x-yaml": yamlMimetype,
-	"application/text":   textMimetype,
+	"application/x-yaml":      yamlMimetype,
+	"application/text":        textMimetype,
+	"text/xml":                xmlMimetype,
+	"application/jsonl":       ndjsonMimetype,
+	"application/protobuf":    protobufMimetype,
+	"application/x-msgpack":   msgpackMimetype,
+	"application/vnd.msgpack": msgpackMimetype,
 }
 
 func mimeAlias(m string) string {
@@ -31,3 +51,102 @@ func mimeAlias(m string) string {
 	}
 	return m
 }
+
+// extensionMimetypes maps file extensions to types which aren't commonly
+// registered with the system (and so aren't detected by mime.TypeByExtension)
+var extensionMimetypes = map[string]string{
+	".tsv":        tsvMimetype,
+	".ini":        iniMimetype,
+	".properties": propsMimetype,
+	".ndjson":     ndjsonMimetype,
+	".jsonl":      ndjsonMimetype,
+	".msgpack":    msgpackMimetype,
+	".mpk":        msgpackMimetype,
+	".cbor":       cborMimetype,
+}
+
+// extensionContentType returns the type for the datasource URL's file
+// extension, when no more specific type than text/plain was detected and no
+// type hint was given
+func extensionContentType(u *url.URL, contentType string) string {
+	if mimeAlias(contentType) != textMimetype || u.Query().Get("type") != "" {
+		return contentType
+	}
+
+	if mt, ok := extensionMimetypes[path.Ext(u.Path)]; ok {
+		return mt
+	}
+
+	return contentType
+}
+
+// sniffContentType detects JSON and YAML content in datasources which would
+// otherwise be treated as plain text, because they have no file extension or
+// because a server sent a generic Content-Type. Files with a .txt extension
+// and datasources with an explicit type hint are never sniffed.
+//
+// Only JSON objects and arrays, and YAML documents containing mappings or
+// sequences are detected - anything else is still plain text.
+func sniffContentType(u *url.URL, contentType string, b []byte) string {
+	if mimeAlias(contentType) != textMimetype || u.Query().Get("type") != "" ||
+		path.Ext(u.Path) == ".txt" {
+		return contentType
+	}
+
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 {
+		return contentType
+	}
+
+	if (b[0] == '{' || b[0] == '[') && json.Valid(b) {
+		return jsonMimetype
+	}
+
+	docs, err := parsers.YAMLDocs(string(b))
+	if err != nil || len(docs) == 0 {
+		return contentType
+	}
+
+	for _, doc := range docs {
+		switch doc.(type) {
+		case map[string]interface{}, map[interface{}]interface{}, []interface{}:
+		default:
+			return contentType
+		}
+	}
+
+	return yamlMimetype
+}
+
+// typeQueryParams lists the datasource URL query parameters which are
+// relevant to parsing each content type
+var typeQueryParams = map[string][]string{
+	csvMimetype:      {"header", "delimiter"},
+	tsvMimetype:      {"header", "delimiter"},
+	protobufMimetype: {"descriptor", "message"},
+}
+
+// paramContentType adds the query parameters from the datasource URL which
+// are relevant to the content type (such as "header" and "delimiter" for CSV),
+// as MIME type parameters, so that the parser can use them.
+func paramContentType(u *url.URL, contentType string) string {
+	mt, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+
+	keys, ok := typeQueryParams[mimeAlias(mt)]
+	if !ok {
+		return contentType
+	}
+
+	q := u.Query()
+
+	for _, k := range keys {
+		if q.Has(k) {
+			params[k] = q.Get(k)
+		}
+	}
+
+	return mime.FormatMediaType(mt, params)
+}
@@ -1,6 +1,7 @@
 package data
 
 import (
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,9 +15,82 @@ func TestMimeAlias(t *testing.T) {
 		{csvMimetype, csvMimetype},
 		{yamlMimetype, yamlMimetype},
 		{"application/x-yaml", yamlMimetype},
+		{"application/jsonl", ndjsonMimetype},
 	}
 
 	for _, d := range data {
 		assert.Equal(t, d.out, mimeAlias(d.in))
 	}
 }
+
+func TestParamContentType(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		u, in, out string
+	}{
+		{"file:///foo.json", jsonMimetype, jsonMimetype},
+		{"file:///foo.csv", csvMimetype, csvMimetype},
+		{"file:///foo.csv?header=true", csvMimetype, "text/csv; header=true"},
+		{"file:///foo.csv?header=true&delimiter=%3B", "text/csv; charset=utf-8", `text/csv; charset=utf-8; delimiter=";"; header=true`},
+		{"file:///foo.tsv", tsvMimetype, tsvMimetype},
+		{"file:///foo.tsv?header=true", tsvMimetype, "text/tab-separated-values; header=true"},
+		{"file:///foo.txt?header=true", textMimetype, textMimetype},
+		{"file:///foo.bin?descriptor=foo.binpb&message=foo.Bar&header=true", protobufMimetype, "application/x-protobuf; descriptor=foo.binpb; message=foo.Bar"},
+		{"file:///foo.bin?message=foo.Bar", "application/protobuf", "application/protobuf; message=foo.Bar"},
+	}
+
+	for _, d := range data {
+		u, _ := url.Parse(d.u)
+		assert.Equal(t, d.out, paramContentType(u, d.in), d.u)
+	}
+}
+
+func TestExtensionContentType(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		u, in, out string
+	}{
+		{"file:///foo.json", jsonMimetype, jsonMimetype},
+		{"file:///foo.tsv", textMimetype, tsvMimetype},
+		{"file:///foo.tsv", "text/plain; charset=utf-8", tsvMimetype},
+		{"file:///foo.tsv?type=text/plain", textMimetype, textMimetype},
+		{"file:///foo.ini", textMimetype, iniMimetype},
+		{"https://example.com/app.properties", textMimetype, propsMimetype},
+		{"file:///export.jsonl", textMimetype, ndjsonMimetype},
+		{"file:///config.msgpack", textMimetype, msgpackMimetype},
+		{"file:///config.cbor", textMimetype, cborMimetype},
+		{"file:///foo.txt", textMimetype, textMimetype},
+	}
+
+	for _, d := range data {
+		u, _ := url.Parse(d.u)
+		assert.Equal(t, d.out, extensionContentType(u, d.in), d.u)
+	}
+}
+
+func TestSniffContentType(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		u, in, content, out string
+	}{
+		{"file:///config", textMimetype, `{"foo": "bar"}`, jsonMimetype},
+		{"file:///config", textMimetype, "  [1, 2, 3]\n", jsonMimetype},
+		{"file:///config", "text/plain; charset=utf-8", "foo: bar\nbaz: [1, 2]\n", yamlMimetype},
+		{"file:///config", textMimetype, "- foo\n- bar\n", yamlMimetype},
+		{"file:///config", textMimetype, "---\nfoo: bar\n---\nbaz: qux\n", yamlMimetype},
+		{"https://example.com/config.json", textMimetype, `{"foo": "bar"}`, jsonMimetype},
+		{"file:///config", textMimetype, "hello world", textMimetype},
+		{"file:///config", textMimetype, "42", textMimetype},
+		{"file:///config", textMimetype, "", textMimetype},
+		{"file:///config", textMimetype, "{not json", textMimetype},
+		{"file:///config", textMimetype, "---\nfoo: bar\n---\njust text\n", textMimetype},
+		{"file:///config.txt", textMimetype, `{"foo": "bar"}`, textMimetype},
+		{"file:///config?type=text/plain", textMimetype, `{"foo": "bar"}`, textMimetype},
+		{"file:///config", csvMimetype, "foo: bar", csvMimetype},
+	}
+
+	for _, d := range data {
+		u, _ := url.Parse(d.u)
+		assert.Equal(t, d.out, sniffContentType(u, d.in, []byte(d.content)), d.u+" "+d.content)
+	}
+}
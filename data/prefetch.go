@@ -0,0 +1,73 @@
+package data
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/rs/zerolog"
+)
+
+// Prefetch reads all defined datasources concurrently, using at most the given
+// number of workers, so that their content is already cached when templates
+// are rendered. Errors are ignored here - they'll be returned if (and when) the
+// datasource is actually used.
+//
+// Datasources which read from standard input or run commands are skipped, as
+// reading them may block or have side-effects.
+func (d *Data) Prefetch(ctx context.Context, workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	type job struct {
+		source config.DataSource
+		alias  string
+	}
+
+	// snapshot the sources so that the workers don't race with datasources
+	// being defined later
+	jobs := make([]job, 0, len(d.Sources))
+	for alias, source := range d.Sources {
+		if source.URL == nil || !prefetchable(source.URL.Scheme) {
+			continue
+		}
+
+		jobs = append(jobs, job{alias: alias, source: source})
+	}
+
+	log := zerolog.Ctx(ctx)
+	ch := make(chan job)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < workers && i < len(jobs); i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := range ch {
+				_, err := d.readSource(ctx, j.alias, &j.source)
+				if err != nil {
+					log.Debug().Err(err).Str("alias", j.alias).Msg("prefetching datasource failed")
+				}
+			}
+		}()
+	}
+
+	for _, j := range jobs {
+		ch <- j
+	}
+
+	close(ch)
+	wg.Wait()
+}
+
+func prefetchable(scheme string) bool {
+	switch scheme {
+	case "stdin", "exec":
+		return false
+	default:
+		return true
+	}
+}
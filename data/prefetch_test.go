@@ -0,0 +1,75 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/hairyhenderson/go-fsimpl/httpfs"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefetch(t *testing.T) {
+	var hits atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// every read starts with a HEAD request (to stat the file), so count
+		// those as reads
+		if r.Method == http.MethodHead {
+			hits.Add(1)
+			time.Sleep(50 * time.Millisecond)
+		}
+
+		if r.URL.Path == "/missing.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", jsonMimetype)
+		fmt.Fprintf(w, `{"path": %q}`, r.URL.Path)
+	}))
+	t.Cleanup(srv.Close)
+
+	fsp := fsimpl.NewMux()
+	fsp.Add(httpfs.FS)
+
+	ctx := datafs.ContextWithFSProvider(context.Background(), fsp)
+
+	sources := map[string]config.DataSource{
+		"missing": {URL: mustParseURL(srv.URL + "/missing.json")},
+		"in":      {URL: mustParseURL("stdin:///in.json")},
+	}
+	for i := range 8 {
+		sources[fmt.Sprintf("ds%d", i)] = config.DataSource{
+			URL: mustParseURL(fmt.Sprintf("%s/%d.json", srv.URL, i)),
+		}
+	}
+
+	d := FromConfig(ctx, &config.Config{DataSources: sources})
+
+	start := time.Now()
+	d.Prefetch(ctx, 4)
+
+	// 9 requests of 50ms each, with 4 workers, should take well under the
+	// 450ms it would take serially
+	assert.Less(t, time.Since(start), 400*time.Millisecond)
+	assert.Equal(t, int32(9), hits.Load())
+
+	// prefetched datasources are read from the cache
+	out, err := d.Datasource("ds3")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"path": "/3.json"}, out)
+	assert.Equal(t, int32(9), hits.Load())
+
+	// errors are deferred until the datasource is used
+	_, err = d.Datasource("missing")
+	require.Error(t, err)
+}
@@ -0,0 +1,128 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// retryBackoff is the delay before the first retry - it doubles with each
+// subsequent retry, up to maxRetryBackoff
+//
+//nolint:gochecknoglobals
+var (
+	retryBackoff    = 250 * time.Millisecond
+	maxRetryBackoff = 10 * time.Second
+)
+
+// readWithRetries reads content from the given URL, applying the timeout to
+// each attempt, and retrying failed attempts with exponential backoff. The
+// "timeout" and "retries" query parameters override the defaults, and are not
+// passed on to the datasource. When prev is a stale cached copy of the content,
+// it may be revalidated rather than fetched again.
+func (d *Data) readWithRetries(ctx context.Context, u *url.URL, hdr http.Header, prev *fileContent) (*fileContent, error) {
+	u, timeout, retries, err := d.retryOptions(u)
+	if err != nil {
+		return nil, err
+	}
+
+	delay := retryBackoff
+
+	for attempt := 0; ; attempt++ {
+		fc, err := d.readAttempt(ctx, u, hdr, prev, timeout)
+		if err == nil || attempt >= retries || !retryable(err) || ctx.Err() != nil {
+			return fc, err
+		}
+
+		zerolog.Ctx(ctx).Debug().Err(err).
+			Stringer("url", u).
+			Int("attempt", attempt+1).
+			Dur("delay", delay).
+			Msg("reading datasource failed, retrying")
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay = min(delay*2, maxRetryBackoff)
+	}
+}
+
+func (d *Data) readAttempt(ctx context.Context, u *url.URL, hdr http.Header, prev *fileContent, timeout time.Duration) (*fileContent, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if !d.conditionalFetchable(u) {
+		return d.readFileContent(ctx, u, hdr)
+	}
+
+	rv := &revalidation{prev: prev}
+
+	fc, err := d.readFileContent(contextWithRevalidation(ctx, rv), u, hdr)
+	if err != nil {
+		return nil, err
+	}
+
+	fc.etag = rv.etag
+	fc.lastModified = rv.lastModified
+
+	return fc, nil
+}
+
+// retryOptions returns the timeout and number of retries for the URL, and a
+// copy of the URL with the "timeout" and "retries" query parameters removed
+func (d *Data) retryOptions(u *url.URL) (*url.URL, time.Duration, int, error) {
+	timeout, retries := d.Timeout, d.Retries
+
+	q := u.Query()
+	if !q.Has("timeout") && !q.Has("retries") {
+		return u, timeout, retries, nil
+	}
+
+	if v := q.Get("timeout"); v != "" {
+		t, err := time.ParseDuration(v)
+		if err != nil || t < 0 {
+			return nil, 0, 0, fmt.Errorf("invalid timeout %q: must be a positive duration", v)
+		}
+
+		timeout = t
+	}
+
+	if v := q.Get("retries"); v != "" {
+		r, err := strconv.Atoi(v)
+		if err != nil || r < 0 {
+			return nil, 0, 0, fmt.Errorf("invalid retries %q: must be a non-negative integer", v)
+		}
+
+		retries = r
+	}
+
+	q.Del("timeout")
+	q.Del("retries")
+
+	out := *u
+	out.RawQuery = q.Encode()
+
+	return &out, timeout, retries, nil
+}
+
+// retryable returns false for errors which retrying won't fix, like missing
+// files or permission errors
+func retryable(err error) bool {
+	return !errors.Is(err, fs.ErrNotExist) &&
+		!errors.Is(err, fs.ErrPermission) &&
+		!errors.Is(err, fs.ErrInvalid) &&
+		!errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,115 @@
+package data
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/hairyhenderson/go-fsimpl/httpfs"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadWithRetries(t *testing.T) {
+	origBackoff := retryBackoff
+	retryBackoff = time.Millisecond
+	t.Cleanup(func() { retryBackoff = origBackoff })
+
+	var hits atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/flaky.json", func(w http.ResponseWriter, r *http.Request) {
+		// every read starts with a HEAD request (to stat the file), so count
+		// those, failing the first 2
+		if r.Method == http.MethodHead && hits.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		assert.Empty(t, r.URL.Query().Get("retries"))
+
+		w.Header().Set("Content-Type", jsonMimetype)
+		w.Write([]byte(`{"foo": "bar"}`))
+	})
+	mux.HandleFunc("/slow.json", func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Header().Set("Content-Type", jsonMimetype)
+		w.Write([]byte(`{"foo": "bar"}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	fsp := fsimpl.NewMux()
+	fsp.Add(httpfs.FS)
+
+	ctx := datafs.ContextWithFSProvider(context.Background(), fsp)
+
+	t.Run("too few retries", func(t *testing.T) {
+		hits.Store(0)
+
+		d := &Data{}
+		_, err := d.readWithRetries(ctx, mustParseURL(srv.URL+"/flaky.json?retries=1"), nil, nil)
+		require.Error(t, err)
+		assert.Equal(t, int32(2), hits.Load())
+	})
+
+	t.Run("enough retries", func(t *testing.T) {
+		hits.Store(0)
+
+		d := &Data{}
+		fc, err := d.readWithRetries(ctx, mustParseURL(srv.URL+"/flaky.json?retries=2"), nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, `{"foo": "bar"}`, string(fc.b))
+		assert.Equal(t, int32(3), hits.Load())
+	})
+
+	t.Run("default retries", func(t *testing.T) {
+		hits.Store(0)
+
+		d := &Data{Retries: 5}
+		_, err := d.readWithRetries(ctx, mustParseURL(srv.URL+"/flaky.json"), nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), hits.Load())
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		d := &Data{}
+		_, err := d.readWithRetries(ctx, mustParseURL(srv.URL+"/slow.json?timeout=20ms"), nil, nil)
+		require.Error(t, err)
+
+		d = &Data{Timeout: 20 * time.Millisecond}
+		_, err = d.readWithRetries(ctx, mustParseURL(srv.URL+"/slow.json?timeout=5s"), nil, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		d := &Data{}
+		_, err := d.readWithRetries(ctx, mustParseURL(srv.URL+"/flaky.json?retries=lots"), nil, nil)
+		require.Error(t, err)
+
+		_, err = d.readWithRetries(ctx, mustParseURL(srv.URL+"/flaky.json?timeout=-1s"), nil, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestRetryOptions(t *testing.T) {
+	d := &Data{Timeout: time.Second, Retries: 3}
+
+	u, timeout, retries, err := d.retryOptions(mustParseURL("https://example.com/foo.json?type=application/json"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/foo.json?type=application/json", u.String())
+	assert.Equal(t, time.Second, timeout)
+	assert.Equal(t, 3, retries)
+
+	u, timeout, retries, err = d.retryOptions(mustParseURL("https://example.com/foo.json?timeout=5s&retries=0&a=b"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/foo.json?a=b", u.String())
+	assert.Equal(t, 5*time.Second, timeout)
+	assert.Equal(t, 0, retries)
+}
@@ -0,0 +1,36 @@
+package data
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/internal/jsonschema"
+	"github.com/hairyhenderson/gomplate/v4/internal/parsers"
+)
+
+// validateSchema validates the parsed contents of a datasource against its
+// JSON Schema, if it has one
+func (d *Data) validateSchema(ctx context.Context, alias string, v interface{}) error {
+	source, ok := d.Sources[alias]
+	if !ok || source.Schema == nil {
+		return nil
+	}
+
+	fc, err := d.readSource(ctx, alias, &config.DataSource{URL: source.Schema})
+	if err != nil {
+		return fmt.Errorf("couldn't read schema for datasource '%s': %w", alias, err)
+	}
+
+	schema, err := parsers.ParseData(fc.contentType, string(fc.b))
+	if err != nil {
+		return fmt.Errorf("couldn't parse schema for datasource '%s': %w", alias, err)
+	}
+
+	err = jsonschema.Validate(schema, v)
+	if err != nil {
+		return fmt.Errorf("datasource '%s' failed validation: %w", alias, err)
+	}
+
+	return nil
+}
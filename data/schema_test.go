@@ -0,0 +1,67 @@
+package data
+
+import (
+	"context"
+	"net/url"
+	"runtime"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatasourceSchema(t *testing.T) {
+	root := "/tmp/"
+	if runtime.GOOS == osWindows {
+		root = "C:/tmp/"
+	}
+
+	fsys := datafs.WrapWdFS(fstest.MapFS{
+		"tmp/good.json":   &fstest.MapFile{Data: []byte(`{"name": "web", "port": 8080}`)},
+		"tmp/bad.yaml":    &fstest.MapFile{Data: []byte("name: web\nport: '8080'\n")},
+		"tmp/schema.json": &fstest.MapFile{Data: []byte(`{"type": "object", "required": ["name", "port"], "properties": {"port": {"type": "integer"}}}`)},
+		"tmp/broken.json": &fstest.MapFile{Data: []byte(`{"type": 42}`)},
+	})
+	ctx := datafs.ContextWithFSProvider(context.Background(), datafs.WrappedFSProvider(fsys, "file", ""))
+
+	fileURL := func(name string) *url.URL {
+		return &url.URL{Scheme: "file", Path: root + name}
+	}
+
+	d := &Data{
+		Ctx: ctx,
+		Sources: map[string]config.DataSource{
+			"good":     {URL: fileURL("good.json"), Schema: fileURL("schema.json")},
+			"bad":      {URL: fileURL("bad.yaml"), Schema: fileURL("schema.json")},
+			"noschema": {URL: fileURL("bad.yaml")},
+			"missing":  {URL: fileURL("good.json"), Schema: fileURL("missing.json")},
+			"broken":   {URL: fileURL("good.json"), Schema: fileURL("broken.json")},
+		},
+	}
+
+	out, err := d.Datasource("good")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "web", "port": 8080}, out)
+
+	_, err = d.Datasource("bad")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "datasource 'bad' failed validation")
+	assert.Contains(t, err.Error(), "/port: expected integer, but got string")
+
+	_, err = d.Datasource("noschema")
+	require.NoError(t, err)
+
+	// include doesn't parse, so doesn't validate
+	_, err = d.Include("bad")
+	require.NoError(t, err)
+
+	_, err = d.Datasource("missing")
+	require.Error(t, err)
+
+	_, err = d.Datasource("broken")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid schema")
+}
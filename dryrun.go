@@ -0,0 +1,130 @@
+package gomplate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/rs/zerolog"
+)
+
+// dryRunWriter buffers rendered output in memory instead of writing it to the
+// output file. When closed, it reports whether the file would be created or
+// updated, or (when diff is set) writes a unified diff of the changes.
+type dryRunWriter struct {
+	ctx      context.Context
+	out      io.Writer
+	buf      *bytes.Buffer
+	filename string
+
+	diff          bool
+	suppressEmpty bool
+}
+
+func newDryRunWriter(ctx context.Context, cfg *config.Config, filename string) *dryRunWriter {
+	return &dryRunWriter{
+		ctx:           ctx,
+		out:           cfg.Stdout,
+		buf:           &bytes.Buffer{},
+		filename:      filename,
+		diff:          cfg.Diff,
+		suppressEmpty: cfg.SuppressEmpty,
+	}
+}
+
+func (w *dryRunWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *dryRunWriter) Close() error {
+	log := zerolog.Ctx(w.ctx)
+
+	rendered := w.buf.String()
+
+	// empty output wouldn't be written at all
+	if w.suppressEmpty && strings.TrimSpace(rendered) == "" {
+		return nil
+	}
+
+	existing, exists, err := readExistingOutput(w.ctx, w.filename)
+	if err != nil {
+		return err
+	}
+
+	if exists && existing == rendered {
+		log.Debug().Str("file", w.filename).Msg("dry run: output file unchanged")
+		return nil
+	}
+
+	action := "update"
+	if !exists {
+		action = "create"
+	}
+	log.Debug().Str("file", w.filename).Msgf("dry run: would %s output file", action)
+
+	if !w.diff {
+		_, err = fmt.Fprintf(w.out, "would %s %s\n", action, w.filename)
+		return err
+	}
+
+	from := w.filename
+	if !exists {
+		from = "/dev/null"
+	}
+
+	err = difflib.WriteUnifiedDiff(w.out, difflib.UnifiedDiff{
+		A:        diffLines(existing),
+		B:        diffLines(rendered),
+		FromFile: from,
+		ToFile:   w.filename,
+		Context:  3,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write diff for %s: %w", w.filename, err)
+	}
+
+	return nil
+}
+
+// readExistingOutput reads the current content of the output file, if it
+// exists
+func readExistingOutput(ctx context.Context, filename string) (string, bool, error) {
+	fsys, err := datafs.FSysForPath(ctx, filename)
+	if err != nil {
+		return "", false, fmt.Errorf("fsysForPath: %w", err)
+	}
+
+	b, err := fs.ReadFile(fsys, filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read output file %q: %w", filename, err)
+	}
+
+	return string(b), true, nil
+}
+
+// diffLines splits the text into lines for diffing, keeping the line endings.
+// A missing newline at the end is marked the same way as in diff(1).
+func diffLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(s, "\n")
+	last := len(lines) - 1
+	if lines[last] == "" {
+		return lines[:last]
+	}
+
+	lines[last] += "\n\\ No newline at end of file\n"
+	return lines
+}
@@ -0,0 +1,96 @@
+package gomplate
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/hack-pad/hackpadfs"
+	"github.com/hack-pad/hackpadfs/mem"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffLines(t *testing.T) {
+	assert.Nil(t, diffLines(""))
+	assert.Equal(t, []string{"a\n", "b\n"}, diffLines("a\nb\n"))
+	assert.Equal(t, []string{"a\n", "b\n\\ No newline at end of file\n"}, diffLines("a\nb"))
+}
+
+func TestDryRunWriter(t *testing.T) {
+	memfs, _ := mem.NewFS()
+	fsys := datafs.WrapWdFS(memfs)
+
+	_ = hackpadfs.Mkdir(fsys, "/tmp", 0o777)
+	require.NoError(t, hackpadfs.WriteFullFile(fsys, "/tmp/existing", []byte("hello\nworld\n"), 0o644))
+
+	ctx := datafs.ContextWithFSProvider(context.Background(), datafs.WrappedFSProvider(fsys, "file"))
+
+	out := &bytes.Buffer{}
+	cfg := &config.Config{Stdout: out, DryRun: true, Diff: true}
+
+	write := func(filename, content string) {
+		t.Helper()
+
+		w, err := getOutfileHandler(ctx, cfg, filename, 0o644, false)
+		require.NoError(t, err)
+
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, w.(io.Closer).Close())
+	}
+
+	write("/tmp/existing", "hello\nthere\n")
+	assert.Equal(t, `--- /tmp/existing
++++ /tmp/existing
+@@ -1,2 +1,2 @@
+ hello
+-world
++there
+`, out.String())
+
+	// the file isn't modified
+	b, err := fs.ReadFile(fsys, "/tmp/existing")
+	require.NoError(t, err)
+	assert.Equal(t, "hello\nworld\n", string(b))
+
+	// unchanged files aren't shown
+	out.Reset()
+	write("/tmp/existing", "hello\nworld\n")
+	assert.Empty(t, out.String())
+
+	// new files are diffed against /dev/null, and aren't created
+	out.Reset()
+	write("/tmp/new", "new\n")
+	assert.Equal(t, `--- /dev/null
++++ /tmp/new
+@@ -0,0 +1 @@
++new
+`, out.String())
+
+	_, err = hackpadfs.Stat(fsys, "/tmp/new")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	// empty output isn't written when suppressEmpty is set
+	out.Reset()
+	cfg.SuppressEmpty = true
+	write("/tmp/empty", "  \n")
+	assert.Empty(t, out.String())
+
+	// without diff, only the changed files are listed
+	out.Reset()
+	cfg.Diff = false
+	write("/tmp/existing", "changed\n")
+	write("/tmp/existing", "hello\nworld\n")
+	write("/tmp/new", "new\n")
+	assert.Equal(t, "would update /tmp/existing\nwould create /tmp/new\n", out.String())
+
+	// stdout is written to as usual
+	w, err := getOutfileHandler(ctx, cfg, "-", 0o644, false)
+	require.NoError(t, err)
+	assert.Same(t, out, w)
+}
@@ -17,7 +17,12 @@ func CreateFuncs(ctx context.Context, d *data.Data) template.FuncMap {
 	addToMap(f, funcs.CreateDataFuncs(ctx, d))
 	addToMap(f, funcs.CreateAWSFuncs(ctx))
 	addToMap(f, funcs.CreateGCPFuncs(ctx))
+	addToMap(f, funcs.CreateAzureFuncs(ctx))
+	addToMap(f, funcs.CreateK8sFuncs(ctx))
 	addToMap(f, funcs.CreateBase64Funcs(ctx))
+	addToMap(f, funcs.CreateBase32Funcs(ctx))
+	addToMap(f, funcs.CreateBase58Funcs(ctx))
+	addToMap(f, funcs.CreateHexFuncs(ctx))
 	addToMap(f, funcs.CreateNetFuncs(ctx))
 	addToMap(f, funcs.CreateReFuncs(ctx))
 	addToMap(f, funcs.CreateStringFuncs(ctx))
@@ -35,6 +40,20 @@ func CreateFuncs(ctx context.Context, d *data.Data) template.FuncMap {
 	addToMap(f, funcs.CreateUUIDFuncs(ctx))
 	addToMap(f, funcs.CreateRandomFuncs(ctx))
 	addToMap(f, funcs.CreateSemverFuncs(ctx))
+	addToMap(f, funcs.CreateJSONSchemaFuncs(ctx))
+	addToMap(f, funcs.CreateCUEFuncs(ctx))
+	addToMap(f, funcs.CreateExecFuncs(ctx))
+	addToMap(f, funcs.CreateScriptFuncs(ctx))
+	addToMap(f, funcs.CreateColorFuncs(ctx))
+	addToMap(f, funcs.CreateMarkdownFuncs(ctx))
+	addToMap(f, funcs.CreateHTMLFuncs(ctx))
+	addToMap(f, funcs.CreateI18nFuncs(ctx, d))
+
+	// added last, so the Sprig variants take precedence over same-named
+	// gomplate functions
+	if config.SprigEnabled(ctx) {
+		addToMap(f, funcs.CreateSprigFuncs(ctx))
+	}
 	return f
 }
 
@@ -53,3 +72,11 @@ func SetExperimental(ctx context.Context) context.Context {
 	// functions available to external packages.
 	return config.SetExperimental(ctx)
 }
+
+// SetSprig enables Sprig-compatible functions in the given context, so that
+// templates written for Helm (or other Sprig-based tools) can be reused. This
+// must be done before creating functions. Where a Sprig function's name
+// matches a gomplate function's, the Sprig variant is used.
+func SetSprig(ctx context.Context) context.Context {
+	return config.SetSprig(ctx)
+}
@@ -81,6 +81,60 @@ func (c *MetaClient) Meta(key string, def ...string) (string, error) {
 	return c.retrieveMetadata(c.ctx, url, def...)
 }
 
+// ProjectMeta retrieves a project-level value from the GCP Instance Metadata
+// Service, returning the given default if the service is unavailable or the
+// requested URL does not exist.
+func (c *MetaClient) ProjectMeta(key string, def ...string) (string, error) {
+	url := c.endpoint + "/computeMetadata/v1/project/" + key
+	return c.retrieveMetadata(c.ctx, url, def...)
+}
+
+// ProjectID returns the ID of the project the instance belongs to.
+func (c *MetaClient) ProjectID(def ...string) (string, error) {
+	return c.ProjectMeta("project-id", def...)
+}
+
+// Zone returns the name of the zone the instance is running in, like
+// "us-central1-a".
+func (c *MetaClient) Zone(def ...string) (string, error) {
+	// the metadata service returns the zone's full resource name, like
+	// "projects/123456789012/zones/us-central1-a"
+	zone, err := c.Meta("zone")
+	if err != nil {
+		return "", err
+	}
+	if zone == "" {
+		return returnDefault(def), nil
+	}
+
+	return zone[strings.LastIndex(zone, "/")+1:], nil
+}
+
+// Region returns the name of the region the instance is running in, like
+// "us-central1", derived from the zone.
+func (c *MetaClient) Region(def ...string) (string, error) {
+	zone, err := c.Zone()
+	if err != nil {
+		return "", err
+	}
+	i := strings.LastIndex(zone, "-")
+	if i <= 0 {
+		return returnDefault(def), nil
+	}
+
+	return zone[:i], nil
+}
+
+// Attribute returns the value of a custom instance metadata attribute.
+func (c *MetaClient) Attribute(key string, def ...string) (string, error) {
+	return c.Meta("attributes/"+key, def...)
+}
+
+// ProjectAttribute returns the value of a custom project metadata attribute.
+func (c *MetaClient) ProjectAttribute(key string, def ...string) (string, error) {
+	return c.ProjectMeta("attributes/"+key, def...)
+}
+
 // retrieveMetadata executes an HTTP request to the GCP Instance Metadata Service with the
 // correct headers set, and extracts the returned value.
 func (c *MetaClient) retrieveMetadata(ctx context.Context, url string, def ...string) (string, error) {
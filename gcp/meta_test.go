@@ -0,0 +1,100 @@
+package gcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMetaServer(t *testing.T) *MetaClient {
+	t.Helper()
+
+	data := map[string]string{
+		"/computeMetadata/v1/instance/id":                 "1334999446930701104",
+		"/computeMetadata/v1/instance/zone":               "projects/123456789012/zones/us-central1-a",
+		"/computeMetadata/v1/instance/attributes/role":    "web",
+		"/computeMetadata/v1/project/project-id":          "my-project",
+		"/computeMetadata/v1/project/attributes/env-name": "prod\n",
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		v, ok := data[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(v))
+	}))
+	t.Cleanup(srv.Close)
+
+	t.Setenv("GCP_META_ENDPOINT", srv.URL)
+	return NewMetaClient(context.Background(), ClientOptions{})
+}
+
+func TestMeta(t *testing.T) {
+	c := setupMetaServer(t)
+
+	out, err := c.Meta("id")
+	require.NoError(t, err)
+	assert.Equal(t, "1334999446930701104", out)
+
+	out, err = c.Meta("missing", "default")
+	require.NoError(t, err)
+	assert.Equal(t, "default", out)
+
+	out, err = c.Attribute("role")
+	require.NoError(t, err)
+	assert.Equal(t, "web", out)
+
+	out, err = c.Attribute("missing")
+	require.NoError(t, err)
+	assert.Empty(t, out)
+}
+
+func TestProjectMeta(t *testing.T) {
+	c := setupMetaServer(t)
+
+	out, err := c.ProjectID()
+	require.NoError(t, err)
+	assert.Equal(t, "my-project", out)
+
+	out, err = c.ProjectAttribute("env-name")
+	require.NoError(t, err)
+	assert.Equal(t, "prod", out)
+
+	out, err = c.ProjectAttribute("missing", "dev")
+	require.NoError(t, err)
+	assert.Equal(t, "dev", out)
+}
+
+func TestZoneAndRegion(t *testing.T) {
+	c := setupMetaServer(t)
+
+	out, err := c.Zone()
+	require.NoError(t, err)
+	assert.Equal(t, "us-central1-a", out)
+
+	out, err = c.Region()
+	require.NoError(t, err)
+	assert.Equal(t, "us-central1", out)
+
+	// outside GCP, the defaults are used
+	t.Setenv("GCP_META_ENDPOINT", "http://127.0.0.1:1")
+	c = NewMetaClient(context.Background(), ClientOptions{})
+
+	out, err = c.Zone("unknown-zone")
+	require.NoError(t, err)
+	assert.Equal(t, "unknown-zone", out)
+
+	out, err = c.Region("unknown")
+	require.NoError(t, err)
+	assert.Equal(t, "unknown", out)
+}
@@ -30,25 +30,10 @@ func RunTemplates(o *Config) error {
 func Run(ctx context.Context, cfg *config.Config) error {
 	Metrics = newMetrics()
 
-	// apply defaults before validation
-	cfg.ApplyDefaults()
-
-	err := cfg.Validate()
-	if err != nil {
-		return fmt.Errorf("failed to validate config: %w\n%+v", err, cfg)
-	}
-
-	funcMap := template.FuncMap{}
-	err = bindPlugins(ctx, cfg, funcMap)
+	ctx, opts, err := prepareRun(ctx, cfg)
 	if err != nil {
 		return err
 	}
-
-	// if a custom Stdin is set in the config, inject it into the context now
-	ctx = datafs.ContextWithStdin(ctx, cfg.Stdin)
-
-	opts := optionsFromConfig(cfg)
-	opts.Funcs = funcMap
 	tr := NewRenderer(opts)
 
 	start := time.Now()
@@ -70,6 +55,72 @@ func Run(ctx context.Context, cfg *config.Config) error {
 	return nil
 }
 
+// prepareRun validates the configuration (after applying defaults), and
+// returns the renderer options and the context to render with
+func prepareRun(ctx context.Context, cfg *config.Config) (context.Context, Options, error) {
+	// apply defaults before validation
+	cfg.ApplyDefaults()
+
+	err := cfg.Validate()
+	if err != nil {
+		return ctx, Options{}, fmt.Errorf("failed to validate config: %w\n%+v", err, cfg)
+	}
+
+	funcMap := template.FuncMap{}
+	err = bindPlugins(ctx, cfg, funcMap)
+	if err != nil {
+		return ctx, Options{}, err
+	}
+
+	// if a custom Stdin is set in the config, inject it into the context now
+	ctx = datafs.ContextWithStdin(ctx, cfg.Stdin)
+
+	// configure client certificates and custom CAs for HTTPS datasources
+	if cfg.TLSCert != "" || cfg.CABundle != "" {
+		client, err := datafs.NewTLSHTTPClient(cfg.TLSCert, cfg.TLSKey, cfg.CABundle)
+		if err != nil {
+			return ctx, Options{}, fmt.Errorf("failed to configure TLS: %w", err)
+		}
+
+		ctx = datafs.ContextWithHTTPClient(ctx, client)
+	}
+
+	// functions which perform network lookups can be disabled, for hermetic
+	// rendering
+	if cfg.DisableNetwork {
+		ctx = config.SetNetworkDisabled(ctx)
+	}
+
+	// Sprig-compatible functions are only added on request, since some of
+	// them conflict with gomplate's own
+	if cfg.Sprig {
+		ctx = config.SetSprig(ctx)
+	}
+
+	// running local commands from templates is opt-in
+	if cfg.AllowExec {
+		ctx = config.SetExecAllowed(ctx, cfg.ExecTimeout)
+	}
+
+	// preload scripts, so their functions are available to script.Run
+	if len(cfg.Scripts) > 0 {
+		ctx, err = loadScripts(ctx, cfg.Scripts)
+		if err != nil {
+			return ctx, Options{}, err
+		}
+	}
+
+	opts := optionsFromConfig(cfg)
+	opts.Funcs = funcMap
+
+	opts.Overrides, err = contextOverrides(ctx, cfg)
+	if err != nil {
+		return ctx, Options{}, err
+	}
+
+	return ctx, opts, nil
+}
+
 func chooseNamer(cfg *config.Config, tr *Renderer) func(context.Context, string) (string, error) {
 	if cfg.OutputMap == "" {
 		return simpleNamer(cfg.OutputDir)
@@ -86,7 +137,7 @@ func simpleNamer(outDir string) func(ctx context.Context, inPath string) (string
 
 func mappingNamer(outMap string, tr *Renderer) func(context.Context, string) (string, error) {
 	return func(ctx context.Context, inPath string) (string, error) {
-		tcontext, err := createTmplContext(ctx, tr.tctxAliases, tr.data)
+		tcontext, err := tr.tmplContext(ctx)
 		if err != nil {
 			return "", err
 		}
@@ -0,0 +1,90 @@
+// Package base58 implements the Base58 encoding used by Bitcoin, IPFS, and
+// others. Unlike Base64, Base58 avoids characters that are easily confused
+// (0, O, I, and l), and non-alphanumeric characters.
+package base58
+
+import (
+	"fmt"
+)
+
+const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var decodeMap [256]int8
+
+func init() {
+	for i := range decodeMap {
+		decodeMap[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		decodeMap[alphabet[i]] = int8(i)
+	}
+}
+
+// Encode - encode the input as Base58. Each leading zero byte is encoded as a
+// '1'.
+func Encode(in []byte) string {
+	zeros := 0
+	for zeros < len(in) && in[zeros] == 0 {
+		zeros++
+	}
+
+	// the output is at most log(256)/log(58) (~1.37) times the input size
+	out := make([]byte, 0, len(in)*138/100+1)
+	for _, b := range in[zeros:] {
+		carry := int(b)
+		for i := range out {
+			carry += int(out[i]) << 8
+			out[i] = byte(carry % 58)
+			carry /= 58
+		}
+		for carry > 0 {
+			out = append(out, byte(carry%58))
+			carry /= 58
+		}
+	}
+
+	res := make([]byte, zeros+len(out))
+	for i := 0; i < zeros; i++ {
+		res[i] = alphabet[0]
+	}
+	// digits are accumulated least-significant first
+	for i, d := range out {
+		res[len(res)-1-i] = alphabet[d]
+	}
+
+	return string(res)
+}
+
+// Decode - decode a Base58-encoded string
+func Decode(in string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(in) && in[zeros] == alphabet[0] {
+		zeros++
+	}
+
+	out := make([]byte, 0, len(in)*733/1000+1)
+	for i := zeros; i < len(in); i++ {
+		d := decodeMap[in[i]]
+		if d < 0 {
+			return nil, fmt.Errorf("illegal base58 data at input byte %d", i)
+		}
+
+		carry := int(d)
+		for j := range out {
+			carry += int(out[j]) * 58
+			out[j] = byte(carry)
+			carry >>= 8
+		}
+		for carry > 0 {
+			out = append(out, byte(carry))
+			carry >>= 8
+		}
+	}
+
+	res := make([]byte, zeros+len(out))
+	for i, b := range out {
+		res[len(res)-1-i] = b
+	}
+
+	return res, nil
+}
@@ -0,0 +1,52 @@
+package base58
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBase58(t *testing.T) {
+	// test vectors from draft-msporny-base58
+	testdata := []struct {
+		in  []byte
+		out string
+	}{
+		{[]byte{}, ""},
+		{[]byte{0}, "1"},
+		{[]byte{0, 0, 0}, "111"},
+		{[]byte("Hello World!"), "2NEpo7TZRRrLZSi2U"},
+		{[]byte("The quick brown fox jumps over the lazy dog."), "USm3fpXnKG5EUBx2ndxBDMPVciP5hGey2Jh4NDv6gmeo1LkMeiKrLJUUBk6Z"},
+		{[]byte{0x00, 0x00, 0x28, 0x7f, 0xb4, 0xcd}, "11233QC4"},
+	}
+
+	for _, d := range testdata {
+		assert.Equal(t, d.out, Encode(d.in))
+
+		out, err := Decode(d.out)
+		require.NoError(t, err)
+		assert.Equal(t, d.in, out)
+	}
+
+	for _, s := range []string{"0", "O", "I", "l", "abc+", "2NEpo7TZRRrLZSi2U\n"} {
+		_, err := Decode(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestBase58RoundTrip(t *testing.T) {
+	for n := 0; n < 100; n++ {
+		in := make([]byte, n)
+		_, err := rand.Read(in)
+		require.NoError(t, err)
+		if n > 2 {
+			in[0] = 0
+		}
+
+		out, err := Decode(Encode(in))
+		require.NoError(t, err)
+		assert.Equal(t, in, out)
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
@@ -23,6 +24,8 @@ const (
 // - creates a config.Config from the cobra flags
 // - creates a config.Config from the config file (if present)
 // - merges the two (flags take precedence)
+// - applies --datasource-schema flags, which may refer to datasources from
+// either source
 func loadConfig(ctx context.Context, cmd *cobra.Command, args []string) (*config.Config, error) {
 	flagConfig, err := cobraConfig(cmd, args)
 	if err != nil {
@@ -39,6 +42,15 @@ func loadConfig(ctx context.Context, cmd *cobra.Command, args []string) (*config
 		cfg = cfg.MergeFrom(flagConfig)
 	}
 
+	schemas, err := getStringSlice(cmd, "datasource-schema")
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.ParseDataSourceSchemaFlags(schemas)
+	if err != nil {
+		return nil, err
+	}
+
 	cfg, err = applyEnvVars(ctx, cfg)
 	if err != nil {
 		return nil, err
@@ -142,6 +154,10 @@ func cobraConfig(cmd *cobra.Command, args []string) (cfg *config.Config, err err
 	if err != nil {
 		return nil, err
 	}
+	cfg.BackupSuffix, err = getString(cmd, "backup-suffix")
+	if err != nil {
+		return nil, err
+	}
 
 	if len(args) > 0 {
 		cfg.PostExec = args
@@ -155,6 +171,26 @@ func cobraConfig(cmd *cobra.Command, args []string) (cfg *config.Config, err err
 	if err != nil {
 		return nil, err
 	}
+	cfg.DisableNetwork, err = getBool(cmd, "disable-network")
+	if err != nil {
+		return nil, err
+	}
+	cfg.Sprig, err = getBool(cmd, "sprig")
+	if err != nil {
+		return nil, err
+	}
+	cfg.AllowExec, err = getBool(cmd, "allow-exec")
+	if err != nil {
+		return nil, err
+	}
+	cfg.ExecTimeout, err = getDuration(cmd, "exec-timeout")
+	if err != nil {
+		return nil, err
+	}
+	cfg.Scripts, err = getStringArray(cmd, "script")
+	if err != nil {
+		return nil, err
+	}
 
 	cfg.LDelim, err = getString(cmd, "left-delim")
 	if err != nil {
@@ -170,6 +206,40 @@ func cobraConfig(cmd *cobra.Command, args []string) (cfg *config.Config, err err
 		return nil, err
 	}
 
+	cfg.TLSCert, err = getString(cmd, "tls-cert")
+	if err != nil {
+		return nil, err
+	}
+	cfg.TLSKey, err = getString(cmd, "tls-key")
+	if err != nil {
+		return nil, err
+	}
+	cfg.CABundle, err = getString(cmd, "ca-bundle")
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.DatasourceCacheTTL, err = getDuration(cmd, "datasource-cache-ttl")
+	if err != nil {
+		return nil, err
+	}
+	cfg.DatasourceCacheDir, err = getString(cmd, "datasource-cache-dir")
+	if err != nil {
+		return nil, err
+	}
+	cfg.DatasourcePrefetch, err = getInt(cmd, "datasource-prefetch")
+	if err != nil {
+		return nil, err
+	}
+	cfg.DatasourceTimeout, err = getDuration(cmd, "datasource-timeout")
+	if err != nil {
+		return nil, err
+	}
+	cfg.DatasourceRetries, err = getInt(cmd, "datasource-retries")
+	if err != nil {
+		return nil, err
+	}
+
 	ds, err := getStringSlice(cmd, "datasource")
 	if err != nil {
 		return nil, err
@@ -191,6 +261,45 @@ func cobraConfig(cmd *cobra.Command, args []string) (cfg *config.Config, err err
 		return nil, err
 	}
 
+	cfg.Watch, err = getBool(cmd, "watch")
+	if err != nil {
+		return nil, err
+	}
+	cfg.WatchPoll, err = getBool(cmd, "watch-poll")
+	if err != nil {
+		return nil, err
+	}
+	cfg.WatchInterval, err = getDuration(cmd, "watch-interval")
+	if err != nil {
+		return nil, err
+	}
+	cfg.ExecSignal, err = getString(cmd, "exec-signal")
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.DryRun, err = getBool(cmd, "dry-run")
+	if err != nil {
+		return nil, err
+	}
+	cfg.Diff, err = getBool(cmd, "diff")
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Set, err = getStringArray(cmd, "set")
+	if err != nil {
+		return nil, err
+	}
+	cfg.SetString, err = getStringArray(cmd, "set-string")
+	if err != nil {
+		return nil, err
+	}
+	cfg.SetFile, err = getStringArray(cmd, "set-file")
+	if err != nil {
+		return nil, err
+	}
+
 	pl, err := getStringSlice(cmd, "plugin")
 	if err != nil {
 		return nil, err
@@ -209,6 +318,13 @@ func getStringSlice(cmd *cobra.Command, flag string) (s []string, err error) {
 	return s, err
 }
 
+func getStringArray(cmd *cobra.Command, flag string) (s []string, err error) {
+	if cmd.Flag(flag) != nil && cmd.Flag(flag).Changed {
+		s, err = cmd.Flags().GetStringArray(flag)
+	}
+	return s, err
+}
+
 func getString(cmd *cobra.Command, flag string) (s string, err error) {
 	if cmd.Flag(flag) != nil && cmd.Flag(flag).Changed {
 		s, err = cmd.Flags().GetString(flag)
@@ -223,6 +339,20 @@ func getBool(cmd *cobra.Command, flag string) (b bool, err error) {
 	return b, err
 }
 
+func getInt(cmd *cobra.Command, flag string) (i int, err error) {
+	if cmd.Flag(flag) != nil && cmd.Flag(flag).Changed {
+		i, err = cmd.Flags().GetInt(flag)
+	}
+	return i, err
+}
+
+func getDuration(cmd *cobra.Command, flag string) (d time.Duration, err error) {
+	if cmd.Flag(flag) != nil && cmd.Flag(flag).Changed {
+		d, err = cmd.Flags().GetDuration(flag)
+	}
+	return d, err
+}
+
 // process --include flags - these are analogous to specifying --exclude '*',
 // then the inverse of the --include options.
 func processIncludes(includes, excludes []string) []string {
@@ -253,6 +383,42 @@ func applyEnvVars(_ context.Context, cfg *config.Config) (*config.Config, error)
 		cfg.PluginTimeout = t
 	}
 
+	if ttl := env.Getenv("GOMPLATE_DATASOURCE_CACHE_TTL"); cfg.DatasourceCacheTTL == 0 && ttl != "" {
+		t, err := time.ParseDuration(ttl)
+		if err != nil {
+			return nil, fmt.Errorf("GOMPLATE_DATASOURCE_CACHE_TTL set to invalid value %q: %w", ttl, err)
+		}
+		cfg.DatasourceCacheTTL = t
+	}
+
+	if cfg.DatasourceCacheDir == "" {
+		cfg.DatasourceCacheDir = env.Getenv("GOMPLATE_DATASOURCE_CACHE_DIR")
+	}
+
+	if n := env.Getenv("GOMPLATE_DATASOURCE_PREFETCH"); cfg.DatasourcePrefetch == 0 && n != "" {
+		i, err := strconv.Atoi(n)
+		if err != nil {
+			return nil, fmt.Errorf("GOMPLATE_DATASOURCE_PREFETCH set to invalid value %q: %w", n, err)
+		}
+		cfg.DatasourcePrefetch = i
+	}
+
+	if to := env.Getenv("GOMPLATE_DATASOURCE_TIMEOUT"); cfg.DatasourceTimeout == 0 && to != "" {
+		t, err := time.ParseDuration(to)
+		if err != nil {
+			return nil, fmt.Errorf("GOMPLATE_DATASOURCE_TIMEOUT set to invalid value %q: %w", to, err)
+		}
+		cfg.DatasourceTimeout = t
+	}
+
+	if n := env.Getenv("GOMPLATE_DATASOURCE_RETRIES"); cfg.DatasourceRetries == 0 && n != "" {
+		i, err := strconv.Atoi(n)
+		if err != nil {
+			return nil, fmt.Errorf("GOMPLATE_DATASOURCE_RETRIES set to invalid value %q: %w", n, err)
+		}
+		cfg.DatasourceRetries = i
+	}
+
 	if !cfg.SuppressEmpty && conv.ToBool(env.Getenv("GOMPLATE_SUPPRESS_EMPTY", "false")) {
 		cfg.SuppressEmpty = true
 	}
@@ -261,6 +427,26 @@ func applyEnvVars(_ context.Context, cfg *config.Config) (*config.Config, error)
 		cfg.Experimental = true
 	}
 
+	if !cfg.DisableNetwork && conv.ToBool(env.Getenv("GOMPLATE_DISABLE_NETWORK", "false")) {
+		cfg.DisableNetwork = true
+	}
+
+	if !cfg.Sprig && conv.ToBool(env.Getenv("GOMPLATE_SPRIG", "false")) {
+		cfg.Sprig = true
+	}
+
+	if !cfg.AllowExec && conv.ToBool(env.Getenv("GOMPLATE_ALLOW_EXEC", "false")) {
+		cfg.AllowExec = true
+	}
+
+	if to := env.Getenv("GOMPLATE_EXEC_TIMEOUT"); cfg.ExecTimeout == 0 && to != "" {
+		t, err := time.ParseDuration(to)
+		if err != nil {
+			return nil, fmt.Errorf("GOMPLATE_EXEC_TIMEOUT set to invalid value %q: %w", to, err)
+		}
+		cfg.ExecTimeout = t
+	}
+
 	if cfg.LDelim == "" {
 		cfg.LDelim = env.Getenv("GOMPLATE_LEFT_DELIM")
 	}
@@ -268,5 +454,15 @@ func applyEnvVars(_ context.Context, cfg *config.Config) (*config.Config, error)
 		cfg.RDelim = env.Getenv("GOMPLATE_RIGHT_DELIM")
 	}
 
+	if cfg.TLSCert == "" {
+		cfg.TLSCert = env.Getenv("GOMPLATE_TLS_CERT")
+	}
+	if cfg.TLSKey == "" {
+		cfg.TLSKey = env.Getenv("GOMPLATE_TLS_KEY")
+	}
+	if cfg.CABundle == "" {
+		cfg.CABundle = env.Getenv("GOMPLATE_CA_BUNDLE")
+	}
+
 	return cfg, nil
 }
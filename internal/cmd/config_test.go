@@ -143,6 +143,64 @@ func TestCobraConfig(t *testing.T) {
 		InputFiles: []string{"in"},
 		PostExec:   []string{"echo", "foo"},
 	}, cfg)
+
+	cmd = &cobra.Command{}
+	cmd.Flags().StringArray("set", nil, "...")
+	cmd.Flags().StringArray("set-string", nil, "...")
+	cmd.Flags().StringArray("set-file", nil, "...")
+	cmd.ParseFlags([]string{"--set", "a.b=1,c=2", "--set", "d={x,y}", "--set-string", "e=3", "--set-file", "f=cert.pem"})
+
+	cfg, err = cobraConfig(cmd, cmd.Flags().Args())
+	require.NoError(t, err)
+	assert.EqualValues(t, &config.Config{
+		Set:       []string{"a.b=1,c=2", "d={x,y}"},
+		SetString: []string{"e=3"},
+		SetFile:   []string{"f=cert.pem"},
+	}, cfg)
+
+	cmd = &cobra.Command{}
+	cmd.Flags().StringArray("script", nil, "...")
+	cmd.ParseFlags([]string{"--script", "lib.star", "--script", "more.star"})
+
+	cfg, err = cobraConfig(cmd, cmd.Flags().Args())
+	require.NoError(t, err)
+	assert.EqualValues(t, &config.Config{
+		Scripts: []string{"lib.star", "more.star"},
+	}, cfg)
+
+	cmd = &cobra.Command{}
+	cmd.Flags().Bool("watch", false, "...")
+	cmd.Flags().Bool("watch-poll", false, "...")
+	cmd.Flags().Duration("watch-interval", 0, "...")
+	cmd.Flags().String("exec-signal", "", "...")
+	cmd.ParseFlags([]string{"--watch", "--watch-poll", "--watch-interval", "250ms", "--exec-signal", "HUP", "--", "nginx"})
+
+	cfg, err = cobraConfig(cmd, cmd.Flags().Args())
+	require.NoError(t, err)
+	assert.EqualValues(t, &config.Config{
+		Watch:         true,
+		WatchPoll:     true,
+		WatchInterval: 250 * time.Millisecond,
+		ExecSignal:    "HUP",
+		PostExec:      []string{"nginx"},
+	}, cfg)
+
+	cmd = &cobra.Command{}
+	cmd.Flags().Bool("dry-run", false, "...")
+	cmd.Flags().Bool("diff", false, "...")
+	cmd.ParseFlags([]string{"--diff"})
+
+	cfg, err = cobraConfig(cmd, cmd.Flags().Args())
+	require.NoError(t, err)
+	assert.EqualValues(t, &config.Config{Diff: true}, cfg)
+
+	cmd = &cobra.Command{}
+	cmd.Flags().String("backup-suffix", "", "...")
+	cmd.ParseFlags([]string{"--backup-suffix", ".orig"})
+
+	cfg, err = cobraConfig(cmd, cmd.Flags().Args())
+	require.NoError(t, err)
+	assert.EqualValues(t, &config.Config{BackupSuffix: ".orig"}, cfg)
 }
 
 func TestProcessIncludes(t *testing.T) {
@@ -199,6 +257,30 @@ func TestApplyEnvVars(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("invalid GOMPLATE_DATASOURCE_PREFETCH", func(t *testing.T) {
+		t.Setenv("GOMPLATE_DATASOURCE_PREFETCH", "lots")
+		_, err := applyEnvVars(context.Background(), &config.Config{})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid GOMPLATE_DATASOURCE_TIMEOUT", func(t *testing.T) {
+		t.Setenv("GOMPLATE_DATASOURCE_TIMEOUT", "bogus")
+		_, err := applyEnvVars(context.Background(), &config.Config{})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid GOMPLATE_DATASOURCE_RETRIES", func(t *testing.T) {
+		t.Setenv("GOMPLATE_DATASOURCE_RETRIES", "bogus")
+		_, err := applyEnvVars(context.Background(), &config.Config{})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid GOMPLATE_DATASOURCE_CACHE_TTL", func(t *testing.T) {
+		t.Setenv("GOMPLATE_DATASOURCE_CACHE_TTL", "bogus")
+		_, err := applyEnvVars(context.Background(), &config.Config{})
+		assert.Error(t, err)
+	})
+
 	data := []struct {
 		input, expected *config.Config
 		env             string
@@ -249,6 +331,36 @@ func TestApplyEnvVars(t *testing.T) {
 			&config.Config{Experimental: true},
 			"GOMPLATE_EXPERIMENTAL", "false",
 		},
+		{
+			&config.Config{},
+			&config.Config{DisableNetwork: true},
+			"GOMPLATE_DISABLE_NETWORK", "true",
+		},
+		{
+			&config.Config{DisableNetwork: true},
+			&config.Config{DisableNetwork: true},
+			"GOMPLATE_DISABLE_NETWORK", "false",
+		},
+		{
+			&config.Config{},
+			&config.Config{Sprig: true},
+			"GOMPLATE_SPRIG", "true",
+		},
+		{
+			&config.Config{},
+			&config.Config{AllowExec: true},
+			"GOMPLATE_ALLOW_EXEC", "true",
+		},
+		{
+			&config.Config{},
+			&config.Config{ExecTimeout: 2 * time.Second},
+			"GOMPLATE_EXEC_TIMEOUT", "2s",
+		},
+		{
+			&config.Config{ExecTimeout: time.Second},
+			&config.Config{ExecTimeout: time.Second},
+			"GOMPLATE_EXEC_TIMEOUT", "2s",
+		},
 		{
 			&config.Config{},
 			&config.Config{LDelim: "--"},
@@ -274,6 +386,56 @@ func TestApplyEnvVars(t *testing.T) {
 			&config.Config{RDelim: "}}"},
 			"GOMPLATE_RIGHT_DELIM", "",
 		},
+		{
+			&config.Config{},
+			&config.Config{TLSCert: "cert.pem"},
+			"GOMPLATE_TLS_CERT", "cert.pem",
+		},
+		{
+			&config.Config{TLSKey: "other.pem"},
+			&config.Config{TLSKey: "other.pem"},
+			"GOMPLATE_TLS_KEY", "key.pem",
+		},
+		{
+			&config.Config{},
+			&config.Config{CABundle: "ca.pem"},
+			"GOMPLATE_CA_BUNDLE", "ca.pem",
+		},
+		{
+			&config.Config{},
+			&config.Config{DatasourceCacheTTL: 5 * time.Minute},
+			"GOMPLATE_DATASOURCE_CACHE_TTL", "5m",
+		},
+		{
+			&config.Config{DatasourceCacheTTL: time.Minute},
+			&config.Config{DatasourceCacheTTL: time.Minute},
+			"GOMPLATE_DATASOURCE_CACHE_TTL", "5m",
+		},
+		{
+			&config.Config{},
+			&config.Config{DatasourceCacheDir: "/tmp/cache"},
+			"GOMPLATE_DATASOURCE_CACHE_DIR", "/tmp/cache",
+		},
+		{
+			&config.Config{},
+			&config.Config{DatasourcePrefetch: 8},
+			"GOMPLATE_DATASOURCE_PREFETCH", "8",
+		},
+		{
+			&config.Config{},
+			&config.Config{DatasourceTimeout: 30 * time.Second},
+			"GOMPLATE_DATASOURCE_TIMEOUT", "30s",
+		},
+		{
+			&config.Config{},
+			&config.Config{DatasourceRetries: 3},
+			"GOMPLATE_DATASOURCE_RETRIES", "3",
+		},
+		{
+			&config.Config{DatasourceRetries: 1},
+			&config.Config{DatasourceRetries: 1},
+			"GOMPLATE_DATASOURCE_RETRIES", "3",
+		},
 	}
 
 	for i, d := range data {
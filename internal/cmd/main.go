@@ -9,6 +9,7 @@ import (
 
 	"github.com/hairyhenderson/gomplate/v4"
 	"github.com/hairyhenderson/gomplate/v4/env"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 	"github.com/hairyhenderson/gomplate/v4/version"
 
@@ -68,6 +69,22 @@ func optionalExecArgs(cmd *cobra.Command, args []string) error {
 	return cobra.NoArgs(cmd, args)
 }
 
+// experimentalContext - enable experimental features in the context, if
+// configured
+func experimentalContext(ctx context.Context, cfg *config.Config) context.Context {
+	if !cfg.Experimental {
+		return ctx
+	}
+
+	log := zerolog.Ctx(ctx)
+	log.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Bool("experimental", true)
+	})
+	log.Info().Msg("experimental functions and features enabled!")
+
+	return gomplate.SetExperimental(ctx)
+}
+
 // NewGomplateCmd -
 func NewGomplateCmd() *cobra.Command {
 	rootCmd := &cobra.Command{
@@ -86,14 +103,7 @@ func NewGomplateCmd() *cobra.Command {
 				return err
 			}
 
-			if cfg.Experimental {
-				log.UpdateContext(func(c zerolog.Context) zerolog.Context {
-					return c.Bool("experimental", true)
-				})
-				log.Info().Msg("experimental functions and features enabled!")
-
-				ctx = gomplate.SetExperimental(ctx)
-			}
+			ctx = experimentalContext(ctx, cfg)
 
 			log.Debug().Msgf("starting %s", cmd.Name())
 			log.Debug().
@@ -101,6 +111,14 @@ func NewGomplateCmd() *cobra.Command {
 				Str("build", version.GitCommit).
 				Msgf("config is:\n%v", cfg)
 
+			if cfg.Watch {
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+
+				// the post-exec command is supervised while watching
+				return watchAndExec(ctx, cfg, cmd.OutOrStdout(), cmd.ErrOrStderr())
+			}
+
 			err = gomplate.Run(ctx, cfg)
 			cmd.SilenceErrors = true
 			cmd.SilenceUsage = true
@@ -117,6 +135,11 @@ func NewGomplateCmd() *cobra.Command {
 		},
 		Args: optionalExecArgs,
 	}
+
+	// cobra adds a completion command when there are subcommands, which isn't
+	// needed
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+
 	return rootCmd
 }
 
@@ -129,8 +152,20 @@ func InitFlags(command *cobra.Command) {
 
 	command.Flags().StringSliceP("datasource", "d", nil, "`datasource` in alias=URL form. Specify multiple times to add multiple sources.")
 	command.Flags().StringSliceP("datasource-header", "H", nil, "HTTP `header` field in 'alias=Name: value' form to be provided on HTTP-based data sources. Multiples can be set.")
+	command.Flags().StringSlice("datasource-schema", nil, "JSON Schema to validate a datasource against, in alias=URL form. Specify multiple times to validate multiple datasources.")
+	command.Flags().String("tls-cert", "", "TLS client certificate `file` (PEM) for HTTPS datasources [$GOMPLATE_TLS_CERT]")
+	command.Flags().String("tls-key", "", "TLS client private key `file` (PEM) for HTTPS datasources [$GOMPLATE_TLS_KEY]")
+	command.Flags().String("ca-bundle", "", "`file` containing additional trusted CA certificates (PEM) for HTTPS datasources [$GOMPLATE_CA_BUNDLE]")
+	command.Flags().Duration("datasource-cache-ttl", 0, "how long to cache datasource reads for - 0 caches for the whole run [$GOMPLATE_DATASOURCE_CACHE_TTL]")
+	command.Flags().String("datasource-cache-dir", "", "`directory` to persist cached remote datasource reads in, between runs (requires --datasource-cache-ttl) [$GOMPLATE_DATASOURCE_CACHE_DIR]")
+	command.Flags().Int("datasource-prefetch", 0, "read all datasources concurrently with this many `workers` before rendering - 0 disables [$GOMPLATE_DATASOURCE_PREFETCH]")
+	command.Flags().Duration("datasource-timeout", 0, "timeout for each attempt to read a datasource - 0 means no timeout [$GOMPLATE_DATASOURCE_TIMEOUT]")
+	command.Flags().Int("datasource-retries", 0, "number of times to retry failed datasource reads, with exponential backoff [$GOMPLATE_DATASOURCE_RETRIES]")
 
 	command.Flags().StringSliceP("context", "c", nil, "pre-load a `datasource` into the context, in alias=URL form. Use the special alias `.` to set the root context.")
+	command.Flags().StringArray("set", nil, "set a value in the context, in `path=value` form (like a.b=c), overriding values from --context datasources. Separate multiple values with commas. Can be specified multiple times")
+	command.Flags().StringArray("set-string", nil, "like --set, but always sets the value as a string, in `path=value` form")
+	command.Flags().StringArray("set-file", nil, "like --set, but sets the value to the contents of a file, in `path=file` form")
 
 	command.Flags().StringSlice("plugin", nil, "plug in an external command as a function in name=path form. Can be specified multiple times")
 
@@ -147,6 +182,15 @@ func InitFlags(command *cobra.Command) {
 	command.Flags().String("output-dir", ".", "`directory` to store the processed templates. Only used for --input-dir")
 	command.Flags().String("output-map", "", "Template `string` to map the input file to an output path")
 	command.Flags().String("chmod", "", "set the mode for output file(s). Omit to inherit from input file(s)")
+	command.Flags().String("backup-suffix", "", "copy existing output files to a backup file with this `suffix` (like .bak) before overwriting them")
+
+	command.Flags().Bool("watch", false, "watch the input templates and local file datasources for changes, and re-render when they change")
+	command.Flags().Bool("watch-poll", false, "in watch mode, poll for changes instead of using filesystem notifications, for filesystems which don't support them")
+	command.Flags().Duration("watch-interval", 0, "how often to poll for changes in watch mode, with --watch-poll (default 1s)")
+	command.Flags().String("exec-signal", "", "`signal` to send to the post-run exec command when templates are re-rendered in watch mode, like HUP. Omit to restart the command instead")
+
+	command.Flags().Bool("dry-run", false, "render templates without writing any output files")
+	command.Flags().Bool("diff", false, "show a unified diff of the changes to output files, without writing them (implies --dry-run)")
 
 	command.Flags().Bool("exec-pipe", false, "pipe the output to the post-run exec command")
 
@@ -159,6 +203,11 @@ func InitFlags(command *cobra.Command) {
 	command.Flags().String("missing-key", "error", "Control the behavior during execution if a map is indexed with a key that is not present in the map. error (default) - return an error, zero - fallback to zero value, default/invalid - print <no value>")
 
 	command.Flags().Bool("experimental", false, "enable experimental features [$GOMPLATE_EXPERIMENTAL]")
+	command.Flags().Bool("disable-network", false, "disable functions which perform network lookups [$GOMPLATE_DISABLE_NETWORK]")
+	command.Flags().Bool("allow-exec", false, "enable the exec function, which runs local commands [$GOMPLATE_ALLOW_EXEC]")
+	command.Flags().Duration("exec-timeout", 0, "timeout for commands run by the exec function (default 5s) [$GOMPLATE_EXEC_TIMEOUT]")
+	command.Flags().StringArray("script", nil, "preload a Starlark script, so its functions can be called with script.Run. Can be specified multiple times")
+	command.Flags().Bool("sprig", false, "add Sprig-compatible functions, for reusing Helm-style templates [$GOMPLATE_SPRIG]")
 
 	command.Flags().BoolP("verbose", "V", false, "output extra information about what gomplate is doing")
 
@@ -177,6 +226,10 @@ func Main(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io
 
 	command := NewGomplateCmd()
 	InitFlags(command)
+
+	serveCmd := newServeCmd()
+	initServeFlags(serveCmd)
+	command.AddCommand(serveCmd)
 	command.SetArgs(args)
 	command.SetIn(stdin)
 	command.SetOut(stdout)
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/hairyhenderson/gomplate/v4"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+// newServeCmd - the serve subcommand, which renders templates over HTTP
+func newServeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Serve rendered templates over HTTP",
+		Long: `Serve the templates in --input-dir over HTTP, rendering them on each request.
+
+Request paths are mapped to the templates' output paths (see --output-map), and
+the request's details (including query parameters) are available in the
+template context as .request.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if v, _ := cmd.Flags().GetBool("verbose"); v {
+				zerolog.SetGlobalLevel(zerolog.DebugLevel)
+			}
+			ctx := cmd.Context()
+
+			cfg, err := loadConfig(ctx, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			ctx = experimentalContext(ctx, cfg)
+
+			addr, err := cmd.Flags().GetString("listen")
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			// serve until interrupted
+			ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return gomplate.Serve(ctx, cfg, addr)
+		},
+	}
+}
+
+// initServeFlags - the serve command accepts the same flags as the root
+// command, plus the address to listen on
+func initServeFlags(command *cobra.Command) {
+	InitFlags(command)
+
+	command.Flags().String("listen", ":8080", "`address` to listen for HTTP requests on")
+}
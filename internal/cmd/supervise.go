@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/hairyhenderson/gomplate/v4"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/rs/zerolog"
+)
+
+// how long to wait for the post-exec command to stop before killing it
+const stopTimeout = 10 * time.Second
+
+// watchAndExec - render the templates and watch them for changes until
+// interrupted, supervising the post-exec command (if any). When the command
+// exits on its own, watching stops, and the command's exit error is returned.
+func watchAndExec(ctx context.Context, cfg *config.Config, stdout, stderr io.Writer) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if len(cfg.PostExec) == 0 {
+		return gomplate.Watch(ctx, cfg, nil)
+	}
+
+	var reloadSig os.Signal
+	if cfg.ExecSignal != "" {
+		var err error
+		reloadSig, err = parseSignal(cfg.ExecSignal)
+		if err != nil {
+			return err
+		}
+	}
+
+	// execPipe can't be used in watch mode, so the command always reads from
+	// stdin
+	sup := newSupervisor(cfg.PostExec, reloadSig, cfg.Stdin, stdout, stderr)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		select {
+		case err := <-sup.exited:
+			zerolog.Ctx(ctx).Info().Err(err).Msg("post-exec command exited, no longer watching")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err := gomplate.Watch(ctx, cfg, sup.reload)
+	if serr := sup.stop(ctx); err == nil {
+		err = serr
+	}
+	return err
+}
+
+// supervisor runs the post-exec command in watch mode, starting it after the
+// templates are first rendered, and restarting it (or sending it a signal)
+// each time they're re-rendered
+type supervisor struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	// the signal to send to the command when the templates are re-rendered -
+	// when nil, the command is restarted instead
+	reloadSig os.Signal
+
+	// receives the command's exit error when it exits on its own
+	exited chan error
+
+	proc *process
+	args []string
+
+	mu sync.Mutex
+}
+
+// process - a started command
+type process struct {
+	cmd  *exec.Cmd
+	done chan struct{}
+	err  error
+
+	// set when the supervisor stops the command, so the exit isn't reported
+	stopped atomic.Bool
+}
+
+func newSupervisor(args []string, reloadSig os.Signal, stdin io.Reader, stdout, stderr io.Writer) *supervisor {
+	return &supervisor{
+		args:      args,
+		reloadSig: reloadSig,
+		stdin:     stdin,
+		stdout:    stdout,
+		stderr:    stderr,
+		exited:    make(chan error, 1),
+	}
+}
+
+// reload - start the command if it isn't running, otherwise signal or
+// restart it
+func (s *supervisor) reload(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log := zerolog.Ctx(ctx)
+
+	if s.proc != nil {
+		if s.reloadSig != nil {
+			log.Debug().Stringer("signal", s.reloadSig).Msg("signalling post-exec command")
+
+			err := s.proc.cmd.Process.Signal(s.reloadSig)
+			if err != nil {
+				return fmt.Errorf("failed to signal post-exec command: %w", err)
+			}
+			return nil
+		}
+
+		log.Debug().Msg("restarting post-exec command")
+		s.proc.stop(ctx)
+	}
+
+	return s.start(ctx)
+}
+
+func (s *supervisor) start(ctx context.Context) error {
+	zerolog.Ctx(ctx).Debug().Strs("args", s.args).Msg("starting post-exec command")
+
+	// the command's lifetime is managed by the supervisor, so the context
+	// isn't used here
+	//nolint:gosec
+	c := exec.Command(s.args[0], s.args[1:]...)
+	c.Stdin = s.stdin
+	c.Stdout = s.stdout
+	c.Stderr = s.stderr
+
+	if err := c.Start(); err != nil {
+		return err
+	}
+
+	p := &process{cmd: c, done: make(chan struct{})}
+	s.proc = p
+
+	go func() {
+		p.err = c.Wait()
+		close(p.done)
+
+		if !p.stopped.Load() {
+			select {
+			case s.exited <- p.err:
+			default:
+			}
+		}
+	}()
+
+	return nil
+}
+
+// stop - stop the command if it's still running. If it had already exited on
+// its own, its exit error is returned.
+func (s *supervisor) stop(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.proc
+	if p == nil {
+		return nil
+	}
+
+	select {
+	case <-p.done:
+		if !p.stopped.Load() {
+			return p.err
+		}
+		return nil
+	default:
+	}
+
+	p.stop(ctx)
+	return nil
+}
+
+// stop - ask the command to stop, and kill it if it doesn't stop in time
+func (p *process) stop(ctx context.Context) {
+	log := zerolog.Ctx(ctx)
+
+	p.stopped.Store(true)
+	_ = p.cmd.Process.Signal(stopSignal)
+
+	select {
+	case <-p.done:
+		return
+	case <-time.After(stopTimeout):
+	}
+
+	log.Warn().Dur("timeout", stopTimeout).Msg("post-exec command didn't stop in time, killing")
+	_ = p.cmd.Process.Kill()
+	<-p.done
+}
@@ -0,0 +1,35 @@
+//go:build !windows
+// +build !windows
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// stopSignal - sent to the post-exec command to stop it before restarting
+var stopSignal os.Signal = syscall.SIGTERM
+
+// parseSignal - parse a signal name like "HUP" or "SIGHUP", or a number
+func parseSignal(name string) (os.Signal, error) {
+	if n, err := strconv.Atoi(name); err == nil && n > 0 {
+		return syscall.Signal(n), nil
+	}
+
+	name = strings.ToUpper(name)
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+
+	sig := unix.SignalNum(name)
+	if sig == 0 {
+		return nil, fmt.Errorf("unknown signal %q", name)
+	}
+	return sig, nil
+}
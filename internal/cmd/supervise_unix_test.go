@@ -0,0 +1,133 @@
+//go:build !windows
+// +build !windows
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSignal(t *testing.T) {
+	for _, s := range []string{"HUP", "hup", "SIGHUP", "1"} {
+		sig, err := parseSignal(s)
+		require.NoError(t, err)
+		assert.Equal(t, syscall.SIGHUP, sig)
+	}
+
+	sig, err := parseSignal("usr1")
+	require.NoError(t, err)
+	assert.Equal(t, syscall.SIGUSR1, sig)
+
+	_, err = parseSignal("bogus")
+	assert.Error(t, err)
+}
+
+// readLines - read the lines written to the file by the test command
+func readLines(t *testing.T, name string) []string {
+	t.Helper()
+
+	b, err := os.ReadFile(name)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	require.NoError(t, err)
+
+	return strings.Fields(string(b))
+}
+
+func TestSupervisorRestart(t *testing.T) {
+	ctx := context.Background()
+	out := filepath.Join(t.TempDir(), "out")
+
+	sup := newSupervisor([]string{"sh", "-c", "echo started >> " + out + "; exec sleep 30"},
+		nil, nil, nil, nil)
+
+	require.NoError(t, sup.reload(ctx))
+	require.Eventually(t, func() bool {
+		return len(readLines(t, out)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	first := sup.proc
+
+	require.NoError(t, sup.reload(ctx))
+	require.Eventually(t, func() bool {
+		return len(readLines(t, out)) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the first process was stopped
+	assert.NotSame(t, first, sup.proc)
+	<-first.done
+
+	require.NoError(t, sup.stop(ctx))
+	<-sup.proc.done
+
+	// stopped processes aren't reported as exited
+	assert.Empty(t, sup.exited)
+}
+
+func TestSupervisorSignal(t *testing.T) {
+	ctx := context.Background()
+	out := filepath.Join(t.TempDir(), "out")
+
+	script := `trap "echo reloaded >> ` + out + `" HUP
+echo started >> ` + out + `
+while true; do sleep 0.05; done`
+	sup := newSupervisor([]string{"sh", "-c", script}, syscall.SIGHUP, nil, nil, nil)
+
+	require.NoError(t, sup.reload(ctx))
+	require.Eventually(t, func() bool {
+		return len(readLines(t, out)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	first := sup.proc
+
+	require.NoError(t, sup.reload(ctx))
+	require.Eventually(t, func() bool {
+		return len(readLines(t, out)) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the process was signalled, not restarted
+	assert.Equal(t, []string{"started", "reloaded"}, readLines(t, out))
+	assert.Same(t, first, sup.proc)
+
+	require.NoError(t, sup.stop(ctx))
+}
+
+func TestSupervisorExited(t *testing.T) {
+	ctx := context.Background()
+
+	sup := newSupervisor([]string{"sh", "-c", "exit 3"}, nil, nil, nil, nil)
+
+	// nothing to stop yet
+	require.NoError(t, sup.stop(ctx))
+
+	require.NoError(t, sup.reload(ctx))
+
+	select {
+	case err := <-sup.exited:
+		var exitErr *exec.ExitError
+		require.ErrorAs(t, err, &exitErr)
+		assert.Equal(t, 3, exitErr.ExitCode())
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for command to exit")
+	}
+
+	// the exit error is returned when stopping
+	err := sup.stop(ctx)
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 3, exitErr.ExitCode())
+
+	sup = newSupervisor([]string{"/bogus/command"}, nil, nil, nil, nil)
+	assert.Error(t, sup.reload(ctx))
+}
@@ -0,0 +1,18 @@
+//go:build windows
+// +build windows
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+)
+
+// stopSignal - Windows doesn't support sending signals other than Kill
+var stopSignal = os.Kill
+
+// parseSignal - signals can't be sent on Windows, so commands are always
+// restarted
+func parseSignal(name string) (os.Signal, error) {
+	return nil, fmt.Errorf("sending signals to the post-exec command is not supported on Windows (got %q)", name)
+}
@@ -60,6 +60,10 @@ type Config struct {
 	OutputFiles []string `yaml:"outputFiles,omitempty,flow"`
 	OutMode     string   `yaml:"chmod,omitempty"`
 
+	// when set, existing output files are copied to a backup file with this
+	// suffix before they're overwritten
+	BackupSuffix string `yaml:"backupSuffix,omitempty"`
+
 	LDelim string `yaml:"leftDelim,omitempty"`
 	RDelim string `yaml:"rightDelim,omitempty"`
 
@@ -67,11 +71,66 @@ type Config struct {
 
 	PostExec []string `yaml:"postExec,omitempty,flow"`
 
+	// watch the input templates and local datasources for changes, and
+	// re-render when they change. Changes are detected with filesystem
+	// notifications, or with WatchPoll, by checking every WatchInterval.
+	Watch         bool          `yaml:"watch,omitempty"`
+	WatchPoll     bool          `yaml:"watchPoll,omitempty"`
+	WatchInterval time.Duration `yaml:"watchInterval,omitempty"`
+
+	// render without writing output files, and (with Diff) show a unified
+	// diff of the changes that would be made. Diff implies DryRun.
+	DryRun bool `yaml:"dryRun,omitempty"`
+	Diff   bool `yaml:"diff,omitempty"`
+
+	// the signal to send to the postExec command when the templates are
+	// re-rendered in watch mode - when empty, the command is restarted
+	ExecSignal string `yaml:"execSignal,omitempty"`
+
+	// values to set in the context after the context datasources are read,
+	// in path=value form
+	Set       []string `yaml:"set,omitempty"`
+	SetString []string `yaml:"setString,omitempty"`
+	SetFile   []string `yaml:"setFile,omitempty"`
+
 	PluginTimeout time.Duration `yaml:"pluginTimeout,omitempty"`
 
+	// how long datasource reads are cached for (0 means for the whole run),
+	// and an optional directory for persisting the cache between runs
+	DatasourceCacheTTL time.Duration `yaml:"datasourceCacheTTL,omitempty"`
+	DatasourceCacheDir string        `yaml:"datasourceCacheDir,omitempty"`
+
+	// number of workers to use to read all datasources before rendering (0
+	// disables prefetching)
+	DatasourcePrefetch int `yaml:"datasourcePrefetch,omitempty"`
+
+	// default timeout and number of retries for reading datasources
+	DatasourceTimeout time.Duration `yaml:"datasourceTimeout,omitempty"`
+	DatasourceRetries int           `yaml:"datasourceRetries,omitempty"`
+
+	// TLS client certificate, key, and CA bundle for HTTPS datasources
+	TLSCert  string `yaml:"tlsCert,omitempty"`
+	TLSKey   string `yaml:"tlsKey,omitempty"`
+	CABundle string `yaml:"caBundle,omitempty"`
+
 	ExecPipe      bool `yaml:"execPipe,omitempty"`
 	SuppressEmpty bool `yaml:"suppressEmpty,omitempty"`
 	Experimental  bool `yaml:"experimental,omitempty"`
+
+	// DisableNetwork - disable functions which perform network lookups
+	DisableNetwork bool `yaml:"disableNetwork,omitempty"`
+
+	// Sprig - add Sprig-compatible functions, for reusing Helm-style templates
+	Sprig bool `yaml:"sprig,omitempty"`
+
+	// AllowExec - enable the exec function, which runs local commands, and the
+	// timeout for each command
+	AllowExec   bool          `yaml:"allowExec,omitempty"`
+	ExecTimeout time.Duration `yaml:"execTimeout,omitempty"`
+
+	// Scripts - Starlark scripts to preload, so their functions are available
+	// to script.Run
+	Scripts []string `yaml:"scripts,omitempty"`
 }
 
 type experimentalCtxKey struct{}
@@ -85,6 +144,61 @@ func ExperimentalEnabled(ctx context.Context) bool {
 	return ok && v
 }
 
+type networkDisabledCtxKey struct{}
+
+// SetNetworkDisabled - disable functions which perform network lookups
+func SetNetworkDisabled(ctx context.Context) context.Context {
+	return context.WithValue(ctx, networkDisabledCtxKey{}, true)
+}
+
+// NetworkDisabled - whether network lookups have been disabled
+func NetworkDisabled(ctx context.Context) bool {
+	v, ok := ctx.Value(networkDisabledCtxKey{}).(bool)
+	return ok && v
+}
+
+type sprigCtxKey struct{}
+
+// SetSprig - enable Sprig-compatible functions
+func SetSprig(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sprigCtxKey{}, true)
+}
+
+// SprigEnabled - whether Sprig-compatible functions have been enabled
+func SprigEnabled(ctx context.Context) bool {
+	v, ok := ctx.Value(sprigCtxKey{}).(bool)
+	return ok && v
+}
+
+type datasourceRefreshCtxKey struct{}
+
+// SetDatasourceRefresh - force datasources to be re-read, instead of using
+// content cached on disk by previous runs
+func SetDatasourceRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, datasourceRefreshCtxKey{}, true)
+}
+
+// DatasourceRefresh - whether datasources must be re-read
+func DatasourceRefresh(ctx context.Context) bool {
+	v, ok := ctx.Value(datasourceRefreshCtxKey{}).(bool)
+	return ok && v
+}
+
+type execCtxKey struct{}
+
+// SetExecAllowed - enable the exec function, with the given timeout for each
+// command (0 for the default)
+func SetExecAllowed(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, execCtxKey{}, timeout)
+}
+
+// ExecAllowed - whether the exec function has been enabled, and the timeout
+// for each command
+func ExecAllowed(ctx context.Context) (bool, time.Duration) {
+	timeout, ok := ctx.Value(execCtxKey{}).(time.Duration)
+	return ok, timeout
+}
+
 // mergeDataSources - use d as defaults, and override with values from o
 func mergeDataSources(d, o map[string]DataSource) map[string]DataSource {
 	for k, v := range o {
@@ -102,6 +216,9 @@ func mergeDataSources(d, o map[string]DataSource) map[string]DataSource {
 type DataSource struct {
 	URL    *url.URL    `yaml:"-"`
 	Header http.Header `yaml:"header,omitempty,flow"`
+	// Schema is the URL of an optional JSON Schema which the datasource's
+	// contents are validated against
+	Schema *url.URL `yaml:"-"`
 }
 
 // UnmarshalYAML - satisfy the yaml.Umarshaler interface - URLs aren't
@@ -110,6 +227,7 @@ func (d *DataSource) UnmarshalYAML(value *yaml.Node) error {
 	type raw struct {
 		Header http.Header
 		URL    string
+		Schema string
 	}
 	r := raw{}
 	err := value.Decode(&r)
@@ -120,9 +238,17 @@ func (d *DataSource) UnmarshalYAML(value *yaml.Node) error {
 	if err != nil {
 		return fmt.Errorf("could not parse datasource URL %q: %w", r.URL, err)
 	}
+	var schema *url.URL
+	if r.Schema != "" {
+		schema, err = urlhelpers.ParseSourceURL(r.Schema)
+		if err != nil {
+			return fmt.Errorf("could not parse datasource schema URL %q: %w", r.Schema, err)
+		}
+	}
 	*d = DataSource{
 		URL:    u,
 		Header: r.Header,
+		Schema: schema,
 	}
 	return nil
 }
@@ -133,11 +259,15 @@ func (d DataSource) MarshalYAML() (interface{}, error) {
 	type raw struct {
 		Header http.Header
 		URL    string
+		Schema string `yaml:",omitempty"`
 	}
 	r := raw{
 		URL:    d.URL.String(),
 		Header: d.Header,
 	}
+	if d.Schema != nil {
+		r.Schema = d.Schema.String()
+	}
 	return r, nil
 }
 
@@ -146,6 +276,9 @@ func (d DataSource) mergeFrom(o DataSource) DataSource {
 	if o.URL != nil {
 		d.URL = o.URL
 	}
+	if o.Schema != nil {
+		d.Schema = o.Schema
+	}
 	if d.Header == nil {
 		d.Header = o.Header
 	} else {
@@ -253,12 +386,54 @@ func (c *Config) MergeFrom(o *Config) *Config {
 	if !isZero(o.OutMode) {
 		c.OutMode = o.OutMode
 	}
+	if !isZero(o.BackupSuffix) {
+		c.BackupSuffix = o.BackupSuffix
+	}
 	if !isZero(o.LDelim) {
 		c.LDelim = o.LDelim
 	}
 	if !isZero(o.RDelim) {
 		c.RDelim = o.RDelim
 	}
+	if !isZero(o.TLSCert) {
+		c.TLSCert = o.TLSCert
+	}
+	if !isZero(o.TLSKey) {
+		c.TLSKey = o.TLSKey
+	}
+	if !isZero(o.CABundle) {
+		c.CABundle = o.CABundle
+	}
+	if !isZero(o.DatasourceCacheTTL) {
+		c.DatasourceCacheTTL = o.DatasourceCacheTTL
+	}
+	if !isZero(o.DatasourceCacheDir) {
+		c.DatasourceCacheDir = o.DatasourceCacheDir
+	}
+	if !isZero(o.DatasourcePrefetch) {
+		c.DatasourcePrefetch = o.DatasourcePrefetch
+	}
+	if !isZero(o.DatasourceTimeout) {
+		c.DatasourceTimeout = o.DatasourceTimeout
+	}
+	if !isZero(o.DatasourceRetries) {
+		c.DatasourceRetries = o.DatasourceRetries
+	}
+	if !isZero(o.DisableNetwork) {
+		c.DisableNetwork = o.DisableNetwork
+	}
+	if !isZero(o.Sprig) {
+		c.Sprig = o.Sprig
+	}
+	if !isZero(o.AllowExec) {
+		c.AllowExec = o.AllowExec
+	}
+	if !isZero(o.ExecTimeout) {
+		c.ExecTimeout = o.ExecTimeout
+	}
+	if !isZero(o.Scripts) {
+		c.Scripts = o.Scripts
+	}
 	if c.Templates == nil {
 		c.Templates = o.Templates
 	} else {
@@ -280,6 +455,31 @@ func (c *Config) MergeFrom(o *Config) *Config {
 		}
 	}
 
+	if !isZero(o.Watch) {
+		c.Watch = o.Watch
+	}
+	if !isZero(o.WatchPoll) {
+		c.WatchPoll = o.WatchPoll
+	}
+	if !isZero(o.WatchInterval) {
+		c.WatchInterval = o.WatchInterval
+	}
+	if !isZero(o.ExecSignal) {
+		c.ExecSignal = o.ExecSignal
+	}
+	if !isZero(o.DryRun) {
+		c.DryRun = o.DryRun
+	}
+	if !isZero(o.Diff) {
+		c.Diff = o.Diff
+	}
+
+	// overrides from the commandline are applied after those in the config
+	// file, so they take precedence
+	c.Set = append(c.Set, o.Set...)
+	c.SetString = append(c.SetString, o.SetString...)
+	c.SetFile = append(c.SetFile, o.SetFile...)
+
 	return c
 }
 
@@ -355,6 +555,39 @@ func (c *Config) parseResources(datasources, contexts, templates []string) error
 	return nil
 }
 
+// ParseDataSourceSchemaFlags - sets the Schema field of datasources and
+// contexts from the alias=URL format flags as provided at the command-line
+func (c *Config) ParseDataSourceSchemaFlags(schemas []string) error {
+	for _, arg := range schemas {
+		alias, u, ok := strings.Cut(arg, "=")
+		if !ok || alias == "" || u == "" {
+			return fmt.Errorf("invalid datasource-schema option '%s': must be in alias=URL form", arg)
+		}
+
+		schema, err := urlhelpers.ParseSourceURL(u)
+		if err != nil {
+			return fmt.Errorf("invalid datasource-schema option '%s': %w", arg, err)
+		}
+
+		found := false
+		if d, ok := c.DataSources[alias]; ok {
+			d.Schema = schema
+			c.DataSources[alias] = d
+			found = true
+		}
+		if d, ok := c.Context[alias]; ok {
+			d.Schema = schema
+			c.Context[alias] = d
+			found = true
+		}
+
+		if !found {
+			return fmt.Errorf("invalid datasource-schema option '%s': no datasource or context named '%s'", arg, alias)
+		}
+	}
+	return nil
+}
+
 // ParsePluginFlags - sets the Plugins field from the
 // key=value format flags as provided at the command-line
 func (c *Config) ParsePluginFlags(plugins []string) error {
@@ -473,6 +706,58 @@ func (c Config) Validate() (err error) {
 		}
 	}
 
+	if err == nil && c.templateFromStdin() && c.datasourceFromStdin() {
+		err = fmt.Errorf("standard input can not be used for both the template and a datasource - use 'in' or 'inputFiles' to provide the template")
+	}
+
+	if err == nil && c.Watch && (c.templateFromStdin() || c.datasourceFromStdin()) {
+		err = fmt.Errorf("standard input can not be used with 'watch' - use 'in', 'inputFiles', or 'inputDir' to provide the template")
+	}
+
+	if err == nil && c.Watch && c.ExecPipe {
+		err = fmt.Errorf("'watch' can not be used with 'execPipe'")
+	}
+
+	if err == nil && c.ExecSignal != "" && (!c.Watch || len(c.PostExec) == 0) {
+		err = fmt.Errorf("'execSignal' requires 'watch' and a postExec command")
+	}
+
+	if err == nil && (c.DryRun || c.Diff) && len(c.PostExec) > 0 {
+		err = fmt.Errorf("'dryRun' and 'diff' can not be used with a postExec command, as no output is written")
+	}
+
+	if err == nil && strings.ContainsAny(c.BackupSuffix, `/\`) {
+		err = fmt.Errorf("'backupSuffix' must not contain path separators")
+	}
+
+	if err == nil {
+		err = mustTogether("tlsCert", "tlsKey", c.TLSCert, c.TLSKey)
+	}
+
+	if err == nil {
+		err = mustTogether("tlsKey", "tlsCert", c.TLSKey, c.TLSCert)
+	}
+
+	if err == nil {
+		err = mustTogether("datasourceCacheDir", "datasourceCacheTTL", c.DatasourceCacheDir, c.DatasourceCacheTTL)
+	}
+
+	if err == nil && c.DatasourceCacheTTL < 0 {
+		err = fmt.Errorf("datasourceCacheTTL must not be negative")
+	}
+
+	if err == nil && c.DatasourcePrefetch < 0 {
+		err = fmt.Errorf("datasourcePrefetch must not be negative")
+	}
+
+	if err == nil && c.DatasourceTimeout < 0 {
+		err = fmt.Errorf("datasourceTimeout must not be negative")
+	}
+
+	if err == nil && c.DatasourceRetries < 0 {
+		err = fmt.Errorf("datasourceRetries must not be negative")
+	}
+
 	if err == nil {
 		missingKeyValues := []string{"", "error", "zero", "default", "invalid"}
 		if !slices.Contains(missingKeyValues, c.MissingKey) {
@@ -483,6 +768,30 @@ func (c Config) Validate() (err error) {
 	return err
 }
 
+// templateFromStdin returns true if the template will be read from standard
+// input, either explicitly or by default
+func (c Config) templateFromStdin() bool {
+	if c.Input != "" || c.InputDir != "" {
+		return false
+	}
+
+	return len(c.InputFiles) == 0 || slices.Contains(c.InputFiles, "-")
+}
+
+// datasourceFromStdin returns true if any datasource or context is read from
+// standard input
+func (c Config) datasourceFromStdin() bool {
+	for _, sources := range []map[string]DataSource{c.DataSources, c.Context} {
+		for _, ds := range sources {
+			if ds.URL != nil && ds.URL.Scheme == "stdin" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func notTogether(names []string, values ...interface{}) error {
 	found := ""
 	for i, value := range values {
@@ -515,6 +824,10 @@ func isZero(value interface{}) bool {
 		return len(v) == 0
 	case bool:
 		return !v
+	case int:
+		return v == 0
+	case time.Duration:
+		return v == 0
 	default:
 		return false
 	}
@@ -566,6 +879,11 @@ func (c *Config) ApplyDefaults() {
 	if c.PluginTimeout == 0 {
 		c.PluginTimeout = 5 * time.Second
 	}
+
+	// showing a diff implies a dry run
+	if c.Diff {
+		c.DryRun = true
+	}
 }
 
 // GetMode - parse an os.FileMode out of the string, and let us know if it's an override or not...
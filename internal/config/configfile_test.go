@@ -31,6 +31,7 @@ chmod: 644
 datasources:
   data:
     url: file:///data.json
+    schema: file:///data.schema.json
   moredata:
     url: https://example.com/more.json
     header:
@@ -56,7 +57,8 @@ pluginTimeout: 2s
 		OutputFiles: []string{"out.txt"},
 		DataSources: map[string]DataSource{
 			"data": {
-				URL: mustURL("file:///data.json"),
+				URL:    mustURL("file:///data.json"),
+				Schema: mustURL("file:///data.schema.json"),
 			},
 			"moredata": {
 				URL: mustURL("https://example.com/more.json"),
@@ -167,6 +169,129 @@ postExec: [echo]
 execPipe: true
 outputMap: foo
 postExec: [echo]
+`))
+
+	assert.Error(t, validateConfig(`datasources:
+  foo:
+    url: stdin:///foo.json
+`))
+	assert.Error(t, validateConfig(`inputFiles: ['-']
+outputFiles: ['-']
+context:
+  foo:
+    url: stdin:///foo.json
+`))
+	require.NoError(t, validateConfig(`in: hello
+outputFiles: ['-']
+datasources:
+  foo:
+    url: stdin:///foo.json
+`))
+	require.NoError(t, validateConfig(`inputFiles: [in.tmpl]
+outputFiles: [out]
+datasources:
+  foo:
+    url: stdin:///foo.json
+`))
+
+	assert.Error(t, validateConfig(`watch: true
+`))
+	assert.Error(t, validateConfig(`watch: true
+inputFiles: ['-']
+outputFiles: ['-']
+`))
+	assert.Error(t, validateConfig(`watch: true
+in: hello
+outputFiles: ['-']
+datasources:
+  foo:
+    url: stdin:///foo.json
+`))
+	require.NoError(t, validateConfig(`watch: true
+in: hello
+outputFiles: ['-']
+postExec: [echo]
+`))
+	assert.Error(t, validateConfig(`watch: true
+in: hello
+execPipe: true
+postExec: [cat]
+`))
+	assert.Error(t, validateConfig(`execSignal: HUP
+in: hello
+outputFiles: ['-']
+postExec: [echo]
+`))
+	assert.Error(t, validateConfig(`watch: true
+execSignal: HUP
+in: hello
+outputFiles: ['-']
+`))
+	require.NoError(t, validateConfig(`watch: true
+execSignal: HUP
+in: hello
+outputFiles: ['-']
+postExec: [echo]
+`))
+	require.NoError(t, validateConfig(`watch: true
+inputDir: in
+outputDir: out
+`))
+
+	assert.Error(t, validateConfig(`dryRun: true
+in: hello
+outputFiles: ['-']
+postExec: [echo]
+`))
+	assert.Error(t, validateConfig(`diff: true
+in: hello
+outputFiles: ['-']
+postExec: [echo]
+`))
+	require.NoError(t, validateConfig(`diff: true
+inputDir: in
+outputDir: out
+`))
+
+	require.NoError(t, validateConfig(`backupSuffix: .bak
+in: hello
+outputFiles: [out]
+`))
+	assert.Error(t, validateConfig(`backupSuffix: /bak
+in: hello
+outputFiles: [out]
+`))
+
+	assert.Error(t, validateConfig(`tlsCert: cert.pem
+`))
+	assert.Error(t, validateConfig(`tlsKey: key.pem
+`))
+	require.NoError(t, validateConfig(`tlsCert: cert.pem
+tlsKey: key.pem
+caBundle: ca.pem
+`))
+
+	assert.Error(t, validateConfig(`datasourceCacheDir: /tmp/cache
+`))
+	assert.Error(t, validateConfig(`datasourceCacheTTL: -5m
+`))
+	require.NoError(t, validateConfig(`datasourceCacheTTL: 5m
+`))
+	require.NoError(t, validateConfig(`datasourceCacheTTL: 5m
+datasourceCacheDir: /tmp/cache
+`))
+
+	assert.Error(t, validateConfig(`datasourcePrefetch: -1
+`))
+	require.NoError(t, validateConfig(`datasourcePrefetch: 8
+`))
+
+	assert.Error(t, validateConfig(`datasourceTimeout: -1s
+`))
+	assert.Error(t, validateConfig(`datasourceRetries: -1
+`))
+	require.NoError(t, validateConfig(`datasourceTimeout: 10s
+datasourceRetries: 3
 `))
 }
 
@@ -425,6 +550,42 @@ func TestMergeFrom(t *testing.T) {
 	}
 
 	assert.EqualValues(t, expected, cfg.MergeFrom(other))
+
+	// overrides from both are kept, in order
+	cfg = &Config{
+		Set:       []string{"a=1"},
+		SetString: []string{"b=2"},
+	}
+	other = &Config{
+		Set:     []string{"a=2"},
+		SetFile: []string{"c=c.txt"},
+	}
+	expected = &Config{
+		Set:       []string{"a=1", "a=2"},
+		SetString: []string{"b=2"},
+		SetFile:   []string{"c=c.txt"},
+	}
+
+	assert.EqualValues(t, expected, cfg.MergeFrom(other))
+
+	cfg = &Config{
+		Watch:         true,
+		WatchInterval: 5 * time.Second,
+		ExecSignal:    "HUP",
+	}
+	other = &Config{
+		WatchPoll:     true,
+		WatchInterval: 2 * time.Second,
+		ExecSignal:    "USR1",
+	}
+	expected = &Config{
+		Watch:         true,
+		WatchPoll:     true,
+		WatchInterval: 2 * time.Second,
+		ExecSignal:    "USR1",
+	}
+
+	assert.EqualValues(t, expected, cfg.MergeFrom(other))
 }
 
 func TestParseDataSourceFlags(t *testing.T) {
@@ -517,6 +678,36 @@ func TestParseDataSourceFlags(t *testing.T) {
 	}, cfg)
 }
 
+func TestParseDataSourceSchemaFlags(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		DataSources: map[string]DataSource{
+			"foo": {URL: mustURL("file:///foo.json")},
+		},
+		Context: map[string]DataSource{
+			"bar": {URL: mustURL("file:///bar.yaml")},
+		},
+	}
+
+	err := cfg.ParseDataSourceSchemaFlags(nil)
+	require.NoError(t, err)
+	assert.Nil(t, cfg.DataSources["foo"].Schema)
+
+	err = cfg.ParseDataSourceSchemaFlags([]string{
+		"foo=file:///foo.schema.json",
+		"bar=https://example.com/bar.schema.json",
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, mustURL("file:///foo.schema.json"), cfg.DataSources["foo"].Schema)
+	assert.EqualValues(t, mustURL("https://example.com/bar.schema.json"), cfg.Context["bar"].Schema)
+
+	err = cfg.ParseDataSourceSchemaFlags([]string{"baz=file:///baz.schema.json"})
+	require.Error(t, err)
+
+	err = cfg.ParseDataSourceSchemaFlags([]string{"foo"})
+	require.Error(t, err)
+}
+
 func TestParsePluginFlags(t *testing.T) {
 	t.Parallel()
 	cfg := &Config{}
@@ -711,6 +902,11 @@ func TestApplyDefaults(t *testing.T) {
 	assert.Empty(t, cfg.OutputDir)
 	assert.False(t, cfg.ExecPipe)
 	assert.Equal(t, "bar", cfg.OutputMap)
+
+	cfg = &Config{Diff: true}
+
+	cfg.ApplyDefaults()
+	assert.True(t, cfg.DryRun)
 }
 
 func TestGetMode(t *testing.T) {
@@ -26,3 +26,23 @@ func InterfaceSlice(slice interface{}) ([]interface{}, error) {
 		return nil, fmt.Errorf("expected an array or slice, but got a %T", s)
 	}
 }
+
+// StringMap converts a map of any type into a map[string]interface{}, for use
+// in functions that expect this. Keys that aren't strings are converted with
+// fmt.Sprint.
+func StringMap(m interface{}) (map[string]interface{}, error) {
+	// avoid the reflection if this is already a map[string]interface{}
+	if s, ok := m.(map[string]interface{}); ok {
+		return s, nil
+	}
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map {
+		return nil, fmt.Errorf("expected a map, but got a %T", m)
+	}
+	ret := make(map[string]interface{}, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		ret[fmt.Sprint(iter.Key().Interface())] = iter.Value().Interface()
+	}
+	return ret, nil
+}
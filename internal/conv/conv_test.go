@@ -29,6 +29,30 @@ func TestInterfaceSlice(t *testing.T) {
 	assert.ErrorContains(t, err, "")
 }
 
+func TestStringMap(t *testing.T) {
+	data := []struct {
+		in       interface{}
+		expected map[string]interface{}
+	}{
+		{map[string]interface{}{"foo": 1}, map[string]interface{}{"foo": 1}},
+		{map[string]string{"foo": "bar"}, map[string]interface{}{"foo": "bar"}},
+		{map[interface{}]interface{}{"foo": "bar", 1: true}, map[string]interface{}{"foo": "bar", "1": true}},
+		{map[int]int{}, map[string]interface{}{}},
+	}
+
+	for _, d := range data {
+		out, err := StringMap(d.in)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, d.expected, out)
+	}
+
+	_, err := StringMap([]string{"foo"})
+	assert.ErrorContains(t, err, "expected a map")
+
+	_, err = StringMap(nil)
+	assert.ErrorContains(t, err, "expected a map")
+}
+
 func BenchmarkInterfaceSlice(b *testing.B) {
 	data := []interface{}{
 		[]int{1, 2, 3},
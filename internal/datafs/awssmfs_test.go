@@ -0,0 +1,73 @@
+package datafs
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/hairyhenderson/go-fsimpl/awssmfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAWSSMClient returns secrets keyed by name and version stage or ID
+type fakeAWSSMClient map[string]string
+
+func (c fakeAWSSMClient) ListSecrets(_ context.Context, _ *secretsmanager.ListSecretsInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.ListSecretsOutput, error) {
+	return &secretsmanager.ListSecretsOutput{}, nil
+}
+
+func (c fakeAWSSMClient) GetSecretValue(_ context.Context, params *secretsmanager.GetSecretValueInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	version := "AWSCURRENT"
+	if params.VersionStage != nil {
+		version = *params.VersionStage
+	}
+
+	if params.VersionId != nil {
+		version = *params.VersionId
+	}
+
+	s, ok := c[aws.ToString(params.SecretId)+"@"+version]
+	if !ok {
+		return nil, fmt.Errorf("secret not found")
+	}
+
+	return &secretsmanager.GetSecretValueOutput{SecretString: &s}, nil
+}
+
+func TestAWSSMFSVersions(t *testing.T) {
+	_, err := NewAWSSMFS(mustParseURL("foo:///"))
+	require.Error(t, err)
+
+	client := fakeAWSSMClient{
+		"/app/db@AWSCURRENT":  "current",
+		"/app/db@AWSPREVIOUS": "previous",
+		"/app/db@v1":          "first",
+	}
+
+	testdata := []struct {
+		stage, id, expected string
+	}{
+		{"", "", "current"},
+		{"AWSPREVIOUS", "", "previous"},
+		{"", "v1", "first"},
+	}
+
+	for _, d := range testdata {
+		fsys, err := awssmfs.New(mustParseURL("aws+sm:///app"))
+		require.NoError(t, err)
+
+		fsys = awssmfs.WithSMClientFS(&versionedSMClient{
+			client:       client,
+			versionStage: d.stage,
+			versionID:    d.id,
+		}, fsys)
+
+		b, err := fs.ReadFile(fsys, "db")
+		require.NoError(t, err)
+		assert.Equal(t, d.expected, string(b))
+	}
+}
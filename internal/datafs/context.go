@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"io/fs"
+	"net/http"
 	"os"
 
 	"github.com/hairyhenderson/gomplate/v4/internal/config"
@@ -43,3 +44,20 @@ func StdinFromContext(ctx context.Context) io.Reader {
 
 	return os.Stdin
 }
+
+type httpClientCtxKey struct{}
+
+// ContextWithHTTPClient returns a context with the given HTTP client, to be
+// used by filesystems that make HTTP requests (e.g. for http/https datasources)
+func ContextWithHTTPClient(ctx context.Context, client *http.Client) context.Context {
+	return context.WithValue(ctx, httpClientCtxKey{}, client)
+}
+
+// HTTPClientFromContext returns the HTTP client from the context, if any
+func HTTPClientFromContext(ctx context.Context) *http.Client {
+	if c, ok := ctx.Value(httpClientCtxKey{}).(*http.Client); ok {
+		return c
+	}
+
+	return nil
+}
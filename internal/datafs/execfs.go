@@ -0,0 +1,154 @@
+package datafs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os/exec"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+)
+
+// NewExecFS returns a filesystem (an fs.FS) that runs local commands and
+// presents their standard output as file content. The file name is the
+// command to run - either a bare name to be found in the PATH, or an absolute
+// path. Arguments are given with (repeatable) "arg" query parameters.
+//
+// This is an experimental feature, and must be enabled with --experimental.
+func NewExecFS(u *url.URL) (fs.FS, error) {
+	if u.Scheme != "exec" {
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+
+	return &execFS{
+		ctx:  context.Background(),
+		args: u.Query()["arg"],
+	}, nil
+}
+
+type execFS struct {
+	ctx  context.Context
+	args []string
+}
+
+//nolint:gochecknoglobals
+var ExecFS = fsimpl.FSProviderFunc(NewExecFS, "exec")
+
+var (
+	_ fs.FS         = (*execFS)(nil)
+	_ withContexter = (*execFS)(nil)
+)
+
+func (f *execFS) WithContext(ctx context.Context) fs.FS {
+	if ctx == nil {
+		return f
+	}
+
+	fsys := *f
+	fsys.ctx = ctx
+
+	return &fsys
+}
+
+func (f *execFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) || name == "." {
+		return nil, &fs.PathError{
+			Op:   "open",
+			Path: name,
+			Err:  fs.ErrInvalid,
+		}
+	}
+
+	if !config.ExperimentalEnabled(f.ctx) {
+		return nil, &fs.PathError{
+			Op:   "open",
+			Path: name,
+			Err:  fmt.Errorf("exec datasources are experimental, and must be enabled with --experimental"),
+		}
+	}
+
+	return &execFile{fsys: f, name: name}, nil
+}
+
+// run runs the named command and returns its standard output
+func (f *execFS) run(name string) ([]byte, error) {
+	// names with a '/' are absolute paths - fs.ValidPath names can't start
+	// with '/' so it needs to be added back
+	if strings.Contains(name, "/") {
+		name = "/" + name
+	}
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	//nolint:gosec
+	cmd := exec.CommandContext(f.ctx, name, f.args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("command %q failed: %w: %s", name, err, msg)
+		}
+
+		return nil, fmt.Errorf("command %q failed: %w", name, err)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+type execFile struct {
+	fsys *execFS
+	body io.Reader
+	name string
+	size int64
+}
+
+var _ fs.File = (*execFile)(nil)
+
+func (f *execFile) Close() error {
+	f.body = nil
+	return nil
+}
+
+func (f *execFile) read() error {
+	if f.body != nil {
+		return nil
+	}
+
+	b, err := f.fsys.run(f.name)
+	if err != nil {
+		return &fs.PathError{Op: "read", Path: f.name, Err: err}
+	}
+
+	f.body = bytes.NewReader(b)
+	f.size = int64(len(b))
+
+	return nil
+}
+
+func (f *execFile) Stat() (fs.FileInfo, error) {
+	err := f.read()
+	if err != nil {
+		return nil, err
+	}
+
+	return FileInfo(path.Base(f.name), f.size, 0o444, time.Time{}, ""), nil
+}
+
+func (f *execFile) Read(p []byte) (int, error) {
+	err := f.read()
+	if err != nil {
+		return 0, err
+	}
+
+	return f.body.Read(p)
+}
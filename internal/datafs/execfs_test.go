@@ -0,0 +1,48 @@
+package datafs
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+
+	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecFS(t *testing.T) {
+	skipWindows(t)
+
+	_, err := NewExecFS(mustParseURL("file:///bin/echo"))
+	require.Error(t, err)
+
+	fsys, err := NewExecFS(mustParseURL("exec:echo?arg=hello&arg=world"))
+	require.NoError(t, err)
+
+	// experimental mode is required
+	_, err = fs.ReadFile(fsys, "echo")
+	require.Error(t, err)
+
+	ctx := config.SetExperimental(context.Background())
+	fsys = fsimpl.WithContextFS(ctx, fsys)
+
+	b, err := fs.ReadFile(fsys, "echo")
+	require.NoError(t, err)
+	assert.Equal(t, "hello world\n", string(b))
+
+	fi, err := fs.Stat(fsys, "echo")
+	require.NoError(t, err)
+	assert.Equal(t, "echo", fi.Name())
+	assert.Equal(t, int64(12), fi.Size())
+
+	fsys, err = NewExecFS(mustParseURL("exec:///bin/sh?arg=-c&arg=echo+oops+>%262%3B+exit+1"))
+	require.NoError(t, err)
+	fsys = fsimpl.WithContextFS(ctx, fsys)
+
+	_, err = fs.ReadFile(fsys, "bin/sh")
+	require.ErrorContains(t, err, "oops")
+
+	_, err = fs.ReadFile(fsys, "bogus-command-that-does-not-exist")
+	require.Error(t, err)
+}
@@ -0,0 +1,189 @@
+package datafs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/textproto"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/jlaffaye/ftp"
+)
+
+// NewFTPFS returns a filesystem (an fs.FS) that can be used to read files
+// from FTP servers, using passive mode. Credentials can be given in the URL's
+// userinfo - anonymous login is used otherwise.
+func NewFTPFS(u *url.URL) (fs.FS, error) {
+	if u.Scheme != "ftp" {
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("host must be given in URL %q", u)
+	}
+
+	return &ftpFS{
+		ctx:      context.Background(),
+		host:     u.Host,
+		userinfo: u.User,
+	}, nil
+}
+
+type ftpFS struct {
+	ctx      context.Context
+	userinfo *url.Userinfo
+	host     string
+}
+
+//nolint:gochecknoglobals
+var FTPFS = fsimpl.FSProviderFunc(NewFTPFS, "ftp")
+
+var (
+	_ fs.FS         = (*ftpFS)(nil)
+	_ withContexter = (*ftpFS)(nil)
+)
+
+func (f *ftpFS) WithContext(ctx context.Context) fs.FS {
+	if ctx == nil {
+		return f
+	}
+
+	fsys := *f
+	fsys.ctx = ctx
+
+	return &fsys
+}
+
+func (f *ftpFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	c, err := f.login()
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	//nolint:errcheck
+	defer c.Quit()
+
+	p := path.Join("/", name)
+
+	// SIZE only succeeds for regular files, so use it to tell files and
+	// directories apart
+	_, err = c.FileSize(p)
+	if err == nil {
+		b, err := retrieve(c, p)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: ftpError(err)}
+		}
+
+		return &memFile{
+			fi:   FileInfo(path.Base(p), int64(len(b)), 0o444, time.Time{}, ""),
+			body: bytes.NewReader(b),
+		}, nil
+	}
+
+	err = c.ChangeDir(p)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: ftpError(err)}
+	}
+
+	names, err := c.NameList("")
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: ftpError(err)}
+	}
+
+	entries := []fs.DirEntry{}
+
+	for _, n := range names {
+		n = path.Base(strings.TrimSpace(n))
+		if n == "." || n == ".." || n == "/" {
+			continue
+		}
+
+		entries = append(entries, FileInfoDirEntry(FileInfo(n, 0, 0o444, time.Time{}, "")))
+	}
+
+	return &memFile{
+		fi:      DirInfo(path.Base(p), time.Time{}),
+		dirents: entries,
+	}, nil
+}
+
+func (f *ftpFS) login() (*ftp.ServerConn, error) {
+	addr := f.host
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "21")
+	}
+
+	c, err := ftp.Dial(addr, ftp.DialWithContext(f.ctx))
+	if err != nil {
+		return nil, ftpError(err)
+	}
+
+	user, pass := "anonymous", "anonymous"
+	if f.userinfo != nil {
+		user = f.userinfo.Username()
+		if p, ok := f.userinfo.Password(); ok {
+			pass = p
+		}
+	}
+
+	err = c.Login(user, pass)
+	if err != nil {
+		//nolint:errcheck
+		c.Quit()
+
+		return nil, fmt.Errorf("ftp login to %s: %w", addr, ftpError(err))
+	}
+
+	return c, nil
+}
+
+// retrieve reads the whole file at p
+func retrieve(c *ftp.ServerConn, p string) ([]byte, error) {
+	r, err := c.Retr(p)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		r.Close()
+
+		return nil, err
+	}
+
+	// closing the response reads the transfer's completion reply
+	err = r.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// ftpError maps FTP error replies to fs errors
+func ftpError(err error) error {
+	var perr *textproto.Error
+	if !errors.As(err, &perr) {
+		return err
+	}
+
+	switch perr.Code {
+	case ftp.StatusFileUnavailable:
+		return fmt.Errorf("%w: %s", fs.ErrNotExist, perr.Msg)
+	case ftp.StatusNotLoggedIn:
+		return fmt.Errorf("%w: %s", fs.ErrPermission, perr.Msg)
+	default:
+		return err
+	}
+}
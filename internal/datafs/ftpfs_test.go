@@ -0,0 +1,160 @@
+package datafs
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"net"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFTPServer serves the given filesystem over FTP (passive mode only), just
+// enough to exercise the client
+func fakeFTPServer(t *testing.T, fsys fstest.MapFS) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	handle := func(conn net.Conn) {
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(format string, args ...interface{}) {
+			fmt.Fprintf(conn, format+"\r\n", args...)
+		}
+
+		var dl net.Listener
+
+		cwd := "."
+
+		reply("220 welcome")
+
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			cmd, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+			name := strings.TrimPrefix(arg, "/")
+
+			switch cmd {
+			case "USER":
+				reply("331 password please")
+			case "PASS":
+				if arg != "secret" {
+					reply("530 bad password")
+					continue
+				}
+
+				reply("230 logged in")
+			case "TYPE":
+				reply("200 ok")
+			case "EPSV":
+				dl, _ = net.Listen("tcp", "127.0.0.1:0")
+				reply("229 Entering Extended Passive Mode (|||%d|)", dl.Addr().(*net.TCPAddr).Port)
+			case "SIZE":
+				fi, err := fs.Stat(fsys, name)
+				if err != nil || fi.IsDir() {
+					reply("550 not a file")
+					continue
+				}
+
+				reply("213 %d", fi.Size())
+			case "CWD":
+				fi, err := fs.Stat(fsys, name)
+				if err != nil || !fi.IsDir() {
+					reply("550 no such directory")
+					continue
+				}
+
+				cwd = name
+				reply("250 ok")
+			case "RETR", "NLST":
+				dc, err := dl.Accept()
+				if err != nil {
+					return
+				}
+
+				reply("150 opening data connection")
+
+				if cmd == "RETR" {
+					_, _ = dc.Write(fsys[name].Data)
+				} else {
+					des, _ := fs.ReadDir(fsys, cwd)
+					for _, de := range des {
+						fmt.Fprintf(dc, "%s\r\n", de.Name())
+					}
+				}
+
+				dc.Close()
+				dl.Close()
+				reply("226 transfer complete")
+			case "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("502 not implemented")
+			}
+		}
+	}
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go handle(conn)
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+func TestFTPFS(t *testing.T) {
+	_, err := NewFTPFS(mustParseURL("ftp:///foo"))
+	require.Error(t, err)
+
+	_, err = NewFTPFS(mustParseURL("sftp://example.com"))
+	require.Error(t, err)
+
+	addr := fakeFTPServer(t, fstest.MapFS{
+		"pub/config.json": {Data: []byte(`{"hello": "world"}`)},
+		"pub/app.yaml":    {Data: []byte("port: 8080\n")},
+	})
+
+	fsys, err := NewFTPFS(mustParseURL("ftp://user:secret@" + addr))
+	require.NoError(t, err)
+
+	b, err := fs.ReadFile(fsys, "pub/config.json")
+	require.NoError(t, err)
+	assert.Equal(t, `{"hello": "world"}`, string(b))
+
+	fi, err := fs.Stat(fsys, "pub")
+	require.NoError(t, err)
+	assert.True(t, fi.IsDir())
+
+	des, err := fs.ReadDir(fsys, "pub")
+	require.NoError(t, err)
+	require.Len(t, des, 2)
+	assert.Equal(t, "app.yaml", des[0].Name())
+	assert.Equal(t, "config.json", des[1].Name())
+
+	_, err = fs.ReadFile(fsys, "pub/missing.json")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	fsys, err = NewFTPFS(mustParseURL("ftp://user:wrong@" + addr))
+	require.NoError(t, err)
+
+	_, err = fs.ReadFile(fsys, "pub/config.json")
+	require.ErrorIs(t, err, fs.ErrPermission)
+}
@@ -0,0 +1,213 @@
+package datafs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/hairyhenderson/go-fsimpl"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// gcpSMClient is the subset of the Secret Manager client used by gcpsmFS
+type gcpSMClient interface {
+	AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error)
+}
+
+// NewGCPSMFS returns a filesystem (an fs.FS) that can be used to read secrets
+// from Google Cloud Secret Manager. Secrets are named by their resource name
+// (e.g. "projects/myproject/secrets/mysecret"), and the latest version is read
+// unless a version is given, either as a "versionId" (or "version") query
+// parameter, or as part of the resource name (e.g.
+// "projects/myproject/secrets/mysecret/versions/3").
+//
+// Application Default Credentials are used to authenticate.
+func NewGCPSMFS(u *url.URL) (fs.FS, error) {
+	if u.Scheme != "gcp+sm" {
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+
+	q := u.Query()
+
+	version := q.Get("versionId")
+	if version == "" {
+		version = q.Get("version")
+	}
+
+	if version == "" {
+		version = "latest"
+	}
+
+	return &gcpsmFS{
+		ctx: context.Background(),
+		// support URLs like gcp+sm://projects/myproject/secrets/mysecret, where
+		// "projects" is parsed as the host
+		prefix:  u.Host,
+		version: version,
+	}, nil
+}
+
+type gcpsmFS struct {
+	ctx      context.Context
+	smclient gcpSMClient
+	prefix   string
+	version  string
+}
+
+//nolint:gochecknoglobals
+var GCPSMFS = fsimpl.FSProviderFunc(NewGCPSMFS, "gcp+sm")
+
+var (
+	_ fs.FS         = (*gcpsmFS)(nil)
+	_ withContexter = (*gcpsmFS)(nil)
+)
+
+func (f *gcpsmFS) WithContext(ctx context.Context) fs.FS {
+	if ctx == nil {
+		return f
+	}
+
+	fsys := *f
+	fsys.ctx = ctx
+
+	return &fsys
+}
+
+// WithSMClient returns a copy of the filesystem which uses the given Secret
+// Manager client, instead of creating one from Application Default Credentials
+func (f *gcpsmFS) WithSMClient(client gcpSMClient) fs.FS {
+	if client == nil {
+		return f
+	}
+
+	fsys := *f
+	fsys.smclient = client
+
+	return &fsys
+}
+
+func (f *gcpsmFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) || name == "." {
+		return nil, &fs.PathError{
+			Op:   "open",
+			Path: name,
+			Err:  fs.ErrInvalid,
+		}
+	}
+
+	resource, ok := f.resourceName(name)
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	return &gcpsmFile{fsys: f, name: name, resource: resource}, nil
+}
+
+// resourceName returns the full resource name of the secret version to access.
+// Names must be in the form projects/<project>/secrets/<secret>, optionally
+// followed by /versions/<version>.
+func (f *gcpsmFS) resourceName(name string) (string, bool) {
+	if f.prefix != "" {
+		name = path.Join(f.prefix, name)
+	}
+
+	parts := strings.Split(name, "/")
+
+	switch {
+	case len(parts) == 4 && parts[0] == "projects" && parts[2] == "secrets":
+		return path.Join(name, "versions", f.version), true
+	case len(parts) == 6 && parts[0] == "projects" && parts[2] == "secrets" && parts[4] == "versions":
+		return name, true
+	default:
+		return "", false
+	}
+}
+
+// accessSecretVersion calls the AccessSecretVersion API and returns the
+// secret payload
+func (f *gcpsmFS) accessSecretVersion(resource string) ([]byte, error) {
+	client := f.smclient
+	if client == nil {
+		c, err := secretmanager.NewClient(f.ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create GCP Secret Manager client: %w", err)
+		}
+		defer c.Close()
+
+		client = c
+	}
+
+	res, err := client.AccessSecretVersion(f.ctx, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: resource,
+	})
+
+	switch status.Code(err) {
+	case codes.OK:
+		return res.GetPayload().GetData(), nil
+	case codes.NotFound:
+		return nil, fs.ErrNotExist
+	case codes.PermissionDenied:
+		return nil, fs.ErrPermission
+	default:
+		return nil, fmt.Errorf("unable to access %s: %w", resource, err)
+	}
+}
+
+type gcpsmFile struct {
+	fsys     *gcpsmFS
+	body     io.Reader
+	name     string
+	resource string
+	size     int64
+}
+
+var _ fs.File = (*gcpsmFile)(nil)
+
+func (f *gcpsmFile) Close() error {
+	f.body = nil
+	return nil
+}
+
+func (f *gcpsmFile) read() error {
+	if f.body != nil {
+		return nil
+	}
+
+	b, err := f.fsys.accessSecretVersion(f.resource)
+	if err != nil {
+		return &fs.PathError{Op: "read", Path: f.name, Err: err}
+	}
+
+	f.body = bytes.NewReader(b)
+	f.size = int64(len(b))
+
+	return nil
+}
+
+func (f *gcpsmFile) Stat() (fs.FileInfo, error) {
+	err := f.read()
+	if err != nil {
+		return nil, err
+	}
+
+	return FileInfo(path.Base(f.name), f.size, 0o444, time.Time{}, ""), nil
+}
+
+func (f *gcpsmFile) Read(p []byte) (int, error) {
+	err := f.read()
+	if err != nil {
+		return 0, err
+	}
+
+	return f.body.Read(p)
+}
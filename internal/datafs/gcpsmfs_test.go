@@ -0,0 +1,105 @@
+package datafs
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"testing"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeGCPSMClient map[string]string
+
+func (c fakeGCPSMClient) AccessSecretVersion(_ context.Context, req *secretmanagerpb.AccessSecretVersionRequest, _ ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	data, ok := c[req.GetName()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "secret not found")
+	}
+
+	return &secretmanagerpb.AccessSecretVersionResponse{
+		Name:    req.GetName(),
+		Payload: &secretmanagerpb.SecretPayload{Data: []byte(data)},
+	}, nil
+}
+
+func setupGCPSMFS(t *testing.T, u string) fs.FS {
+	t.Helper()
+
+	client := fakeGCPSMClient{
+		"projects/p1/secrets/foo/versions/latest": "hello world",
+		"projects/p1/secrets/foo/versions/1":      "hello",
+	}
+
+	fsys, err := NewGCPSMFS(mustParseURL(u))
+	require.NoError(t, err)
+
+	return fsys.(*gcpsmFS).WithSMClient(client)
+}
+
+func TestGCPSMFS(t *testing.T) {
+	_, err := NewGCPSMFS(mustParseURL("foo:///"))
+	assert.Error(t, err)
+
+	fsys := setupGCPSMFS(t, "gcp+sm:///")
+
+	b, err := fs.ReadFile(fsys, "projects/p1/secrets/foo")
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(b))
+
+	b, err = fs.ReadFile(fsys, "projects/p1/secrets/foo/versions/1")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b))
+
+	fi, err := fs.Stat(fsys, "projects/p1/secrets/foo")
+	require.NoError(t, err)
+	assert.Equal(t, "foo", fi.Name())
+	assert.Equal(t, int64(11), fi.Size())
+
+	_, err = fs.ReadFile(fsys, "projects/p1/secrets/bar")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = fsys.Open("foo/bar")
+	assert.ErrorIs(t, err, fs.ErrInvalid)
+	_, err = fsys.Open("foo")
+	assert.Error(t, err)
+
+	t.Run("host as part of the name", func(t *testing.T) {
+		fsys := setupGCPSMFS(t, "gcp+sm://projects/")
+
+		b, err := fs.ReadFile(fsys, "p1/secrets/foo")
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(b))
+	})
+
+	t.Run("pinned version", func(t *testing.T) {
+		fsys := setupGCPSMFS(t, "gcp+sm:///?version=1")
+
+		f, err := fsys.(*gcpsmFS).WithContext(context.Background()).Open("projects/p1/secrets/foo")
+		require.NoError(t, err)
+		defer f.Close()
+
+		b, err := io.ReadAll(f)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(b))
+
+		fsys = setupGCPSMFS(t, "gcp+sm:///?versionId=1")
+
+		b, err = fs.ReadFile(fsys, "projects/p1/secrets/foo")
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(b))
+
+		// versionId takes precedence over version
+		fsys = setupGCPSMFS(t, "gcp+sm:///?versionId=1&version=latest")
+		assert.Equal(t, "1", fsys.(*gcpsmFS).version)
+
+		fsys = setupGCPSMFS(t, "gcp+sm:///?versionId=2")
+		_, err = fs.ReadFile(fsys, "projects/p1/secrets/foo")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+}
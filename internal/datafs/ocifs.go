@@ -0,0 +1,341 @@
+package datafs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/hairyhenderson/go-fsimpl"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+// NewOCIFS returns a filesystem (an fs.FS) that can be used to read files from
+// artifacts stored in OCI registries (such as those pushed with ORAS). Files
+// are named in the form "<repository>:<tag>[/<file>]" or
+// "<repository>@<digest>[/<file>]", where <file> is the title of one of the
+// artifact's layers. When the artifact has only one layer, the file name can
+// be omitted.
+//
+// Credentials for the registry can be given in the URL's userinfo. The oci+http
+// scheme can be used to access registries over plain HTTP.
+func NewOCIFS(u *url.URL) (fs.FS, error) {
+	plainHTTP := false
+	switch u.Scheme {
+	case "oci":
+	case "oci+http":
+		plainHTTP = true
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("registry host must be given in URL %q", u)
+	}
+
+	return &ociFS{
+		ctx:       context.Background(),
+		host:      u.Host,
+		plainHTTP: plainHTTP,
+		userinfo:  u.User,
+		authCache: auth.NewCache(),
+	}, nil
+}
+
+type ociFS struct {
+	ctx        context.Context
+	httpClient *http.Client
+	host       string
+	plainHTTP  bool
+	userinfo   *url.Userinfo
+
+	// registry tokens are cached across repositories and requests
+	authCache auth.Cache
+}
+
+//nolint:gochecknoglobals
+var OCIFS = fsimpl.FSProviderFunc(NewOCIFS, "oci", "oci+http")
+
+var (
+	_ fs.FS         = (*ociFS)(nil)
+	_ fs.ReadDirFS  = (*ociFS)(nil)
+	_ withContexter = (*ociFS)(nil)
+)
+
+func (f *ociFS) WithContext(ctx context.Context) fs.FS {
+	if ctx == nil {
+		return f
+	}
+
+	fsys := *f
+	fsys.ctx = ctx
+
+	return &fsys
+}
+
+func (f *ociFS) WithHTTPClient(client *http.Client) fs.FS {
+	if client == nil {
+		return f
+	}
+
+	fsys := *f
+	fsys.httpClient = client
+
+	return &fsys
+}
+
+// repository returns a client for the named repository in the registry
+func (f *ociFS) repository(name string) (*remote.Repository, error) {
+	repo, err := remote.NewRepository(f.host + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &auth.Client{
+		Client: f.httpClient,
+		Cache:  f.authCache,
+	}
+
+	if f.userinfo != nil {
+		pass, _ := f.userinfo.Password()
+		client.Credential = auth.StaticCredential(f.host, auth.Credential{
+			Username: f.userinfo.Username(),
+			Password: pass,
+		})
+	}
+
+	repo.Client = client
+	repo.PlainHTTP = f.plainHTTP
+
+	return repo, nil
+}
+
+// parseOCIRef splits a name like "org/repo:tag/file.json" into the repository,
+// reference (tag or digest), and file name
+func parseOCIRef(name string) (repo, ref, file string, err error) {
+	sep := ":"
+	if strings.Contains(name, "@") {
+		sep = "@"
+	}
+
+	repo, rest, ok := strings.Cut(name, sep)
+	if !ok || repo == "" || rest == "" {
+		return "", "", "", fmt.Errorf("invalid artifact reference %q: must be in the form <repository>:<tag> or <repository>@<digest>", name)
+	}
+
+	// neither tags nor digests contain a '/', so the file (if any) follows the
+	// first one
+	ref, file, _ = strings.Cut(rest, "/")
+
+	return repo, ref, file, nil
+}
+
+func (f *ociFS) manifest(repo *remote.Repository, ref string) (*ocispec.Manifest, error) {
+	desc, rc, err := repo.FetchReference(f.ctx, ref)
+	if err != nil {
+		return nil, ociError(err)
+	}
+	defer rc.Close()
+
+	b, err := content.ReadAll(rc, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &ocispec.Manifest{}
+
+	err = json.Unmarshal(b, m)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode manifest: %w", err)
+	}
+
+	return m, nil
+}
+
+// blob fetches the layer's content, verifying its size and digest
+func (f *ociFS) blob(repo *remote.Repository, desc ocispec.Descriptor) ([]byte, error) {
+	rc, err := repo.Blobs().Fetch(f.ctx, desc)
+	if err != nil {
+		return nil, ociError(err)
+	}
+	defer rc.Close()
+
+	return content.ReadAll(rc, desc)
+}
+
+// ociError maps registry errors to the equivalent fs errors
+func ociError(err error) error {
+	if errors.Is(err, errdef.ErrNotFound) {
+		return fmt.Errorf("%w: %w", fs.ErrNotExist, err)
+	}
+
+	var errResp *errcode.ErrorResponse
+	if errors.As(err, &errResp) {
+		switch errResp.StatusCode {
+		case http.StatusNotFound:
+			return fmt.Errorf("%w: %w", fs.ErrNotExist, err)
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return fmt.Errorf("%w: %w", fs.ErrPermission, err)
+		}
+	}
+
+	return err
+}
+
+// layerName returns the file name for a layer - its title, if it has one
+func layerName(desc ocispec.Descriptor) string {
+	if t := desc.Annotations[ocispec.AnnotationTitle]; t != "" {
+		return t
+	}
+
+	return strings.ReplaceAll(desc.Digest.String(), ":", "-")
+}
+
+// layerContentType returns the content type of a layer, guessed from the file
+// name when the layer has a generic (vendor-specific) media type
+func layerContentType(desc ocispec.Descriptor) string {
+	if desc.MediaType != "" && !strings.HasPrefix(desc.MediaType, "application/vnd.") {
+		return desc.MediaType
+	}
+
+	return mime.TypeByExtension(path.Ext(layerName(desc)))
+}
+
+func (f *ociFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) || name == "." {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	repo, ref, file, err := parseOCIRef(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	r, err := f.repository(repo)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	m, err := f.manifest(r, ref)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	if file == "" {
+		// a single-layer artifact can be read directly
+		if len(m.Layers) == 1 {
+			return &ociFile{fsys: f, repo: r, desc: m.Layers[0]}, nil
+		}
+
+		return &ociDir{name: path.Base(ref), layers: m.Layers}, nil
+	}
+
+	for _, l := range m.Layers {
+		if layerName(l) == file {
+			return &ociFile{fsys: f, repo: r, desc: l}, nil
+		}
+	}
+
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+func (f *ociFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	file, err := f.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	dir, ok := file.(*ociDir)
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fmt.Errorf("not a directory")}
+	}
+
+	return dir.ReadDir(-1)
+}
+
+type ociFile struct {
+	fsys *ociFS
+	body io.Reader
+	repo *remote.Repository
+	desc ocispec.Descriptor
+}
+
+var _ fs.File = (*ociFile)(nil)
+
+func (f *ociFile) Close() error {
+	f.body = nil
+	return nil
+}
+
+func (f *ociFile) Stat() (fs.FileInfo, error) {
+	return FileInfo(layerName(f.desc), f.desc.Size, 0o444, time.Time{}, layerContentType(f.desc)), nil
+}
+
+func (f *ociFile) Read(p []byte) (int, error) {
+	if f.body == nil {
+		b, err := f.fsys.blob(f.repo, f.desc)
+		if err != nil {
+			return 0, &fs.PathError{Op: "read", Path: layerName(f.desc), Err: err}
+		}
+
+		f.body = bytes.NewReader(b)
+	}
+
+	return f.body.Read(p)
+}
+
+// ociDir presents a multi-layer artifact as a directory of its layers
+type ociDir struct {
+	name   string
+	layers []ocispec.Descriptor
+	diroff int
+}
+
+var _ fs.ReadDirFile = (*ociDir)(nil)
+
+func (d *ociDir) Close() error { return nil }
+
+func (d *ociDir) Stat() (fs.FileInfo, error) {
+	return DirInfo(d.name, time.Time{}), nil
+}
+
+func (d *ociDir) Read(_ []byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: fmt.Errorf("is a directory")}
+}
+
+func (d *ociDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	remaining := d.layers[d.diroff:]
+	if n > 0 && len(remaining) == 0 {
+		return nil, io.EOF
+	}
+
+	if n > 0 && n < len(remaining) {
+		remaining = remaining[:n]
+	}
+
+	entries := make([]fs.DirEntry, len(remaining))
+	for i, l := range remaining {
+		entries[i] = FileInfoDirEntry(
+			FileInfo(layerName(l), l.Size, 0o444, time.Time{}, layerContentType(l)),
+		)
+	}
+
+	d.diroff += len(remaining)
+
+	return entries, nil
+}
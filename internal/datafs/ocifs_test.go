@@ -0,0 +1,189 @@
+package datafs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func digestOf(b string) string {
+	sum := sha256.Sum256([]byte(b))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func setupOCIFS(t *testing.T) (fs.FS, string) {
+	t.Helper()
+
+	blobs := map[string]string{
+		"config.json": `{"hello": "world"}`,
+		"app.yaml":    "port: 8080\n",
+		"single.toml": "name = \"single\"\n",
+	}
+
+	layer := func(name string) map[string]interface{} {
+		return map[string]interface{}{
+			"mediaType":   "application/vnd.oci.image.layer.v1.tar",
+			"digest":      digestOf(blobs[name]),
+			"size":        len(blobs[name]),
+			"annotations": map[string]string{ocispec.AnnotationTitle: name},
+		}
+	}
+
+	manifests := map[string]interface{}{
+		"configs/v1": map[string]interface{}{
+			"mediaType": ocispec.MediaTypeImageManifest,
+			"layers":    []interface{}{layer("config.json"), layer("app.yaml")},
+		},
+		"single/latest": map[string]interface{}{
+			"mediaType": ocispec.MediaTypeImageManifest,
+			"layers":    []interface{}{layer("single.toml")},
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("scope") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if u, p, _ := r.BasicAuth(); u != "user" || p != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Write([]byte(`{"token": "t0k3n"}`))
+	})
+
+	var srv *httptest.Server
+
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t0k3n" {
+			w.Header().Set("WWW-Authenticate",
+				`Bearer realm="`+srv.URL+`/token",service="test",scope="repository:foo:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		p := strings.TrimPrefix(r.URL.Path, "/v2/")
+		if repo, ref, ok := strings.Cut(p, "/manifests/"); ok {
+			m, ok := manifests[repo+"/"+ref]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.Header().Set("Content-Type", ocispec.MediaTypeImageManifest)
+			_ = json.NewEncoder(w).Encode(m)
+
+			return
+		}
+
+		if _, digest, ok := strings.Cut(p, "/blobs/"); ok {
+			for _, b := range blobs {
+				if digestOf(b) == digest {
+					w.Write([]byte(b))
+					return
+				}
+			}
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	fsys, err := NewOCIFS(mustParseURL("oci+http://user:pass@" + host))
+	require.NoError(t, err)
+
+	return fsys, host
+}
+
+func TestOCIFS(t *testing.T) {
+	_, err := NewOCIFS(mustParseURL("oci:///foo:bar"))
+	require.Error(t, err)
+
+	_, err = NewOCIFS(mustParseURL("http://example.com"))
+	require.Error(t, err)
+
+	fsys, host := setupOCIFS(t)
+
+	b, err := fs.ReadFile(fsys, "configs:v1/config.json")
+	require.NoError(t, err)
+	assert.Equal(t, `{"hello": "world"}`, string(b))
+
+	fi, err := fs.Stat(fsys, "configs:v1/app.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "app.yaml", fi.Name())
+	assert.Equal(t, int64(11), fi.Size())
+
+	// single-layer artifacts can be read without a file name
+	b, err = fs.ReadFile(fsys, "single:latest")
+	require.NoError(t, err)
+	assert.Equal(t, "name = \"single\"\n", string(b))
+
+	// multi-layer artifacts are directories
+	fi, err = fs.Stat(fsys, "configs:v1")
+	require.NoError(t, err)
+	assert.True(t, fi.IsDir())
+
+	des, err := fs.ReadDir(fsys, "configs:v1")
+	require.NoError(t, err)
+	require.Len(t, des, 2)
+	assert.Equal(t, "config.json", des[0].Name())
+	assert.Equal(t, "app.yaml", des[1].Name())
+
+	_, err = fs.ReadFile(fsys, "configs:v1/missing.json")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = fs.ReadFile(fsys, "configs:v2/config.json")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = fs.ReadFile(fsys, "configs")
+	require.Error(t, err)
+
+	// bad credentials
+	fsys, err = NewOCIFS(mustParseURL("oci+http://user:wrong@" + host))
+	require.NoError(t, err)
+
+	_, err = fs.ReadFile(fsys, "configs:v1/config.json")
+	require.ErrorIs(t, err, fs.ErrPermission)
+}
+
+func TestParseOCIRef(t *testing.T) {
+	testdata := []struct {
+		in, repo, ref, file string
+	}{
+		{"foo:bar", "foo", "bar", ""},
+		{"org/foo:v1/config.json", "org/foo", "v1", "config.json"},
+		{"org/foo@sha256:abcd", "org/foo", "sha256:abcd", ""},
+		{"org/foo@sha256:abcd/a.yaml", "org/foo", "sha256:abcd", "a.yaml"},
+	}
+
+	for _, d := range testdata {
+		repo, ref, file, err := parseOCIRef(d.in)
+		require.NoError(t, err)
+		assert.Equal(t, d.repo, repo)
+		assert.Equal(t, d.ref, ref)
+		assert.Equal(t, d.file, file)
+	}
+
+	_, _, _, err := parseOCIRef("foo")
+	require.Error(t, err)
+
+	_, _, _, err = parseOCIRef(":foo")
+	require.Error(t, err)
+}
@@ -0,0 +1,304 @@
+package datafs
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// NewSFTPFS returns a filesystem (an fs.FS) that can be used to read files
+// from SFTP servers. Paths are absolute on the server.
+//
+// Password authentication is used when a password is given in the URL's
+// userinfo. Otherwise, a private key can be given with $SFTP_SSH_KEY (or
+// $SFTP_SSH_KEY_FILE), and if neither is set the SSH agent is used. Host keys
+// are verified against the known_hosts file ($SSH_KNOWN_HOSTS, defaulting to
+// ~/.ssh/known_hosts).
+func NewSFTPFS(u *url.URL) (fs.FS, error) {
+	if u.Scheme != "sftp" {
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("host must be given in URL %q", u)
+	}
+
+	return &sftpFS{
+		ctx:      context.Background(),
+		host:     u.Host,
+		userinfo: u.User,
+	}, nil
+}
+
+type sftpFS struct {
+	ctx      context.Context
+	userinfo *url.Userinfo
+	host     string
+}
+
+//nolint:gochecknoglobals
+var SFTPFS = fsimpl.FSProviderFunc(NewSFTPFS, "sftp")
+
+var (
+	_ fs.FS         = (*sftpFS)(nil)
+	_ withContexter = (*sftpFS)(nil)
+)
+
+func (f *sftpFS) WithContext(ctx context.Context) fs.FS {
+	if ctx == nil {
+		return f
+	}
+
+	fsys := *f
+	fsys.ctx = ctx
+
+	return &fsys
+}
+
+func (f *sftpFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	conn, err := f.dial()
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	defer conn.Close()
+
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fmt.Errorf("sftp subsystem: %w", err)}
+	}
+	defer client.Close()
+
+	return openSFTPFile(client, name)
+}
+
+// openSFTPFile reads the named file (or directory listing) fully into memory
+func openSFTPFile(client *sftp.Client, name string) (fs.File, error) {
+	p := path.Join("/", name)
+
+	fi, err := client.Stat(p)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	if fi.IsDir() {
+		fis, err := client.ReadDir(p)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+
+		sort.Slice(fis, func(i, j int) bool { return fis[i].Name() < fis[j].Name() })
+
+		entries := make([]fs.DirEntry, len(fis))
+		for i, fi := range fis {
+			entries[i] = fs.FileInfoToDirEntry(fi)
+		}
+
+		return &memFile{fi: fi, dirents: entries}, nil
+	}
+
+	sf, err := client.Open(p)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	defer sf.Close()
+
+	b, err := io.ReadAll(sf)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return &memFile{
+		fi:   FileInfo(fi.Name(), int64(len(b)), fi.Mode(), fi.ModTime(), ""),
+		body: bytes.NewReader(b),
+	}, nil
+}
+
+func (f *sftpFS) dial() (*ssh.Client, error) {
+	hostKeyCallback, err := knownHostsCallback()
+	if err != nil {
+		return nil, err
+	}
+
+	auth, agentConn, err := f.authMethods()
+	if err != nil {
+		return nil, err
+	}
+
+	// the agent is only needed during the handshake
+	if agentConn != nil {
+		defer agentConn.Close()
+	}
+
+	user := os.Getenv("USER")
+	if f.userinfo != nil && f.userinfo.Username() != "" {
+		user = f.userinfo.Username()
+	}
+
+	addr := f.host
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "22")
+	}
+
+	d := net.Dialer{}
+
+	nc, err := d.DialContext(f.ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	c, chans, reqs, err := ssh.NewClientConn(nc, addr, &ssh.ClientConfig{
+		User:            user,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+	})
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("ssh connect to %s: %w", addr, err)
+	}
+
+	return ssh.NewClient(c, chans, reqs), nil
+}
+
+// authMethods returns the SSH auth methods to use. When the SSH agent is used,
+// the connection to it is also returned, and must be closed by the caller once
+// the handshake is complete.
+func (f *sftpFS) authMethods() ([]ssh.AuthMethod, io.Closer, error) {
+	if f.userinfo != nil {
+		if pass, ok := f.userinfo.Password(); ok {
+			return []ssh.AuthMethod{ssh.Password(pass)}, nil, nil
+		}
+	}
+
+	key, err := sftpSSHKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if key != nil {
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse SSH key: %w", err)
+		}
+
+		return []ssh.AuthMethod{ssh.PublicKeys(signer)}, nil, nil
+	}
+
+	sock := os.Getenv("SSH_AUTH_SOCK")
+	if sock == "" {
+		return nil, nil, fmt.Errorf("no SFTP credentials: set a password in the URL, set SFTP_SSH_KEY, or run an SSH agent")
+	}
+
+	ac, err := net.Dial("unix", sock)
+	if err != nil {
+		return nil, nil, fmt.Errorf("connect to SSH agent: %w", err)
+	}
+
+	return []ssh.AuthMethod{ssh.PublicKeysCallback(agent.NewClient(ac).Signers)}, ac, nil
+}
+
+// sftpSSHKey returns the private key from $SFTP_SSH_KEY or $SFTP_SSH_KEY_FILE,
+// which may be Base64-encoded, or nil if neither is set
+func sftpSSHKey() ([]byte, error) {
+	key := []byte(os.Getenv("SFTP_SSH_KEY"))
+	if len(key) == 0 {
+		fname := os.Getenv("SFTP_SSH_KEY_FILE")
+		if fname == "" {
+			return nil, nil
+		}
+
+		var err error
+
+		key, err = os.ReadFile(fname)
+		if err != nil {
+			return nil, fmt.Errorf("read SSH key file: %w", err)
+		}
+	}
+
+	if dec, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(key))); err == nil {
+		key = dec
+	}
+
+	return key, nil
+}
+
+func knownHostsCallback() (ssh.HostKeyCallback, error) {
+	fname := os.Getenv("SSH_KNOWN_HOSTS")
+	if fname == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("find known_hosts: %w", err)
+		}
+
+		fname = filepath.Join(home, ".ssh", "known_hosts")
+	}
+
+	cb, err := knownhosts.New(fname)
+	if err != nil {
+		return nil, fmt.Errorf("load known_hosts: %w", err)
+	}
+
+	return cb, nil
+}
+
+// memFile is a file or directory read fully into memory from a remote server
+type memFile struct {
+	fi      fs.FileInfo
+	body    io.Reader
+	dirents []fs.DirEntry
+	diroff  int
+}
+
+var (
+	_ fs.File        = (*memFile)(nil)
+	_ fs.ReadDirFile = (*memFile)(nil)
+)
+
+func (f *memFile) Close() error { return nil }
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.fi, nil }
+
+func (f *memFile) Read(p []byte) (int, error) {
+	if f.body == nil {
+		return 0, &fs.PathError{Op: "read", Path: f.fi.Name(), Err: fmt.Errorf("is a directory")}
+	}
+
+	return f.body.Read(p)
+}
+
+func (f *memFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !f.fi.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: f.fi.Name(), Err: fmt.Errorf("not a directory")}
+	}
+
+	remaining := f.dirents[f.diroff:]
+	if n > 0 && len(remaining) == 0 {
+		return nil, io.EOF
+	}
+
+	if n > 0 && n < len(remaining) {
+		remaining = remaining[:n]
+	}
+
+	f.diroff += len(remaining)
+
+	return remaining, nil
+}
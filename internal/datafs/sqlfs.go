@@ -0,0 +1,284 @@
+package datafs
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
+
+	// register the PostgreSQL driver
+	_ "github.com/lib/pq"
+)
+
+// NewSQLFS returns a filesystem (an fs.FS) that can be used to query SQL
+// databases. The database name is given as the file name, and the SELECT
+// statement to run is given in the "query" query parameter, or in the URL
+// fragment. Query results are presented as a JSON array of objects, one per
+// row, keyed by column name.
+//
+// PostgreSQL and MySQL are supported, with the sql+postgres and sql+mysql
+// schemes. With the plain sql scheme, the driver is named in the "driver"
+// query parameter, and defaults to postgres. Other query parameters are passed
+// through to the driver as connection parameters.
+func NewSQLFS(u *url.URL) (fs.FS, error) {
+	q := u.Query()
+
+	var driver string
+	switch u.Scheme {
+	case "sql":
+		driver = q.Get("driver")
+		if driver == "" {
+			driver = "postgres"
+		}
+	case "sql+postgres", "sql+postgresql":
+		driver = "postgres"
+	case "sql+mysql":
+		driver = "mysql"
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+
+	switch driver {
+	case "postgres", "postgresql":
+		driver = "postgres"
+	case "mysql":
+	default:
+		return nil, fmt.Errorf("unsupported SQL driver %q", driver)
+	}
+
+	query := q.Get("query")
+	if query == "" {
+		query = u.Fragment
+	}
+
+	q.Del("query")
+	q.Del("type")
+	q.Del("driver")
+
+	dsn := *u
+	dsn.Scheme = driver
+	dsn.RawQuery = q.Encode()
+	dsn.Fragment = ""
+	dsn.RawFragment = ""
+
+	return &sqlFS{
+		ctx:    context.Background(),
+		driver: driver,
+		dsn:    &dsn,
+		query:  query,
+	}, nil
+}
+
+type sqlFS struct {
+	ctx    context.Context
+	dsn    *url.URL
+	driver string
+	query  string
+}
+
+//nolint:gochecknoglobals
+var SQLFS = fsimpl.FSProviderFunc(NewSQLFS, "sql", "sql+postgres", "sql+postgresql", "sql+mysql")
+
+var (
+	_ fs.FS         = (*sqlFS)(nil)
+	_ withContexter = (*sqlFS)(nil)
+)
+
+func (f *sqlFS) WithContext(ctx context.Context) fs.FS {
+	if ctx == nil {
+		return f
+	}
+
+	fsys := *f
+	fsys.ctx = ctx
+
+	return &fsys
+}
+
+func (f *sqlFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) || name == "." || strings.Contains(name, "/") {
+		return nil, &fs.PathError{
+			Op:   "open",
+			Path: name,
+			Err:  fs.ErrInvalid,
+		}
+	}
+
+	if f.query == "" {
+		return nil, &fs.PathError{
+			Op:   "open",
+			Path: name,
+			Err:  fmt.Errorf("a SELECT statement must be given in the 'query' parameter or the URL fragment"),
+		}
+	}
+
+	return &sqlFile{fsys: f, name: name}, nil
+}
+
+// dataSourceName returns the driver-specific data source name for the named
+// database. PostgreSQL accepts URLs, but MySQL needs the DSN format described
+// at https://github.com/go-sql-driver/mysql#dsn-data-source-name.
+func (f *sqlFS) dataSourceName(name string) (string, error) {
+	dsn := *f.dsn
+	dsn.Path = "/" + name
+
+	if f.driver != "mysql" {
+		return dsn.String(), nil
+	}
+
+	// the driver parses the address, database, and parameters (including
+	// driver options like parseTime), and the credentials are set afterwards
+	// so that they don't need to be escaped
+	s := "/" + name
+	if dsn.Host != "" {
+		s = "tcp(" + dsn.Host + ")" + s
+	}
+
+	if dsn.RawQuery != "" {
+		s += "?" + dsn.RawQuery
+	}
+
+	cfg, err := mysql.ParseDSN(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid MySQL connection parameters: %w", err)
+	}
+
+	if dsn.User != nil {
+		cfg.User = dsn.User.Username()
+		cfg.Passwd, _ = dsn.User.Password()
+	}
+
+	return cfg.FormatDSN(), nil
+}
+
+// runQuery connects to the named database, runs the query in a read-only
+// transaction, and returns the results as a JSON array of objects
+func (f *sqlFS) runQuery(name string) ([]byte, error) {
+	dsn, err := f.dataSourceName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open(f.driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+
+	tx, err := db.BeginTx(f.ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	//nolint:errcheck
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(f.ctx, f.query)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+	defer rows.Close()
+
+	out, err := rowsToMaps(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(out)
+}
+
+// rowsToMaps reads all rows into a slice of maps keyed by column name
+func rowsToMaps(rows *sql.Rows) ([]map[string]interface{}, error) {
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("columns: %w", err)
+	}
+
+	out := []map[string]interface{}{}
+	for rows.Next() {
+		vals := make([]interface{}, len(cols))
+		ptrs := make([]interface{}, len(cols))
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+
+		row := make(map[string]interface{}, len(cols))
+		for i, c := range cols {
+			// text values are usually returned as byte slices
+			if b, ok := vals[i].([]byte); ok {
+				row[c] = string(b)
+			} else {
+				row[c] = vals[i]
+			}
+		}
+
+		out = append(out, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+
+	return out, nil
+}
+
+type sqlFile struct {
+	fsys *sqlFS
+	body io.Reader
+	name string
+	size int64
+}
+
+var _ fs.File = (*sqlFile)(nil)
+
+func (f *sqlFile) Close() error {
+	f.body = nil
+	return nil
+}
+
+func (f *sqlFile) read() error {
+	if f.body != nil {
+		return nil
+	}
+
+	b, err := f.fsys.runQuery(f.name)
+	if err != nil {
+		return &fs.PathError{Op: "read", Path: f.name, Err: err}
+	}
+
+	f.body = bytes.NewReader(b)
+	f.size = int64(len(b))
+
+	return nil
+}
+
+func (f *sqlFile) Stat() (fs.FileInfo, error) {
+	err := f.read()
+	if err != nil {
+		return nil, err
+	}
+
+	return FileInfo(f.name, f.size, 0o444, time.Time{}, iohelpers.JSONArrayMimetype), nil
+}
+
+func (f *sqlFile) Read(p []byte) (int, error) {
+	err := f.read()
+	if err != nil {
+		return 0, err
+	}
+
+	return f.body.Read(p)
+}
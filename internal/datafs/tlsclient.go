@@ -0,0 +1,55 @@
+package datafs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// NewTLSHTTPClient returns an HTTP client configured to present the given
+// client certificate and key (for mutual TLS), and to trust the CA
+// certificates in the given PEM bundle in addition to the system roots. Any of
+// the file names may be empty, but the certificate and key must be given
+// together.
+func NewTLSHTTPClient(certFile, keyFile, caFile string) (*http.Client, error) {
+	if (certFile == "") != (keyFile == "") {
+		return nil, fmt.Errorf("TLS client certificate and key must both be provided")
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if caFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		b, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("no valid certificates found in CA bundle %s", caFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	//nolint:forcetypeassert
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{Transport: transport}, nil
+}
@@ -0,0 +1,56 @@
+package datafs
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTLSHTTPClient(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("hello"))
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+
+	caFile := filepath.Join(dir, "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0o600))
+
+	badFile := filepath.Join(dir, "bad.pem")
+	require.NoError(t, os.WriteFile(badFile, []byte("not a cert"), 0o600))
+
+	_, err := NewTLSHTTPClient("cert.pem", "", "")
+	require.Error(t, err)
+
+	_, err = NewTLSHTTPClient("", "key.pem", "")
+	require.Error(t, err)
+
+	_, err = NewTLSHTTPClient("", "", filepath.Join(dir, "missing.pem"))
+	require.Error(t, err)
+
+	_, err = NewTLSHTTPClient("", "", badFile)
+	require.Error(t, err)
+
+	_, err = NewTLSHTTPClient(badFile, badFile, "")
+	require.Error(t, err)
+
+	client, err := NewTLSHTTPClient("", "", caFile)
+	require.NoError(t, err)
+
+	res, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"strings"
 
 	"github.com/hairyhenderson/go-fsimpl/vaultfs/vaultauth"
 	"github.com/hairyhenderson/gomplate/v4/internal/deprecated"
@@ -14,11 +15,13 @@ import (
 )
 
 // compositeVaultAuthMethod configures the auth method based on environment
-// variables. It extends [vaultfs.EnvAuthMethod] by falling back to AWS EC2
-// authentication if the other methods fail.
+// variables. It extends [vaultfs.EnvAuthMethod] by falling back to Kubernetes,
+// AWS IAM, and AWS EC2 authentication if the other methods fail.
 func compositeVaultAuthMethod(envFsys fs.FS) api.AuthMethod {
 	return vaultauth.CompositeAuthMethod(
 		vaultauth.EnvAuthMethod(),
+		envK8sAuthAdapter(envFsys),
+		envIAMAuthAdapter(envFsys),
 		envEC2AuthAdapter(envFsys),
 	)
 }
@@ -27,6 +30,92 @@ func compositeVaultAuthMethod(envFsys fs.FS) api.AuthMethod {
 // 	return compositeVaultAuthMethod(WrapWdFS(osfs.NewFS()))
 // }
 
+const defaultK8sTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// envK8sAuthAdapter builds a Kubernetes authentication method from environment
+// variables, for use only with [CompositeVaultAuthMethod]. The role must be
+// set with $VAULT_AUTH_K8S_ROLE, otherwise nil is returned.
+func envK8sAuthAdapter(envFS fs.FS) api.AuthMethod {
+	role := GetenvFsys(envFS, "VAULT_AUTH_K8S_ROLE")
+	if role == "" {
+		return nil
+	}
+
+	return &k8sAuth{
+		fsys:      envFS,
+		role:      role,
+		mountPath: GetenvFsys(envFS, "VAULT_AUTH_K8S_MOUNT", "kubernetes"),
+		tokenPath: GetenvFsys(envFS, "VAULT_AUTH_K8S_TOKEN_PATH", defaultK8sTokenPath),
+	}
+}
+
+// k8sAuth logs in to Vault with the Kubernetes auth method, using the pod's
+// service account token
+type k8sAuth struct {
+	fsys      fs.FS
+	role      string
+	mountPath string
+	tokenPath string
+}
+
+var _ api.AuthMethod = (*k8sAuth)(nil)
+
+func (a *k8sAuth) Login(ctx context.Context, client *api.Client) (*api.Secret, error) {
+	jwt, err := readFile(a.fsys, a.tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read service account token: %w", err)
+	}
+
+	secret, err := client.Logical().WriteWithContext(ctx, "auth/"+a.mountPath+"/login",
+		map[string]interface{}{
+			"role": a.role,
+			"jwt":  strings.TrimSpace(jwt),
+		})
+	if err != nil {
+		return nil, fmt.Errorf("unable to log in with Kubernetes auth: %w", err)
+	}
+
+	if secret == nil || secret.Auth == nil {
+		return nil, fmt.Errorf("no auth info returned from Kubernetes auth login")
+	}
+
+	return secret, nil
+}
+
+// envIAMAuthAdapter builds an AWS IAM authentication method from environment
+// variables, for use only with [CompositeVaultAuthMethod]. IAM authentication
+// must be selected by setting $VAULT_AUTH_AWS_METHOD to "iam", otherwise nil is
+// returned.
+func envIAMAuthAdapter(envFS fs.FS) api.AuthMethod {
+	if GetenvFsys(envFS, "VAULT_AUTH_AWS_METHOD") != "iam" {
+		return nil
+	}
+
+	opts := []aws.LoginOption{
+		aws.WithIAMAuth(),
+		aws.WithMountPath(GetenvFsys(envFS, "VAULT_AUTH_AWS_MOUNT", "aws")),
+	}
+
+	if role := GetenvFsys(envFS, "VAULT_AUTH_AWS_ROLE"); role != "" {
+		opts = append(opts, aws.WithRole(role))
+	}
+
+	if header := GetenvFsys(envFS, "VAULT_AUTH_AWS_HEADER_VALUE"); header != "" {
+		opts = append(opts, aws.WithIAMServerIDHeader(header))
+	}
+
+	if region := GetenvFsys(envFS, "AWS_REGION"); region != "" {
+		opts = append(opts, aws.WithRegion(region))
+	}
+
+	awsauth, err := aws.NewAWSAuth(opts...)
+	if err != nil {
+		return nil
+	}
+
+	return awsauth
+}
+
 // envEC2AuthAdapter builds an AWS EC2 authentication method from environment
 // variables, for use only with [CompositeVaultAuthMethod]
 func envEC2AuthAdapter(envFS fs.FS) api.AuthMethod {
@@ -0,0 +1,93 @@
+package datafs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvK8sAuthAdapter(t *testing.T) {
+	fsys := fstest.MapFS{}
+
+	assert.Nil(t, envK8sAuthAdapter(fsys))
+
+	t.Setenv("VAULT_AUTH_K8S_ROLE", "myrole")
+
+	a := envK8sAuthAdapter(fsys)
+	require.NotNil(t, a)
+	assert.Equal(t, &k8sAuth{
+		fsys:      fsys,
+		role:      "myrole",
+		mountPath: "kubernetes",
+		tokenPath: defaultK8sTokenPath,
+	}, a)
+
+	t.Setenv("VAULT_AUTH_K8S_MOUNT", "k8s-prod")
+	t.Setenv("VAULT_AUTH_K8S_TOKEN_PATH", "/tmp/token")
+
+	a = envK8sAuthAdapter(fsys)
+	assert.Equal(t, "k8s-prod", a.(*k8sAuth).mountPath)
+	assert.Equal(t, "/tmp/token", a.(*k8sAuth).tokenPath)
+}
+
+func TestK8sAuth_Login(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/auth/kubernetes/login", func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]string{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		if body["role"] != "myrole" || body["jwt"] != "eyJhbGciOi.foo.bar" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors": ["permission denied"]}`))
+
+			return
+		}
+
+		w.Write([]byte(`{"auth": {"client_token": "s.abc123"}}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client, err := api.NewClient(&api.Config{Address: srv.URL})
+	require.NoError(t, err)
+
+	fsys := fstest.MapFS{
+		"token": &fstest.MapFile{Data: []byte("eyJhbGciOi.foo.bar\n")},
+	}
+
+	a := &k8sAuth{fsys: fsys, role: "myrole", mountPath: "kubernetes", tokenPath: "token"}
+
+	secret, err := a.Login(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, "s.abc123", secret.Auth.ClientToken)
+
+	a.role = "otherrole"
+	_, err = a.Login(context.Background(), client)
+	require.Error(t, err)
+
+	a.tokenPath = "missing"
+	_, err = a.Login(context.Background(), client)
+	require.Error(t, err)
+}
+
+func TestEnvIAMAuthAdapter(t *testing.T) {
+	fsys := fstest.MapFS{}
+
+	assert.Nil(t, envIAMAuthAdapter(fsys))
+
+	t.Setenv("VAULT_AUTH_AWS_METHOD", "ec2")
+	assert.Nil(t, envIAMAuthAdapter(fsys))
+
+	t.Setenv("VAULT_AUTH_AWS_METHOD", "iam")
+	t.Setenv("VAULT_AUTH_AWS_ROLE", "myrole")
+	t.Setenv("VAULT_AUTH_AWS_HEADER_VALUE", "vault.example.com")
+	assert.NotNil(t, envIAMAuthAdapter(fsys))
+}
@@ -0,0 +1,229 @@
+package datafs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/hairyhenderson/go-fsimpl/vaultfs"
+	"github.com/hairyhenderson/go-fsimpl/vaultfs/vaultauth"
+	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
+	"github.com/hashicorp/vault/api"
+)
+
+// NewVaultFS returns a filesystem (an fs.FS) that can be used to read secrets
+// from Vault. It wraps go-fsimpl's vaultfs, adding support for the "lease"
+// query parameter. When lease=true, secrets are read along with their lease
+// metadata, as a JSON object like:
+//
+//	{"data": {...}, "lease_id": "...", "lease_duration": 3600, "renewable": true}
+//
+// The lease parameter is never sent to Vault.
+func NewVaultFS(u *url.URL) (fs.FS, error) {
+	q := u.Query()
+	lease := q.Get("lease")
+	q.Del("lease")
+
+	base := *u
+	base.RawQuery = q.Encode()
+
+	if lease != "true" {
+		return vaultfs.New(&base)
+	}
+
+	config := api.DefaultConfig()
+	if config.Error != nil {
+		return nil, fmt.Errorf("vault configuration error: %w", config.Error)
+	}
+
+	// as with vaultfs, the address is only taken from the URL if it has a host
+	// part - otherwise $VAULT_ADDR is used
+	if u.Host != "" {
+		scheme := strings.TrimPrefix(u.Scheme, "vault+")
+		if scheme == "vault" {
+			scheme = "https"
+		}
+
+		config.Address = scheme + "://" + u.Host
+	}
+
+	client, err := api.NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("vault client creation failed: %w", err)
+	}
+
+	return &vaultLeaseFS{
+		ctx:    context.Background(),
+		client: client,
+		auth:   vaultauth.NewTokenAuth(""),
+		root:   strings.Trim(u.Path, "/"),
+		params: q,
+	}, nil
+}
+
+//nolint:gochecknoglobals
+var VaultFS = fsimpl.FSProviderFunc(NewVaultFS, "vault", "vault+http", "vault+https")
+
+// vaultLeaseFS reads Vault secrets along with their lease metadata
+type vaultLeaseFS struct {
+	ctx    context.Context
+	client *api.Client
+	auth   api.AuthMethod
+	params url.Values
+	root   string
+}
+
+var (
+	_ fs.FS         = (*vaultLeaseFS)(nil)
+	_ withContexter = (*vaultLeaseFS)(nil)
+)
+
+func (f *vaultLeaseFS) WithContext(ctx context.Context) fs.FS {
+	if ctx == nil {
+		return f
+	}
+
+	fsys := *f
+	fsys.ctx = ctx
+
+	return &fsys
+}
+
+func (f *vaultLeaseFS) WithHeader(headers http.Header) fs.FS {
+	for k, vs := range headers {
+		for _, v := range vs {
+			f.client.AddHeader(k, v)
+		}
+	}
+
+	return f
+}
+
+// WithAuthMethod sets the auth method, so that the filesystem can be
+// configured with [vaultauth.WithAuthMethod]
+func (f *vaultLeaseFS) WithAuthMethod(auth api.AuthMethod) fs.FS {
+	fsys := *f
+	fsys.auth = auth
+
+	return &fsys
+}
+
+func (f *vaultLeaseFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) || name == "." {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	return &vaultLeaseFile{fsys: f, name: name}, nil
+}
+
+// readSecret logs in if necessary, and reads the secret at the given path. As
+// with vaultfs, parameters are sent in the body of a POST request, as required
+// by some secrets engines.
+//
+// Note that the token isn't revoked after reading, as that would also revoke
+// the lease - it will expire according to its own TTL.
+func (f *vaultLeaseFS) readSecret(name string) (*api.Secret, error) {
+	if f.client.Token() == "" {
+		secret, err := f.auth.Login(f.ctx, f.client)
+		if err != nil {
+			return nil, fmt.Errorf("vault login failure: %w", err)
+		}
+
+		f.client.SetToken(secret.Auth.ClientToken)
+	}
+
+	p := path.Join(f.root, name)
+
+	var (
+		secret *api.Secret
+		err    error
+	)
+
+	if len(f.params) > 0 {
+		data := map[string]interface{}{}
+		for k := range f.params {
+			data[k] = f.params.Get(k)
+		}
+
+		secret, err = f.client.Logical().WriteWithContext(f.ctx, p, data)
+	} else {
+		secret, err = f.client.Logical().ReadWithContext(f.ctx, p)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", p, err)
+	}
+
+	if secret == nil {
+		return nil, fs.ErrNotExist
+	}
+
+	return secret, nil
+}
+
+type vaultLeaseFile struct {
+	fsys *vaultLeaseFS
+	body io.Reader
+	name string
+	size int64
+}
+
+var _ fs.File = (*vaultLeaseFile)(nil)
+
+func (f *vaultLeaseFile) Close() error {
+	f.body = nil
+	return nil
+}
+
+func (f *vaultLeaseFile) read() error {
+	if f.body != nil {
+		return nil
+	}
+
+	secret, err := f.fsys.readSecret(f.name)
+	if err != nil {
+		return &fs.PathError{Op: "read", Path: f.name, Err: err}
+	}
+
+	b, err := json.Marshal(map[string]interface{}{
+		"data":           secret.Data,
+		"lease_id":       secret.LeaseID,
+		"lease_duration": secret.LeaseDuration,
+		"renewable":      secret.Renewable,
+	})
+	if err != nil {
+		return &fs.PathError{Op: "read", Path: f.name, Err: err}
+	}
+
+	f.body = bytes.NewReader(b)
+	f.size = int64(len(b))
+
+	return nil
+}
+
+func (f *vaultLeaseFile) Stat() (fs.FileInfo, error) {
+	err := f.read()
+	if err != nil {
+		return nil, err
+	}
+
+	return FileInfo(path.Base(f.name), f.size, 0o444, time.Time{}, iohelpers.JSONMimetype), nil
+}
+
+func (f *vaultLeaseFile) Read(p []byte) (int, error) {
+	err := f.read()
+	if err != nil {
+		return 0, err
+	}
+
+	return f.body.Read(p)
+}
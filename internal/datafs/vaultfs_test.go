@@ -0,0 +1,107 @@
+package datafs
+
+import (
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hairyhenderson/go-fsimpl/vaultfs/vaultauth"
+	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultFSLease(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/database/creds/readonly", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.abc123" || r.URL.Query().Has("lease") {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors": ["permission denied"]}`))
+
+			return
+		}
+
+		w.Write([]byte(`{
+			"lease_id": "database/creds/readonly/2f6a614c",
+			"lease_duration": 3600,
+			"renewable": true,
+			"data": {"username": "v-token-readonly", "password": "s3cr3t"}
+		}`))
+	})
+	mux.HandleFunc("/v1/database/creds/missing", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": []}`))
+	})
+	mux.HandleFunc("/v1/aws/sts/deploy", func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]string{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		if r.Method != http.MethodPut || body["ttl"] != "15m" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.Write([]byte(`{
+			"lease_id": "aws/sts/deploy/9a8b7c",
+			"lease_duration": 900,
+			"renewable": false,
+			"data": {"access_key": "ASIAEXAMPLE"}
+		}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	srvURL, _ := url.Parse(srv.URL)
+
+	fsys, err := NewVaultFS(mustParseURL("vault+http://" + srvURL.Host + "/?lease=true"))
+	require.NoError(t, err)
+
+	fsys = vaultauth.WithAuthMethod(vaultauth.NewTokenAuth("s.abc123"), fsys)
+
+	b, err := fs.ReadFile(fsys, "database/creds/readonly")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"lease_id": "database/creds/readonly/2f6a614c",
+		"lease_duration": 3600,
+		"renewable": true,
+		"data": {"username": "v-token-readonly", "password": "s3cr3t"}
+	}`, string(b))
+
+	fi, err := fs.Stat(fsys, "database/creds/readonly")
+	require.NoError(t, err)
+	assert.Equal(t, "readonly", fi.Name())
+	assert.Equal(t, iohelpers.JSONMimetype, fi.(interface{ ContentType() string }).ContentType())
+
+	_, err = fs.ReadFile(fsys, "database/creds/missing")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = fsys.Open("/database")
+	require.ErrorIs(t, err, fs.ErrInvalid)
+
+	// other parameters are sent to Vault
+	fsys, err = NewVaultFS(mustParseURL("vault+http://" + srvURL.Host + "/aws/?lease=true&ttl=15m"))
+	require.NoError(t, err)
+
+	fsys = vaultauth.WithAuthMethod(vaultauth.NewTokenAuth("s.abc123"), fsys)
+
+	b, err = fs.ReadFile(fsys, "sts/deploy")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"lease_id": "aws/sts/deploy/9a8b7c",
+		"lease_duration": 900,
+		"renewable": false,
+		"data": {"access_key": "ASIAEXAMPLE"}
+	}`, string(b))
+
+	// without lease=true, go-fsimpl's vaultfs is used
+	fsys, err = NewVaultFS(mustParseURL("vault+http://" + srvURL.Host + "/?lease=false"))
+	require.NoError(t, err)
+
+	_, ok := fsys.(*vaultLeaseFS)
+	assert.False(t, ok)
+}
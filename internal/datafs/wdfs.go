@@ -273,6 +273,8 @@ var (
 	_ hackpadfs.MkdirAllFS = (*wdFS)(nil)
 	_ hackpadfs.RemoveFS   = (*wdFS)(nil)
 	_ hackpadfs.ChmodFS    = (*wdFS)(nil)
+	_ hackpadfs.RenameFS   = (*wdFS)(nil)
+	_ hackpadfs.LstatFS    = (*wdFS)(nil)
 )
 
 func (w *wdFS) fsysFor(vol string) (fs.FS, error) {
@@ -353,10 +355,28 @@ func (w *wdFS) Sub(name string) (fs.FS, error) {
 	return fs.Sub(w.fsys, name)
 }
 
-func (w *wdFS) Glob(_ string) ([]string, error) {
-	// I'm not sure how to handle this, so I'm just going to error for now -
-	// I have no need of Glob anyway.
-	return nil, fmt.Errorf("glob not supported by wdFS: %w", fs.ErrInvalid)
+func (w *wdFS) Glob(pattern string) ([]string, error) {
+	// fs.Glob's generic implementation only needs ReadDir and Stat, which
+	// already resolve paths relative to the working directory. It must not see
+	// this Glob method though, or it would recurse.
+	return fs.Glob(noGlobFS{w}, pattern)
+}
+
+// noGlobFS hides wdFS's Glob method from fs.Glob
+type noGlobFS struct {
+	w *wdFS
+}
+
+func (g noGlobFS) Open(name string) (fs.File, error) {
+	return g.w.Open(name)
+}
+
+func (g noGlobFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return g.w.ReadDir(name)
+}
+
+func (g noGlobFS) Stat(name string) (fs.FileInfo, error) {
+	return g.w.Stat(name)
 }
 
 func (w *wdFS) Create(name string) (fs.File, error) {
@@ -434,3 +454,34 @@ func (w *wdFS) Chmod(name string, mode fs.FileMode) error {
 	}
 	return hackpadfs.Chmod(fsys, resolved, mode)
 }
+
+func (w *wdFS) Rename(oldname, newname string) error {
+	root, oldResolved, err := resolveLocalPath(w.vol, oldname)
+	if err != nil {
+		return fmt.Errorf("resolve: %w", err)
+	}
+	newRoot, newResolved, err := resolveLocalPath(w.vol, newname)
+	if err != nil {
+		return fmt.Errorf("resolve: %w", err)
+	}
+	if newRoot != root {
+		return &fs.PathError{Op: "rename", Path: newname, Err: fmt.Errorf("can not rename across volumes %q and %q", root, newRoot)}
+	}
+	fsys, err := w.fsysFor(root)
+	if err != nil {
+		return err
+	}
+	return hackpadfs.Rename(fsys, oldResolved, newResolved)
+}
+
+func (w *wdFS) Lstat(name string) (fs.FileInfo, error) {
+	root, resolved, err := resolveLocalPath(w.vol, name)
+	if err != nil {
+		return nil, fmt.Errorf("resolve: %w", err)
+	}
+	fsys, err := w.fsysFor(root)
+	if err != nil {
+		return nil, err
+	}
+	return hackpadfs.Lstat(fsys, resolved)
+}
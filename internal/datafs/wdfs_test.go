@@ -62,6 +62,15 @@ func TestWDFS_ReadOps(t *testing.T) {
 	b, err = fs.ReadFile(subfs, "bar")
 	require.NoError(t, err)
 	assert.Equal(t, "goodnight moon", string(b))
+
+	matches, err := fs.Glob(fsys, "/tmp/*.txt")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/tmp/one.txt", "/tmp/three.txt", "/tmp/two.txt"}, matches)
+
+	// relative to the working directory (/)
+	matches, err = fs.Glob(fsys, "tmp/s*/*")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tmp/sub/bar"}, matches)
 }
 
 func TestWDFS_WriteOps(t *testing.T) {
@@ -133,6 +142,18 @@ func TestWDFS_WriteOps(t *testing.T) {
 	assert.True(t, fi.Mode().IsRegular())
 	assert.Equal(t, "0444", fmt.Sprintf("%#o", fi.Mode().Perm()))
 
+	// rename it, and back again
+	err = fsys.Rename("/tmp/foo", "/tmp/sub/foo")
+	require.NoError(t, err)
+	_, err = fsys.Stat("/tmp/foo")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	err = fsys.Rename("/tmp/sub/foo", "/tmp/foo")
+	require.NoError(t, err)
+
+	fi, err = fsys.Lstat("/tmp/foo")
+	require.NoError(t, err)
+	assert.True(t, fi.Mode().IsRegular())
+
 	// now delete it
 	err = fsys.Remove("/tmp/foo")
 	require.NoError(t, err)
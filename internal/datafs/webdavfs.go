@@ -0,0 +1,234 @@
+package datafs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/studio-b12/gowebdav"
+)
+
+// NewWebDAVFS returns a filesystem (an fs.FS) that can be used to read files
+// and list directories on WebDAV servers (such as Nextcloud or SharePoint).
+// Basic or Digest authentication credentials can be given in the URL's
+// userinfo.
+func NewWebDAVFS(u *url.URL) (fs.FS, error) {
+	scheme := ""
+	switch u.Scheme {
+	case "webdav+http":
+		scheme = "http"
+	case "webdav+https":
+		scheme = "https"
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("host must be given in URL %q", u)
+	}
+
+	return &webdavFS{
+		ctx:      context.Background(),
+		base:     &url.URL{Scheme: scheme, Host: u.Host, Path: u.Path},
+		userinfo: u.User,
+	}, nil
+}
+
+type webdavFS struct {
+	ctx        context.Context
+	httpClient *http.Client
+	base       *url.URL
+	userinfo   *url.Userinfo
+}
+
+//nolint:gochecknoglobals
+var WebDAVFS = fsimpl.FSProviderFunc(NewWebDAVFS, "webdav+http", "webdav+https")
+
+var (
+	_ fs.FS         = (*webdavFS)(nil)
+	_ fs.ReadDirFS  = (*webdavFS)(nil)
+	_ withContexter = (*webdavFS)(nil)
+)
+
+func (f *webdavFS) WithContext(ctx context.Context) fs.FS {
+	if ctx == nil {
+		return f
+	}
+
+	fsys := *f
+	fsys.ctx = ctx
+
+	return &fsys
+}
+
+func (f *webdavFS) WithHTTPClient(client *http.Client) fs.FS {
+	if client == nil {
+		return f
+	}
+
+	fsys := *f
+	fsys.httpClient = client
+
+	return &fsys
+}
+
+// client returns a WebDAV client for a single operation. Authentication is
+// negotiated with the server, so Basic and Digest auth are both supported.
+func (f *webdavFS) client() *gowebdav.Client {
+	user, pass := "", ""
+	if f.userinfo != nil {
+		user = f.userinfo.Username()
+		pass, _ = f.userinfo.Password()
+	}
+
+	c := gowebdav.NewClient(f.base.String(), user, pass)
+
+	if f.httpClient != nil {
+		c.SetTransport(f.httpClient.Transport)
+		c.SetTimeout(f.httpClient.Timeout)
+	}
+
+	ctx := f.ctx
+	c.SetInterceptor(func(_ string, req *http.Request) {
+		*req = *req.WithContext(ctx)
+	})
+
+	return c
+}
+
+func (f *webdavFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	return &webdavFile{client: f.client(), name: name}, nil
+}
+
+func (f *webdavFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	fis, err := f.client().ReadDir(path.Join("/", name))
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: webdavError(err)}
+	}
+
+	entries := make([]fs.DirEntry, len(fis))
+	for i, fi := range fis {
+		entries[i] = FileInfoDirEntry(webdavFileInfo(fi.Name(), fi))
+	}
+
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	return entries, nil
+}
+
+// webdavFileInfo converts file info from the WebDAV client, using the given
+// name as some servers don't return a display name
+func webdavFileInfo(name string, fi fs.FileInfo) fs.FileInfo {
+	if fi.IsDir() {
+		return DirInfo(name, fi.ModTime())
+	}
+
+	ct := ""
+	if c, ok := fi.(interface{ ContentType() string }); ok {
+		ct = webdavContentType(c.ContentType())
+	}
+
+	return FileInfo(name, fi.Size(), 0o444, fi.ModTime(), ct)
+}
+
+// webdavContentType returns the given content type, unless it's a generic
+// binary type (which servers often return for unknown types), so that the
+// type is determined from the file extension instead
+func webdavContentType(ct string) string {
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil || mt == "application/octet-stream" {
+		return ""
+	}
+
+	return ct
+}
+
+// webdavError maps HTTP status errors from the WebDAV client to fs errors
+func webdavError(err error) error {
+	var serr gowebdav.StatusError
+	if !errors.As(err, &serr) {
+		return err
+	}
+
+	switch serr.Status {
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %w", fs.ErrNotExist, err)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %w", fs.ErrPermission, err)
+	default:
+		return err
+	}
+}
+
+type webdavFile struct {
+	client *gowebdav.Client
+	fi     fs.FileInfo
+	body   io.Reader
+	name   string
+}
+
+var _ fs.File = (*webdavFile)(nil)
+
+func (f *webdavFile) Close() error {
+	f.body = nil
+	return nil
+}
+
+func (f *webdavFile) Stat() (fs.FileInfo, error) {
+	if f.fi != nil {
+		return f.fi, nil
+	}
+
+	fi, err := f.client.Stat(path.Join("/", f.name))
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: f.name, Err: webdavError(err)}
+	}
+
+	f.fi = webdavFileInfo(path.Base(f.name), fi)
+
+	return f.fi, nil
+}
+
+func (f *webdavFile) read() error {
+	if f.body != nil {
+		return nil
+	}
+
+	b, err := f.client.Read(path.Join("/", f.name))
+	if err != nil {
+		return &fs.PathError{Op: "read", Path: f.name, Err: webdavError(err)}
+	}
+
+	f.body = bytes.NewReader(b)
+
+	return nil
+}
+
+func (f *webdavFile) Read(p []byte) (int, error) {
+	err := f.read()
+	if err != nil {
+		return 0, err
+	}
+
+	return f.body.Read(p)
+}
@@ -0,0 +1,142 @@
+package datafs
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWebDAVServer serves the given filesystem over (a small subset of) WebDAV
+func fakeWebDAVServer(t *testing.T, fsys fstest.MapFS) *httptest.Server {
+	t.Helper()
+
+	propResponse := func(href string, fi fs.FileInfo) string {
+		rt, extra := "", ""
+		if fi.IsDir() {
+			rt = "<d:collection/>"
+		} else {
+			extra = fmt.Sprintf("<d:getcontentlength>%d</d:getcontentlength>"+
+				"<d:getcontenttype>application/octet-stream</d:getcontenttype>", fi.Size())
+		}
+
+		return fmt.Sprintf(`<d:response><d:href>%s</d:href><d:propstat><d:prop>`+
+			`<d:resourcetype>%s</d:resourcetype>%s`+
+			`<d:getlastmodified>Mon, 02 Jan 2006 15:04:05 GMT</d:getlastmodified>`+
+			`</d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`,
+			href, rt, extra)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "user" || pass != "secret" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		name := strings.Trim(r.URL.Path, "/")
+		if name == "" {
+			name = "."
+		}
+
+		fi, err := fs.Stat(fsys, name)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write(fsys[name].Data)
+		case "PROPFIND":
+			out := `<?xml version="1.0"?><d:multistatus xmlns:d="DAV:">`
+			out += propResponse(r.URL.Path, fi)
+
+			if fi.IsDir() && r.Header.Get("Depth") == "1" {
+				des, _ := fs.ReadDir(fsys, name)
+				for _, de := range des {
+					dfi, _ := de.Info()
+					out += propResponse(strings.TrimSuffix(r.URL.Path, "/")+"/"+de.Name(), dfi)
+				}
+			}
+
+			out += `</d:multistatus>`
+
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusMultiStatus)
+			_, _ = w.Write([]byte(out))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestWebDAVFS(t *testing.T) {
+	_, err := NewWebDAVFS(mustParseURL("webdav+https:///foo"))
+	require.Error(t, err)
+
+	_, err = NewWebDAVFS(mustParseURL("https://example.com"))
+	require.Error(t, err)
+
+	srv := fakeWebDAVServer(t, fstest.MapFS{
+		"conf/config.json": {Data: []byte(`{"hello": "world"}`)},
+		"conf/app.yaml":    {Data: []byte("port: 8080\n")},
+		"conf/sub/x.txt":   {Data: []byte("x")},
+	})
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	fsys, err := NewWebDAVFS(mustParseURL("webdav+http://user:secret@" + host + "/"))
+	require.NoError(t, err)
+
+	b, err := fs.ReadFile(fsys, "conf/config.json")
+	require.NoError(t, err)
+	assert.Equal(t, `{"hello": "world"}`, string(b))
+
+	fi, err := fs.Stat(fsys, "conf/app.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "app.yaml", fi.Name())
+	assert.Equal(t, int64(11), fi.Size())
+	assert.Equal(t, 2006, fi.ModTime().Year())
+
+	// generic binary content types are ignored
+	assert.Equal(t, "", fi.(interface{ ContentType() string }).ContentType())
+
+	fi, err = fs.Stat(fsys, "conf")
+	require.NoError(t, err)
+	assert.True(t, fi.IsDir())
+
+	des, err := fs.ReadDir(fsys, "conf")
+	require.NoError(t, err)
+	require.Len(t, des, 3)
+	assert.Equal(t, "app.yaml", des[0].Name())
+	assert.Equal(t, "config.json", des[1].Name())
+	assert.Equal(t, "sub", des[2].Name())
+	assert.True(t, des[2].IsDir())
+
+	_, err = fs.ReadFile(fsys, "conf/missing.json")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = fs.ReadFile(fsys.(withContexter).WithContext(ctx), "conf/config.json")
+	require.ErrorIs(t, err, context.Canceled)
+
+	fsys, err = NewWebDAVFS(mustParseURL("webdav+http://user:wrong@" + host + "/"))
+	require.NoError(t, err)
+
+	_, err = fs.ReadFile(fsys, "conf/config.json")
+	require.ErrorIs(t, err, fs.ErrPermission)
+}
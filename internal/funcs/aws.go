@@ -61,6 +61,12 @@ func (a *Funcs) EC2Dynamic(key string, def ...string) (string, error) {
 	return a.meta.Dynamic(key, def...)
 }
 
+// EC2AccountID -
+func (a *Funcs) EC2AccountID(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.AccountID(def...)
+}
+
 // EC2Tag -
 func (a *Funcs) EC2Tag(tag string, def ...string) (string, error) {
 	a.infoInit.Do(a.initInfo)
@@ -36,6 +36,8 @@ func TestAWSFuncs(t *testing.T) {
 	assert.Equal(t, "", must(af.EC2Meta("foo")))
 	assert.Equal(t, "", must(af.EC2Tag("foo")))
 	assert.Equal(t, "unknown", must(af.EC2Region()))
+	assert.Equal(t, "", must(af.EC2AccountID()))
+	assert.Equal(t, "foo", must(af.EC2AccountID("foo")))
 }
 
 func must(r interface{}, err error) interface{} {
@@ -0,0 +1,82 @@
+package funcs
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hairyhenderson/gomplate/v4/azure"
+)
+
+// CreateAzureFuncs -
+func CreateAzureFuncs(ctx context.Context) map[string]interface{} {
+	ns := &AzureFuncs{
+		ctx:       ctx,
+		azureopts: azure.GetClientOptions(ctx),
+	}
+	return map[string]interface{}{
+		"azure": func() interface{} { return ns },
+	}
+}
+
+// AzureFuncs -
+type AzureFuncs struct {
+	ctx context.Context
+
+	meta      *azure.MetaClient
+	azureopts azure.ClientOptions
+	metaInit  sync.Once
+}
+
+// Meta -
+func (a *AzureFuncs) Meta(key string, def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.Meta(key, def...)
+}
+
+// Name -
+func (a *AzureFuncs) Name(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.Name(def...)
+}
+
+// ResourceGroup -
+func (a *AzureFuncs) ResourceGroup(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.ResourceGroup(def...)
+}
+
+// SubscriptionID -
+func (a *AzureFuncs) SubscriptionID(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.SubscriptionID(def...)
+}
+
+// Location -
+func (a *AzureFuncs) Location(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.Location(def...)
+}
+
+// Tags -
+func (a *AzureFuncs) Tags() (map[string]string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.Tags()
+}
+
+// Tag -
+func (a *AzureFuncs) Tag(name string, def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.Tag(name, def...)
+}
+
+// Token -
+func (a *AzureFuncs) Token(resource string, clientID ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.Token(resource, clientID...)
+}
+
+func (a *AzureFuncs) initMeta() {
+	if a.meta == nil {
+		a.meta = azure.NewMetaClient(a.ctx, a.azureopts)
+	}
+}
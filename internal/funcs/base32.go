@@ -0,0 +1,42 @@
+package funcs
+
+import (
+	"context"
+	"encoding/base32"
+	"strings"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+)
+
+// CreateBase32Funcs -
+func CreateBase32Funcs(ctx context.Context) map[string]interface{} {
+	f := map[string]interface{}{}
+
+	ns := &Base32Funcs{ctx}
+	f["base32"] = func() interface{} { return ns }
+
+	return f
+}
+
+// Base32Funcs -
+type Base32Funcs struct {
+	ctx context.Context
+}
+
+// Encode -
+func (Base32Funcs) Encode(in interface{}) (string, error) {
+	return base32.StdEncoding.EncodeToString(toBytes(in)), nil
+}
+
+// Decode -
+func (f Base32Funcs) Decode(in interface{}) (string, error) {
+	out, err := f.DecodeBytes(in)
+	return string(out), err
+}
+
+// DecodeBytes -
+func (Base32Funcs) DecodeBytes(in interface{}) ([]byte, error) {
+	s := conv.ToString(in)
+	// padding is optional
+	return base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(s, "="))
+}
@@ -0,0 +1,45 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBase32Funcs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateBase32Funcs(ctx)
+			actual := fmap["base32"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*Base32Funcs).ctx)
+		})
+	}
+}
+
+func TestBase32(t *testing.T) {
+	t.Parallel()
+
+	bf := &Base32Funcs{}
+	assert.Equal(t, "MZXW6YTBOI======", must(bf.Encode("foobar")))
+	assert.Equal(t, "AAB74===", must(bf.Encode([]byte{0, 3, 0xfe})))
+
+	assert.Equal(t, "foobar", must(bf.Decode("MZXW6YTBOI======")))
+	assert.Equal(t, "foobar", must(bf.Decode("MZXW6YTBOI")))
+
+	out, err := bf.DecodeBytes("AAB74===")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 3, 0xfe}, out)
+
+	_, err = bf.Decode("not base32!")
+	require.Error(t, err)
+}
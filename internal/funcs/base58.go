@@ -0,0 +1,39 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/base58"
+)
+
+// CreateBase58Funcs -
+func CreateBase58Funcs(ctx context.Context) map[string]interface{} {
+	f := map[string]interface{}{}
+
+	ns := &Base58Funcs{ctx}
+	f["base58"] = func() interface{} { return ns }
+
+	return f
+}
+
+// Base58Funcs -
+type Base58Funcs struct {
+	ctx context.Context
+}
+
+// Encode -
+func (Base58Funcs) Encode(in interface{}) (string, error) {
+	return base58.Encode(toBytes(in)), nil
+}
+
+// Decode -
+func (Base58Funcs) Decode(in interface{}) (string, error) {
+	out, err := base58.Decode(conv.ToString(in))
+	return string(out), err
+}
+
+// DecodeBytes -
+func (Base58Funcs) DecodeBytes(in interface{}) ([]byte, error) {
+	return base58.Decode(conv.ToString(in))
+}
@@ -0,0 +1,44 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBase58Funcs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateBase58Funcs(ctx)
+			actual := fmap["base58"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*Base58Funcs).ctx)
+		})
+	}
+}
+
+func TestBase58(t *testing.T) {
+	t.Parallel()
+
+	bf := &Base58Funcs{}
+	assert.Equal(t, "2NEpo7TZRRrLZSi2U", must(bf.Encode("Hello World!")))
+	assert.Equal(t, "11233QC4", must(bf.Encode([]byte{0, 0, 0x28, 0x7f, 0xb4, 0xcd})))
+
+	assert.Equal(t, "Hello World!", must(bf.Decode("2NEpo7TZRRrLZSi2U")))
+
+	out, err := bf.DecodeBytes("11233QC4")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0x28, 0x7f, 0xb4, 0xcd}, out)
+
+	_, err = bf.Decode("0OIl")
+	require.Error(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"reflect"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
+	iconv "github.com/hairyhenderson/gomplate/v4/internal/conv"
 	"github.com/hairyhenderson/gomplate/v4/internal/deprecated"
 	"github.com/hairyhenderson/gomplate/v4/internal/texttemplate"
 
@@ -20,7 +21,9 @@ func CreateCollFuncs(ctx context.Context) map[string]interface{} {
 	f["coll"] = func() interface{} { return ns }
 
 	f["has"] = ns.Has
+	f["dig"] = ns.Dig
 	f["slice"] = ns.deprecatedSlice
+	f["list"] = ns.Slice
 	f["dict"] = ns.Dict
 	f["keys"] = ns.Keys
 	f["values"] = ns.Values
@@ -30,8 +33,12 @@ func CreateCollFuncs(ctx context.Context) map[string]interface{} {
 	f["reverse"] = ns.Reverse
 	f["merge"] = ns.Merge
 	f["sort"] = ns.Sort
+	f["sortBy"] = ns.SortBy
+	f["groupBy"] = ns.GroupBy
+	f["chunk"] = ns.Chunk
 	f["jsonpath"] = ns.JSONPath
 	f["jq"] = ns.JQ
+	f["jmespath"] = ns.JMESPath
 	f["flatten"] = ns.Flatten
 	return f
 }
@@ -63,6 +70,11 @@ func (CollFuncs) Has(in interface{}, key string) bool {
 	return coll.Has(in, key)
 }
 
+// Dig -
+func (CollFuncs) Dig(path string, def interface{}, in interface{}) interface{} {
+	return coll.Dig(path, def, in)
+}
+
 // Index returns the result of indexing the last argument with the preceding
 // index keys. This is similar to the `index` built-in template function, but
 // the arguments are ordered differently for pipeline compatibility. Also, this
@@ -85,13 +97,35 @@ func (CollFuncs) Dict(in ...interface{}) (map[string]interface{}, error) {
 }
 
 // Keys -
-func (CollFuncs) Keys(in ...map[string]interface{}) ([]string, error) {
-	return coll.Keys(in...)
+func (CollFuncs) Keys(in ...interface{}) ([]string, error) {
+	maps, err := stringMaps(in)
+	if err != nil {
+		return nil, err
+	}
+	return coll.Keys(maps...)
 }
 
 // Values -
-func (CollFuncs) Values(in ...map[string]interface{}) ([]interface{}, error) {
-	return coll.Values(in...)
+func (CollFuncs) Values(in ...interface{}) ([]interface{}, error) {
+	maps, err := stringMaps(in)
+	if err != nil {
+		return nil, err
+	}
+	return coll.Values(maps...)
+}
+
+// stringMaps converts the arguments to map[string]interface{}, so that maps of
+// other types (such as those parsed from YAML) can be used
+func stringMaps(in []interface{}) ([]map[string]interface{}, error) {
+	maps := make([]map[string]interface{}, len(in))
+	for i, m := range in {
+		sm, err := iconv.StringMap(m)
+		if err != nil {
+			return nil, err
+		}
+		maps[i] = sm
+	}
+	return maps, nil
 }
 
 // Append -
@@ -138,6 +172,21 @@ func (CollFuncs) Sort(args ...interface{}) ([]interface{}, error) {
 	return coll.Sort(key, list)
 }
 
+// SortBy -
+func (CollFuncs) SortBy(path string, list interface{}) ([]interface{}, error) {
+	return coll.SortBy(path, list)
+}
+
+// GroupBy -
+func (CollFuncs) GroupBy(path string, list interface{}) (map[string]interface{}, error) {
+	return coll.GroupBy(path, list)
+}
+
+// Chunk -
+func (CollFuncs) Chunk(size interface{}, list interface{}) ([]interface{}, error) {
+	return coll.Chunk(conv.ToInt(size), list)
+}
+
 // JSONPath -
 func (CollFuncs) JSONPath(p string, in interface{}) (interface{}, error) {
 	return coll.JSONPath(p, in)
@@ -148,6 +197,11 @@ func (f *CollFuncs) JQ(jqExpr string, in interface{}) (interface{}, error) {
 	return coll.JQ(f.ctx, jqExpr, in)
 }
 
+// JMESPath -
+func (CollFuncs) JMESPath(expr string, in interface{}) (interface{}, error) {
+	return coll.JMESPath(expr, in)
+}
+
 // Flatten -
 func (CollFuncs) Flatten(args ...interface{}) ([]interface{}, error) {
 	if len(args) == 0 || len(args) > 2 {
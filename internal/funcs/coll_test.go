@@ -27,6 +27,16 @@ func TestCreateCollFuncs(t *testing.T) {
 	}
 }
 
+func TestListAlias(t *testing.T) {
+	t.Parallel()
+
+	fmap := CreateCollFuncs(context.Background())
+	list := fmap["list"].(func(...interface{}) []interface{})
+
+	assert.Equal(t, []interface{}{"a", 1, true}, list("a", 1, true))
+	assert.Empty(t, list())
+}
+
 func TestFlatten(t *testing.T) {
 	t.Parallel()
 
@@ -47,6 +57,29 @@ func TestFlatten(t *testing.T) {
 	assert.EqualValues(t, []interface{}{1, []int{2}, 3}, out)
 }
 
+func TestKeysValues(t *testing.T) {
+	t.Parallel()
+
+	c := CollFuncs{}
+
+	_, err := c.Keys()
+	assert.Error(t, err)
+
+	_, err = c.Keys(42)
+	assert.Error(t, err)
+
+	keys, err := c.Keys(map[string]string{"b": "2", "a": "1"}, map[interface{}]interface{}{"d": 4, "c": 3})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, keys)
+
+	_, err = c.Values([]string{"a"})
+	assert.Error(t, err)
+
+	values, err := c.Values(map[string]string{"b": "2", "a": "1"}, map[interface{}]interface{}{"d": 4, "c": 3})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"1", "2", 3, 4}, values)
+}
+
 func TestPick(t *testing.T) {
 	t.Parallel()
 
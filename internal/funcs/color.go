@@ -0,0 +1,106 @@
+package funcs
+
+import (
+	"context"
+	"os"
+	"regexp"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/env"
+	"golang.org/x/term"
+)
+
+// CreateColorFuncs -
+func CreateColorFuncs(ctx context.Context) map[string]interface{} {
+	f := map[string]interface{}{}
+
+	ns := &ColorFuncs{ctx, colorEnabled(os.Stdout)}
+	f["color"] = func() interface{} { return ns }
+
+	return f
+}
+
+// colorEnabled - colour output is disabled when NO_COLOR is set (see
+// https://no-color.org), forced on when FORCE_COLOR is set (useful when
+// rendering to a file like /etc/motd), and otherwise only enabled when out is
+// a terminal.
+func colorEnabled(out *os.File) bool {
+	if env.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := env.Getenv("FORCE_COLOR"); force != "" {
+		return force != "0" && force != "false"
+	}
+	return out != nil && term.IsTerminal(int(out.Fd()))
+}
+
+// ColorFuncs -
+type ColorFuncs struct {
+	ctx     context.Context
+	enabled bool
+}
+
+// ansiSeq matches ANSI SGR (colour/style) escape sequences
+var ansiSeq = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// wrap surrounds the input with the given SGR start and end codes. The end
+// code only resets the attribute that was set, so styles can be nested.
+func (f *ColorFuncs) wrap(start, end string, in interface{}) string {
+	s := conv.ToString(in)
+	if !f.enabled {
+		return s
+	}
+	return "\x1b[" + start + "m" + s + "\x1b[" + end + "m"
+}
+
+// Enabled - whether colour output is enabled
+func (f *ColorFuncs) Enabled() bool {
+	return f.enabled
+}
+
+// Strip - remove all ANSI colour and style sequences from the input
+func (f *ColorFuncs) Strip(in interface{}) string {
+	return ansiSeq.ReplaceAllString(conv.ToString(in), "")
+}
+
+// Black -
+func (f *ColorFuncs) Black(in interface{}) string { return f.wrap("30", "39", in) }
+
+// Red -
+func (f *ColorFuncs) Red(in interface{}) string { return f.wrap("31", "39", in) }
+
+// Green -
+func (f *ColorFuncs) Green(in interface{}) string { return f.wrap("32", "39", in) }
+
+// Yellow -
+func (f *ColorFuncs) Yellow(in interface{}) string { return f.wrap("33", "39", in) }
+
+// Blue -
+func (f *ColorFuncs) Blue(in interface{}) string { return f.wrap("34", "39", in) }
+
+// Magenta -
+func (f *ColorFuncs) Magenta(in interface{}) string { return f.wrap("35", "39", in) }
+
+// Cyan -
+func (f *ColorFuncs) Cyan(in interface{}) string { return f.wrap("36", "39", in) }
+
+// White -
+func (f *ColorFuncs) White(in interface{}) string { return f.wrap("37", "39", in) }
+
+// Gray -
+func (f *ColorFuncs) Gray(in interface{}) string { return f.wrap("90", "39", in) }
+
+// Bold -
+func (f *ColorFuncs) Bold(in interface{}) string { return f.wrap("1", "22", in) }
+
+// Dim -
+func (f *ColorFuncs) Dim(in interface{}) string { return f.wrap("2", "22", in) }
+
+// Italic -
+func (f *ColorFuncs) Italic(in interface{}) string { return f.wrap("3", "23", in) }
+
+// Underline -
+func (f *ColorFuncs) Underline(in interface{}) string { return f.wrap("4", "24", in) }
+
+// Reverse -
+func (f *ColorFuncs) Reverse(in interface{}) string { return f.wrap("7", "27", in) }
@@ -0,0 +1,67 @@
+package funcs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateColorFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateColorFuncs(ctx)
+			actual := fmap["color"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*ColorFuncs).ctx)
+		})
+	}
+}
+
+func TestColor(t *testing.T) {
+	t.Parallel()
+
+	c := &ColorFuncs{ctx: context.Background(), enabled: true}
+	assert.True(t, c.Enabled())
+	assert.Equal(t, "\x1b[31mhello\x1b[39m", c.Red("hello"))
+	assert.Equal(t, "\x1b[32m42\x1b[39m", c.Green(42))
+	assert.Equal(t, "\x1b[1m\x1b[36mhi\x1b[39m\x1b[22m", c.Bold(c.Cyan("hi")))
+	assert.Equal(t, "\x1b[4mu\x1b[24m", c.Underline("u"))
+	assert.Equal(t, "hi", c.Strip(c.Bold(c.Cyan("hi"))))
+
+	c = &ColorFuncs{ctx: context.Background(), enabled: false}
+	assert.False(t, c.Enabled())
+	assert.Equal(t, "hello", c.Red("hello"))
+	assert.Equal(t, "hi", c.Bold(c.Cyan("hi")))
+}
+
+func TestColorEnabled(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+	assert.False(t, colorEnabled(f))
+	assert.False(t, colorEnabled(nil))
+
+	t.Setenv("FORCE_COLOR", "1")
+	assert.True(t, colorEnabled(f))
+
+	t.Setenv("FORCE_COLOR", "0")
+	assert.False(t, colorEnabled(f))
+
+	t.Setenv("FORCE_COLOR", "1")
+	t.Setenv("NO_COLOR", "1")
+	assert.False(t, colorEnabled(f))
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
 	"github.com/hairyhenderson/gomplate/v4/internal/deprecated"
+	"golang.org/x/net/idna"
 )
 
 // CreateConvFuncs -
@@ -76,6 +77,40 @@ func (ConvFuncs) URL(s interface{}) (*url.URL, error) {
 	return url.Parse(conv.ToString(s))
 }
 
+// URLQueryEscape - escape the input so it can be safely placed inside a URL
+// query
+func (ConvFuncs) URLQueryEscape(in interface{}) string {
+	return url.QueryEscape(conv.ToString(in))
+}
+
+// URLQueryUnescape - the inverse of URLQueryEscape
+func (ConvFuncs) URLQueryUnescape(in interface{}) (string, error) {
+	return url.QueryUnescape(conv.ToString(in))
+}
+
+// URLPathEscape - escape the input so it can be safely placed inside a URL
+// path segment
+func (ConvFuncs) URLPathEscape(in interface{}) string {
+	return url.PathEscape(conv.ToString(in))
+}
+
+// URLPathUnescape - the inverse of URLPathEscape
+func (ConvFuncs) URLPathUnescape(in interface{}) (string, error) {
+	return url.PathUnescape(conv.ToString(in))
+}
+
+// ToPunycode - convert an internationalized domain name to its ASCII
+// (punycode) form, e.g. "bücher.example" to "xn--bcher-kva.example"
+func (ConvFuncs) ToPunycode(in interface{}) (string, error) {
+	return idna.ToASCII(conv.ToString(in))
+}
+
+// FromPunycode - convert a punycode-encoded domain name to its Unicode form,
+// e.g. "xn--bcher-kva.example" to "bücher.example"
+func (ConvFuncs) FromPunycode(in interface{}) (string, error) {
+	return idna.ToUnicode(conv.ToString(in))
+}
+
 // ToInt64 -
 func (ConvFuncs) ToInt64(in interface{}) int64 {
 	return conv.ToInt64(in)
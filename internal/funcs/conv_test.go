@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateConvFuncs(t *testing.T) {
@@ -61,3 +62,42 @@ func TestDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestURLEscaping(t *testing.T) {
+	t.Parallel()
+
+	c := &ConvFuncs{}
+	assert.Equal(t, "a+b%26c%3Dd%2F%C3%A9", c.URLQueryEscape("a b&c=d/é"))
+	assert.Equal(t, "a%20b&c=d%2F%C3%A9", c.URLPathEscape("a b&c=d/é"))
+
+	out, err := c.URLQueryUnescape("a+b%26c%3Dd%2F%C3%A9")
+	require.NoError(t, err)
+	assert.Equal(t, "a b&c=d/é", out)
+
+	out, err = c.URLPathUnescape("a%20b+c")
+	require.NoError(t, err)
+	assert.Equal(t, "a b+c", out)
+
+	_, err = c.URLQueryUnescape("%zz")
+	require.Error(t, err)
+}
+
+func TestPunycode(t *testing.T) {
+	t.Parallel()
+
+	c := &ConvFuncs{}
+	out, err := c.ToPunycode("bücher.example")
+	require.NoError(t, err)
+	assert.Equal(t, "xn--bcher-kva.example", out)
+
+	out, err = c.ToPunycode("example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", out)
+
+	out, err = c.FromPunycode("xn--bcher-kva.example")
+	require.NoError(t, err)
+	assert.Equal(t, "bücher.example", out)
+
+	_, err = c.FromPunycode("xn--zz")
+	require.Error(t, err)
+}
@@ -9,21 +9,33 @@ import (
 	"crypto/sha512"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/pem"
 	"fmt"
+	"io/fs"
+	"math"
 	"strings"
+	"time"
 	"unicode/utf8"
 
+	osfs "github.com/hack-pad/hackpadfs/os"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
 	"github.com/hairyhenderson/gomplate/v4/crypto"
+	iconv "github.com/hairyhenderson/gomplate/v4/internal/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 )
 
 // CreateCryptoFuncs -
 func CreateCryptoFuncs(ctx context.Context) map[string]interface{} {
 	f := map[string]interface{}{}
 
-	ns := &CryptoFuncs{ctx}
+	fsys, err := datafs.FSysForPath(ctx, "/")
+	if err != nil {
+		fsys = datafs.WrapWdFS(osfs.NewFS())
+	}
+
+	ns := &CryptoFuncs{ctx: ctx, fs: fsys}
 
 	f["crypto"] = func() interface{} { return ns }
 	return f
@@ -32,6 +44,7 @@ func CreateCryptoFuncs(ctx context.Context) map[string]interface{} {
 // CryptoFuncs -
 type CryptoFuncs struct {
 	ctx context.Context
+	fs  fs.FS
 }
 
 // PBKDF2 - Run the Password-Based Key Derivation Function #2 as defined in
@@ -164,6 +177,98 @@ func (CryptoFuncs) SHA512_256Bytes(input interface{}) ([]byte, error) {
 	return out, nil
 }
 
+// Blake2b - compute a BLAKE2b checksum, optionally with a digest size in bits
+// (the default is 512), and output it as a hexadecimal string
+func (f CryptoFuncs) Blake2b(args ...interface{}) (string, error) {
+	out, err := f.Blake2bBytes(args...)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%02x", out), nil
+}
+
+// Blake2bBytes -
+func (CryptoFuncs) Blake2bBytes(args ...interface{}) ([]byte, error) {
+	bits := 512
+	var input interface{}
+	switch len(args) {
+	case 1:
+		input = args[0]
+	case 2:
+		bits = conv.ToInt(args[0])
+		input = args[1]
+	default:
+		return nil, fmt.Errorf("wrong number of args: wanted 1 or 2, got %d", len(args))
+	}
+	return crypto.Blake2b(bits, toBytes(input))
+}
+
+// HMAC - compute a keyed-hash message authentication code with the named hash
+// function, and output it as a hexadecimal string
+func (f CryptoFuncs) HMAC(hashFunc string, key, input interface{}) (string, error) {
+	out, err := f.HMACBytes(hashFunc, key, input)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%02x", out), nil
+}
+
+// HMACBytes -
+func (CryptoFuncs) HMACBytes(hashFunc string, key, input interface{}) ([]byte, error) {
+	h, err := crypto.StrToHash(hashFunc)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.HMAC(h, toBytes(key), toBytes(input))
+}
+
+// HashFile - compute a checksum of the file at the given path with the named
+// hash function, and output it as a hexadecimal string
+func (f CryptoFuncs) HashFile(hashFunc string, path interface{}) (string, error) {
+	h, err := crypto.StrToHash(hashFunc)
+	if err != nil {
+		return "", err
+	}
+
+	p := conv.ToString(path)
+	file, err := f.fs.Open(p)
+	if err != nil {
+		return "", fmt.Errorf("open %s: %w", p, err)
+	}
+	defer file.Close()
+
+	out, err := crypto.HashReader(h, file)
+	if err != nil {
+		return "", fmt.Errorf("hash %s: %w", p, err)
+	}
+	return fmt.Sprintf("%02x", out), nil
+}
+
+// Argon2 - hash the input with Argon2id, optionally with the time cost,
+// memory cost (in KiB), and parallelism
+func (CryptoFuncs) Argon2(args ...interface{}) (string, error) {
+	var (
+		input    interface{}
+		timeCost uint32 = crypto.Argon2DefaultTime
+		memory   uint32 = crypto.Argon2DefaultMemory
+		threads  uint8  = crypto.Argon2DefaultThreads
+	)
+	switch len(args) {
+	case 1:
+		input = args[0]
+	case 4:
+		t, m, p := conv.ToInt64(args[0]), conv.ToInt64(args[1]), conv.ToInt64(args[2])
+		if t < 1 || t > math.MaxUint32 || m < 1 || m > math.MaxUint32 || p < 1 || p > math.MaxUint8 {
+			return "", fmt.Errorf("invalid Argon2 parameters: time=%d, memory=%d, threads=%d", t, m, p)
+		}
+		timeCost, memory, threads = uint32(t), uint32(m), uint8(p)
+		input = args[3]
+	default:
+		return "", fmt.Errorf("wrong number of args: wanted 1 or 4, got %d", len(args))
+	}
+	return crypto.Argon2id(toBytes(input), timeCost, memory, threads)
+}
+
 // Bcrypt -
 func (CryptoFuncs) Bcrypt(args ...interface{}) (string, error) {
 	input := ""
@@ -274,6 +379,200 @@ func (f *CryptoFuncs) ECDSADerivePublicKey(privateKey string) (string, error) {
 	return string(out), err
 }
 
+// GenerateCA - generate a self-signed CA certificate and key
+// Experimental!
+func (f *CryptoFuncs) GenerateCA(opts interface{}) (*crypto.Certificate, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return nil, err
+	}
+
+	o, err := parseCertOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.GenerateCA(o)
+}
+
+// GenerateCert - generate a certificate and key, either self-signed or signed
+// by the given CA
+// Experimental!
+func (f *CryptoFuncs) GenerateCert(opts interface{}, ca ...interface{}) (*crypto.Certificate, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return nil, err
+	}
+
+	o, err := parseCertOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var c *crypto.Certificate
+	switch len(ca) {
+	case 0:
+	case 1:
+		c, err = toCertificate(ca[0])
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(ca)+1)
+	}
+
+	return crypto.GenerateCert(o, c)
+}
+
+// defaultCertTTL is the default validity period for generated certificates
+const defaultCertTTL = 365 * 24 * time.Hour
+
+func parseCertOptions(in interface{}) (crypto.CertOptions, error) {
+	opts := crypto.CertOptions{TTL: defaultCertTTL}
+
+	m, err := iconv.StringMap(in)
+	if err != nil {
+		return opts, fmt.Errorf("certificate options: %w", err)
+	}
+
+	for k, v := range m {
+		switch k {
+		case "commonName":
+			opts.CommonName = conv.ToString(v)
+		case "organization":
+			opts.Organization = toStrings(v)
+		case "sans":
+			opts.SANs = toStrings(v)
+		case "ttl":
+			switch d := v.(type) {
+			case time.Duration:
+				opts.TTL = d
+			default:
+				opts.TTL, err = time.ParseDuration(conv.ToString(v))
+				if err != nil {
+					return opts, fmt.Errorf("certificate options: invalid ttl: %w", err)
+				}
+			}
+		default:
+			return opts, fmt.Errorf("certificate options: unknown option %q", k)
+		}
+	}
+
+	return opts, nil
+}
+
+// toStrings converts a single value or a list to a slice of strings
+func toStrings(v interface{}) []string {
+	l, err := iconv.InterfaceSlice(v)
+	if err != nil {
+		return []string{conv.ToString(v)}
+	}
+
+	out := make([]string, len(l))
+	for i, s := range l {
+		out[i] = conv.ToString(s)
+	}
+	return out
+}
+
+// toCertificate converts the CA argument, which may be the output of
+// GenerateCA, or a map with PEM-encoded "Cert" and "Key" values
+func toCertificate(in interface{}) (*crypto.Certificate, error) {
+	if c, ok := in.(*crypto.Certificate); ok {
+		return c, nil
+	}
+
+	m, err := iconv.StringMap(in)
+	if err != nil {
+		return nil, fmt.Errorf("CA must be a certificate or a map with Cert and Key values, got %T", in)
+	}
+
+	c := &crypto.Certificate{}
+	for k, v := range m {
+		switch strings.ToLower(k) {
+		case "cert":
+			c.Cert = conv.ToString(v)
+		case "key":
+			c.Key = conv.ToString(v)
+		}
+	}
+	return c, nil
+}
+
+// JWTSign - create a JSON Web Token with the given claims, signed with the
+// given algorithm and key
+// Experimental!
+func (f *CryptoFuncs) JWTSign(alg string, key interface{}, claims interface{}) (string, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return "", err
+	}
+
+	c, err := iconv.StringMap(claims)
+	if err != nil {
+		return "", fmt.Errorf("JWT claims: %w", err)
+	}
+
+	return crypto.JWTSign(alg, toBytes(key), c)
+}
+
+// JWTVerify - verify a JSON Web Token's signature with the given algorithm and
+// key, and return its claims
+// Experimental!
+func (f *CryptoFuncs) JWTVerify(alg string, key interface{}, token interface{}) (map[string]interface{}, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return nil, err
+	}
+
+	return crypto.JWTVerify(alg, toBytes(key), conv.ToString(token))
+}
+
+// JWTDecode - decode a JSON Web Token's header and claims, without verifying
+// its signature
+// Experimental!
+func (f *CryptoFuncs) JWTDecode(token interface{}) (map[string]interface{}, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return nil, err
+	}
+
+	header, claims, err := crypto.JWTDecode(conv.ToString(token))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"header": header, "claims": claims}, nil
+}
+
+// PEMEncode - encode the input as a PEM block of the given type, optionally
+// with a map of headers
+func (CryptoFuncs) PEMEncode(blockType string, args ...interface{}) (string, error) {
+	var (
+		headers map[string]string
+		in      interface{}
+	)
+	switch len(args) {
+	case 1:
+		in = args[0]
+	case 2:
+		h, err := iconv.StringMap(args[0])
+		if err != nil {
+			return "", fmt.Errorf("PEM headers: %w", err)
+		}
+		headers = make(map[string]string, len(h))
+		for k, v := range h {
+			headers[k] = conv.ToString(v)
+		}
+		in = args[1]
+	default:
+		return "", fmt.Errorf("wrong number of args: want 2 or 3, got %d", len(args)+1)
+	}
+
+	out, err := crypto.PEMEncode(blockType, headers, toBytes(in))
+	return string(out), err
+}
+
+// PEMDecode - decode the first PEM block in the input
+func (CryptoFuncs) PEMDecode(in interface{}) (*pem.Block, error) {
+	return crypto.PEMDecode(toBytes(in))
+}
+
 // Ed25519GenerateKey -
 // Experimental!
 func (f *CryptoFuncs) Ed25519GenerateKey() (string, error) {
@@ -320,6 +619,151 @@ func (f *CryptoFuncs) Ed25519DerivePublicKey(privateKey string) (string, error)
 	return string(out), err
 }
 
+// SSHGenerateKey -
+// Experimental!
+func (f *CryptoFuncs) SSHGenerateKey(args ...interface{}) (string, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return "", err
+	}
+
+	keyType := "ed25519"
+	bits := 0
+	switch len(args) {
+	case 0:
+	case 1:
+		keyType = conv.ToString(args[0])
+	case 2:
+		keyType = conv.ToString(args[0])
+		bits = conv.ToInt(args[1])
+	default:
+		return "", fmt.Errorf("wrong number of args: want 0, 1, or 2, got %d", len(args))
+	}
+
+	out, err := crypto.SSHGenerateKey(keyType, bits)
+	return string(out), err
+}
+
+// SSHPublicKey -
+// Experimental!
+func (f *CryptoFuncs) SSHPublicKey(args ...interface{}) (string, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return "", err
+	}
+
+	var comment string
+	var key interface{}
+	switch len(args) {
+	case 1:
+		key = args[0]
+	case 2:
+		comment = conv.ToString(args[0])
+		key = args[1]
+	default:
+		return "", fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
+	}
+
+	return crypto.SSHPublicKey(toBytes(key), comment)
+}
+
+// SSHAuthorizedKey -
+// Experimental!
+func (f *CryptoFuncs) SSHAuthorizedKey(options, key interface{}) (string, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return "", err
+	}
+
+	return crypto.SSHAuthorizedKey(toStrings(options), toBytes(key))
+}
+
+// SSHKnownHosts -
+// Experimental!
+func (f *CryptoFuncs) SSHKnownHosts(hosts, key interface{}) (string, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return "", err
+	}
+
+	return crypto.SSHKnownHosts(toStrings(hosts), toBytes(key), false)
+}
+
+// SSHKnownHostsHashed -
+// Experimental!
+func (f *CryptoFuncs) SSHKnownHostsHashed(hosts, key interface{}) (string, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return "", err
+	}
+
+	return crypto.SSHKnownHosts(toStrings(hosts), toBytes(key), true)
+}
+
+// SSHFingerprint -
+// Experimental!
+func (f *CryptoFuncs) SSHFingerprint(key interface{}) (string, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return "", err
+	}
+
+	return crypto.SSHFingerprint(toBytes(key))
+}
+
+// AgeGenerateKey -
+// Experimental!
+func (f *CryptoFuncs) AgeGenerateKey() (string, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return "", err
+	}
+
+	return crypto.AgeGenerateKey()
+}
+
+// AgeRecipient -
+// Experimental!
+func (f *CryptoFuncs) AgeRecipient(identity string) (string, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return "", err
+	}
+
+	return crypto.AgeRecipient(identity)
+}
+
+// AgeEncrypt -
+// Experimental!
+func (f *CryptoFuncs) AgeEncrypt(recipients, in interface{}) (string, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return "", err
+	}
+
+	out, err := crypto.AgeEncrypt(toStrings(recipients), toBytes(in))
+	return string(out), err
+}
+
+// AgeEncryptWithPassphrase -
+// Experimental!
+func (f *CryptoFuncs) AgeEncryptWithPassphrase(passphrase string, in interface{}) (string, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return "", err
+	}
+
+	out, err := crypto.AgeEncryptWithPassphrase(passphrase, crypto.AgeDefaultWorkFactor, toBytes(in))
+	return string(out), err
+}
+
+// AgeDecrypt -
+// Experimental!
+func (f *CryptoFuncs) AgeDecrypt(key string, in interface{}) (string, error) {
+	out, err := f.AgeDecryptBytes(key, in)
+	return string(out), err
+}
+
+// AgeDecryptBytes -
+// Experimental!
+func (f *CryptoFuncs) AgeDecryptBytes(key string, in interface{}) ([]byte, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return nil, err
+	}
+
+	return crypto.AgeDecrypt(key, toBytes(in))
+}
+
 // EncryptAES -
 // Experimental!
 func (f *CryptoFuncs) EncryptAES(key string, args ...interface{}) ([]byte, error) {
@@ -327,7 +771,7 @@ func (f *CryptoFuncs) EncryptAES(key string, args ...interface{}) ([]byte, error
 		return nil, err
 	}
 
-	k, msg, err := parseAESArgs(key, args...)
+	k, msg, err := parseAESArgs([]byte(key), args...)
 	if err != nil {
 		return nil, err
 	}
@@ -353,7 +797,7 @@ func (f *CryptoFuncs) DecryptAESBytes(key string, args ...interface{}) ([]byte,
 		return nil, err
 	}
 
-	k, msg, err := parseAESArgs(key, args...)
+	k, msg, err := parseAESArgs([]byte(key), args...)
 	if err != nil {
 		return nil, err
 	}
@@ -361,7 +805,48 @@ func (f *CryptoFuncs) DecryptAESBytes(key string, args ...interface{}) ([]byte,
 	return crypto.DecryptAESCBC(k, msg)
 }
 
-func parseAESArgs(key string, args ...interface{}) ([]byte, []byte, error) {
+// EncryptAESGCM -
+// Experimental!
+func (f *CryptoFuncs) EncryptAESGCM(key interface{}, args ...interface{}) ([]byte, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return nil, err
+	}
+
+	k, msg, err := parseAESArgs(toBytes(key), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.EncryptAESGCM(k, msg)
+}
+
+// DecryptAESGCM -
+// Experimental!
+func (f *CryptoFuncs) DecryptAESGCM(key interface{}, args ...interface{}) (string, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return "", err
+	}
+
+	out, err := f.DecryptAESGCMBytes(key, args...)
+	return conv.ToString(out), err
+}
+
+// DecryptAESGCMBytes -
+// Experimental!
+func (f *CryptoFuncs) DecryptAESGCMBytes(key interface{}, args ...interface{}) ([]byte, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return nil, err
+	}
+
+	k, msg, err := parseAESArgs(toBytes(key), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.DecryptAESGCM(k, msg)
+}
+
+func parseAESArgs(key []byte, args ...interface{}) ([]byte, []byte, error) {
 	keyBits := 256 // default to AES-256-CBC
 
 	var msg []byte
@@ -377,7 +862,7 @@ func parseAESArgs(key string, args ...interface{}) ([]byte, []byte, error) {
 	}
 
 	k := make([]byte, keyBits/8)
-	copy(k, []byte(key))
+	copy(k, key)
 
 	return k, msg, nil
 }
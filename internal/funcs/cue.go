@@ -0,0 +1,42 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/parsers"
+)
+
+// CreateCUEFuncs -
+func CreateCUEFuncs(ctx context.Context) map[string]interface{} {
+	ns := &CUEFuncs{ctx}
+
+	// "cue" is also the alias for data.CUE, so it returns the namespace only
+	// when called without arguments (as in `cue.Validate`)
+	cue := func(args ...interface{}) (interface{}, error) {
+		switch len(args) {
+		case 0:
+			return ns, nil
+		case 1:
+			return parsers.CUE(conv.ToString(args[0]))
+		default:
+			return nil, fmt.Errorf("wrong number of args: wanted 0 or 1, got %d", len(args))
+		}
+	}
+
+	return map[string]interface{}{
+		"cue": cue,
+	}
+}
+
+// CUEFuncs -
+type CUEFuncs struct {
+	ctx context.Context
+}
+
+// Validate - validates the input against the CUE schema, returning the input
+// unchanged when it's valid.
+func (CUEFuncs) Validate(schema string, in interface{}) (interface{}, error) {
+	return parsers.CUEValidate(schema, in)
+}
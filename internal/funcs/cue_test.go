@@ -0,0 +1,58 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCUEFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateCUEFuncs(ctx)
+			actual := fmap["cue"].(func(...interface{}) (interface{}, error))
+
+			ns, err := actual()
+			require.NoError(t, err)
+			assert.Equal(t, ctx, ns.(*CUEFuncs).ctx)
+		})
+	}
+}
+
+func TestCUEAlias(t *testing.T) {
+	t.Parallel()
+
+	cue := CreateCUEFuncs(context.Background())["cue"].(func(...interface{}) (interface{}, error))
+
+	// with an argument, cue parses the input, like data.CUE
+	out, err := cue(`foo: "bar"`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"foo": "bar"}, out)
+
+	_, err = cue("a", "b")
+	require.Error(t, err)
+}
+
+func TestCUEValidate(t *testing.T) {
+	t.Parallel()
+
+	ns := CUEFuncs{}
+
+	in := map[string]interface{}{"port": 8080}
+
+	out, err := ns.Validate(`port: int & >0 & <65536`, in)
+	require.NoError(t, err)
+	assert.Equal(t, in, out)
+
+	_, err = ns.Validate(`port: int & <65536`, map[string]interface{}{"port": 70000})
+	require.Error(t, err)
+}
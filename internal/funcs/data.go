@@ -2,9 +2,11 @@ package funcs
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
 	"github.com/hairyhenderson/gomplate/v4/data"
+	iconv "github.com/hairyhenderson/gomplate/v4/internal/conv"
 	"github.com/hairyhenderson/gomplate/v4/internal/parsers"
 )
 
@@ -20,6 +22,7 @@ func CreateDataFuncs(ctx context.Context, d *data.Data) map[string]interface{} {
 	f["defineDatasource"] = d.DefineDatasource
 	f["include"] = d.Include
 	f["listDatasources"] = d.ListDatasources
+	f["datasourceURLs"] = d.DatasourceURLs
 
 	ns := &DataFuncs{ctx}
 
@@ -27,19 +30,31 @@ func CreateDataFuncs(ctx context.Context, d *data.Data) map[string]interface{} {
 
 	f["json"] = ns.JSON
 	f["jsonArray"] = ns.JSONArray
+	f["ndjson"] = ns.NDJSON
 	f["yaml"] = ns.YAML
 	f["yamlArray"] = ns.YAMLArray
+	f["yamlDocs"] = ns.YAMLDocs
 	f["toml"] = ns.TOML
 	f["csv"] = ns.CSV
 	f["csvByRow"] = ns.CSVByRow
 	f["csvByColumn"] = ns.CSVByColumn
-	f["cue"] = ns.CUE
+	f["xml"] = ns.XML
+	f["xpath"] = ns.XPath
+	f["ini"] = ns.INI
+	f["properties"] = ns.Properties
+	f["msgpack"] = ns.Msgpack
+	f["cbor"] = ns.CBOR
 	f["toJSON"] = ns.ToJSON
 	f["toJSONPretty"] = ns.ToJSONPretty
 	f["toYAML"] = ns.ToYAML
 	f["toTOML"] = ns.ToTOML
 	f["toCSV"] = ns.ToCSV
 	f["toCUE"] = ns.ToCUE
+	f["toINI"] = ns.ToINI
+	f["toProperties"] = ns.ToProperties
+	f["toEnv"] = ns.ToEnv
+	f["toMsgpack"] = ns.ToMsgpack
+	f["toCBOR"] = ns.ToCBOR
 	return f
 }
 
@@ -58,6 +73,11 @@ func (f *DataFuncs) JSONArray(in interface{}) ([]interface{}, error) {
 	return parsers.JSONArray(conv.ToString(in))
 }
 
+// NDJSON -
+func (f *DataFuncs) NDJSON(in interface{}) ([]interface{}, error) {
+	return parsers.NDJSON(conv.ToString(in))
+}
+
 // YAML -
 func (f *DataFuncs) YAML(in interface{}) (map[string]interface{}, error) {
 	return parsers.YAML(conv.ToString(in))
@@ -68,6 +88,11 @@ func (f *DataFuncs) YAMLArray(in interface{}) ([]interface{}, error) {
 	return parsers.YAMLArray(conv.ToString(in))
 }
 
+// YAMLDocs -
+func (f *DataFuncs) YAMLDocs(in interface{}) ([]interface{}, error) {
+	return parsers.YAMLDocs(conv.ToString(in))
+}
+
 // TOML -
 func (f *DataFuncs) TOML(in interface{}) (interface{}, error) {
 	return parsers.TOML(conv.ToString(in))
@@ -93,6 +118,36 @@ func (f *DataFuncs) CUE(in interface{}) (interface{}, error) {
 	return parsers.CUE(conv.ToString(in))
 }
 
+// XML -
+func (f *DataFuncs) XML(in interface{}) (map[string]interface{}, error) {
+	return parsers.XML(conv.ToString(in))
+}
+
+// XPath -
+func (f *DataFuncs) XPath(expr string, in interface{}) ([]string, error) {
+	return parsers.XPath(expr, conv.ToString(in))
+}
+
+// INI -
+func (f *DataFuncs) INI(in interface{}) (map[string]interface{}, error) {
+	return parsers.INI(conv.ToString(in))
+}
+
+// Properties -
+func (f *DataFuncs) Properties(in interface{}) (map[string]interface{}, error) {
+	return parsers.Properties(conv.ToString(in))
+}
+
+// Msgpack -
+func (f *DataFuncs) Msgpack(in interface{}) (interface{}, error) {
+	return parsers.Msgpack(conv.ToString(in))
+}
+
+// CBOR -
+func (f *DataFuncs) CBOR(in interface{}) (interface{}, error) {
+	return parsers.CBOR(conv.ToString(in))
+}
+
 // ToCSV -
 func (f *DataFuncs) ToCSV(args ...interface{}) (string, error) {
 	return parsers.ToCSV(args...)
@@ -104,21 +159,140 @@ func (f *DataFuncs) ToCUE(in interface{}) (string, error) {
 }
 
 // ToJSON -
-func (f *DataFuncs) ToJSON(in interface{}) (string, error) {
-	return parsers.ToJSON(in)
+func (f *DataFuncs) ToJSON(args ...interface{}) (string, error) {
+	opts, in, err := jsonArgs("data.ToJSON", args)
+	if err != nil {
+		return "", err
+	}
+	return parsers.ToJSONWithOptions(in, opts)
 }
 
 // ToJSONPretty -
-func (f *DataFuncs) ToJSONPretty(indent string, in interface{}) (string, error) {
-	return parsers.ToJSONPretty(indent, in)
+func (f *DataFuncs) ToJSONPretty(indent string, args ...interface{}) (string, error) {
+	opts, in, err := jsonArgs("data.ToJSONPretty", args)
+	if err != nil {
+		return "", err
+	}
+	if opts.Indent == "" {
+		opts.Indent = indent
+	}
+	return parsers.ToJSONWithOptions(in, opts)
+}
+
+// jsonArgs parses the arguments to the ToJSON functions - the object to
+// marshal, optionally preceded by a map of options
+func jsonArgs(fn string, args []interface{}) (opts parsers.JSONOptions, in interface{}, err error) {
+	switch len(args) {
+	case 1:
+		return opts, args[0], nil
+	case 2:
+		opts, err = parseJSONOptions(args[0])
+		if err != nil {
+			return opts, nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		return opts, args[1], nil
+	default:
+		return opts, nil, fmt.Errorf("wrong number of args for %s: want 1 or 2 - got %d", fn, len(args))
+	}
+}
+
+func parseJSONOptions(in interface{}) (parsers.JSONOptions, error) {
+	opts := parsers.JSONOptions{}
+
+	m, err := iconv.StringMap(in)
+	if err != nil {
+		return opts, fmt.Errorf("JSON options: %w", err)
+	}
+
+	for k, v := range m {
+		switch k {
+		case "indent":
+			opts.Indent = conv.ToString(v)
+		case "escapeHTML":
+			opts.NoEscapeHTML = !conv.ToBool(v)
+		default:
+			return opts, fmt.Errorf("JSON options: unknown option %q", k)
+		}
+	}
+
+	return opts, nil
 }
 
 // ToYAML -
-func (f *DataFuncs) ToYAML(in interface{}) (string, error) {
-	return parsers.ToYAML(in)
+func (f *DataFuncs) ToYAML(args ...interface{}) (string, error) {
+	var in interface{}
+	opts := parsers.YAMLOptions{}
+
+	switch len(args) {
+	case 1:
+		in = args[0]
+	case 2:
+		var err error
+		opts, err = parseYAMLOptions(args[0])
+		if err != nil {
+			return "", fmt.Errorf("data.ToYAML: %w", err)
+		}
+		in = args[1]
+	default:
+		return "", fmt.Errorf("wrong number of args for data.ToYAML: want 1 or 2 - got %d", len(args))
+	}
+
+	return parsers.ToYAMLWithOptions(in, opts)
+}
+
+func parseYAMLOptions(in interface{}) (parsers.YAMLOptions, error) {
+	opts := parsers.YAMLOptions{}
+
+	m, err := iconv.StringMap(in)
+	if err != nil {
+		return opts, fmt.Errorf("YAML options: %w", err)
+	}
+
+	for k, v := range m {
+		switch k {
+		case "indent":
+			opts.Indent = conv.ToInt(v)
+		case "blockStyle":
+			opts.BlockStyle = conv.ToString(v)
+		default:
+			return opts, fmt.Errorf("YAML options: unknown option %q", k)
+		}
+	}
+
+	return opts, nil
+}
+
+// YAMLPath -
+func (f *DataFuncs) YAMLPath(p string, in interface{}) (interface{}, error) {
+	return parsers.YAMLPath(p, in)
 }
 
 // ToTOML -
 func (f *DataFuncs) ToTOML(in interface{}) (string, error) {
 	return parsers.ToTOML(in)
 }
+
+// ToINI -
+func (f *DataFuncs) ToINI(in interface{}) (string, error) {
+	return parsers.ToINI(in)
+}
+
+// ToProperties -
+func (f *DataFuncs) ToProperties(in interface{}) (string, error) {
+	return parsers.ToProperties(in)
+}
+
+// ToEnv -
+func (f *DataFuncs) ToEnv(in interface{}) (string, error) {
+	return parsers.ToEnv(in)
+}
+
+// ToMsgpack -
+func (f *DataFuncs) ToMsgpack(in interface{}) (string, error) {
+	return parsers.ToMsgpack(in)
+}
+
+// ToCBOR -
+func (f *DataFuncs) ToCBOR(in interface{}) (string, error) {
+	return parsers.ToCBOR(in)
+}
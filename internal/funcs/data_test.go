@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateDataFuncs(t *testing.T) {
@@ -24,3 +25,74 @@ func TestCreateDataFuncs(t *testing.T) {
 		})
 	}
 }
+
+func TestToJSONOptions(t *testing.T) {
+	t.Parallel()
+
+	d := &DataFuncs{ctx: context.Background()}
+	in := map[string]interface{}{"b": "<a href=\"x\">&</a>", "a": 1}
+
+	out, err := d.ToJSON(in)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1,"b":"\u003ca href=\"x\"\u003e\u0026\u003c/a\u003e"}`, out)
+
+	out, err = d.ToJSON(map[string]interface{}{"escapeHTML": false}, in)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1,"b":"<a href=\"x\">&</a>"}`, out)
+
+	out, err = d.ToJSON(map[string]interface{}{"indent": " "}, in)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n \"a\": 1,\n \"b\": \"\\u003ca href=\\\"x\\\"\\u003e\\u0026\\u003c/a\\u003e\"\n}", out)
+
+	out, err = d.ToJSONPretty("  ", map[string]interface{}{"escapeHTML": "false"}, in)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"a\": 1,\n  \"b\": \"<a href=\\\"x\\\">&</a>\"\n}", out)
+
+	_, err = d.ToJSON(map[string]interface{}{"bogus": true}, in)
+	assert.ErrorContains(t, err, `unknown option "bogus"`)
+
+	_, err = d.ToJSON("not a map", in)
+	assert.Error(t, err)
+
+	_, err = d.ToJSON()
+	assert.Error(t, err)
+
+	_, err = d.ToJSONPretty("  ", 1, 2, 3)
+	assert.Error(t, err)
+}
+
+func TestToYAMLOptions(t *testing.T) {
+	t.Parallel()
+
+	d := &DataFuncs{ctx: context.Background()}
+	in := map[string]interface{}{"spec": map[string]interface{}{"cmd": "a\nb"}}
+
+	out, err := d.ToYAML(in)
+	require.NoError(t, err)
+	assert.Equal(t, "spec:\n  cmd: |-\n    a\n    b\n", out)
+
+	out, err = d.ToYAML(map[string]interface{}{"indent": 4, "blockStyle": "quoted"}, in)
+	require.NoError(t, err)
+	assert.Equal(t, "spec:\n    cmd: \"a\\nb\"\n", out)
+
+	_, err = d.ToYAML(map[string]interface{}{"bogus": true}, in)
+	assert.ErrorContains(t, err, `unknown option "bogus"`)
+
+	_, err = d.ToYAML(map[string]interface{}{"blockStyle": "fancy"}, in)
+	assert.Error(t, err)
+
+	_, err = d.ToYAML()
+	assert.Error(t, err)
+}
+
+func TestYAMLPath(t *testing.T) {
+	t.Parallel()
+
+	d := &DataFuncs{ctx: context.Background()}
+	out, err := d.YAMLPath(".spec.replicas", "spec:\n  replicas: 3\n")
+	require.NoError(t, err)
+	assert.Equal(t, 3, out)
+
+	_, err = d.YAMLPath(".spec.missing", "spec:\n  replicas: 3\n")
+	assert.Error(t, err)
+}
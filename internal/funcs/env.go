@@ -23,8 +23,8 @@ type EnvFuncs struct {
 }
 
 // Getenv -
-func (EnvFuncs) Getenv(key interface{}, def ...string) string {
-	return env.Getenv(conv.ToString(key), def...)
+func (EnvFuncs) Getenv(key interface{}, def ...interface{}) string {
+	return env.Getenv(conv.ToString(key), conv.ToStrings(def...)...)
 }
 
 // ExpandEnv -
@@ -3,10 +3,12 @@ package funcs
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateEnvFuncs(t *testing.T) {
@@ -34,4 +36,26 @@ func TestEnvGetenv(t *testing.T) {
 	assert.Equal(t, expected, ef.Getenv("USER"))
 
 	assert.Equal(t, "foo", ef.Getenv("bogusenvvar", "foo"))
+	assert.Equal(t, "8080", ef.Getenv("bogusenvvar", 8080))
+	assert.Equal(t, "", ef.Getenv("bogusenvvar"))
+}
+
+func TestEnvGetenvFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	secret := filepath.Join(tmpDir, "secret")
+	require.NoError(t, os.WriteFile(secret, []byte("hunter2\n"), 0o600))
+
+	ef := &EnvFuncs{}
+
+	t.Setenv("GOMPLATE_TEST_SECRET_FILE", secret)
+	assert.Equal(t, "hunter2", ef.Getenv("GOMPLATE_TEST_SECRET", "default"))
+
+	// the plain variable takes precedence over the _FILE variant
+	t.Setenv("GOMPLATE_TEST_SECRET", "plain")
+	assert.Equal(t, "plain", ef.Getenv("GOMPLATE_TEST_SECRET", "default"))
+
+	// an unreadable file falls back to the default
+	t.Setenv("GOMPLATE_TEST_SECRET", "")
+	t.Setenv("GOMPLATE_TEST_SECRET_FILE", filepath.Join(tmpDir, "missing"))
+	assert.Equal(t, "default", ef.Getenv("GOMPLATE_TEST_SECRET", "default"))
 }
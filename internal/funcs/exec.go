@@ -0,0 +1,71 @@
+package funcs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+)
+
+// defaultExecTimeout - how long commands run by exec may take, unless
+// overridden with --exec-timeout
+const defaultExecTimeout = 5 * time.Second
+
+// CreateExecFuncs -
+func CreateExecFuncs(ctx context.Context) map[string]interface{} {
+	ns := &ExecFuncs{ctx}
+
+	return map[string]interface{}{
+		"exec": ns.Exec,
+	}
+}
+
+// ExecFuncs -
+type ExecFuncs struct {
+	ctx context.Context
+}
+
+// Exec - run a local command, returning its standard output with trailing
+// newlines removed. This is disabled unless --allow-exec is set.
+func (f ExecFuncs) Exec(name string, args ...interface{}) (string, error) {
+	allowed, timeout := config.ExecAllowed(f.ctx)
+	if !allowed {
+		return "", fmt.Errorf("exec is disabled, and must be enabled with --allow-exec")
+	}
+
+	if timeout == 0 {
+		timeout = defaultExecTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(f.ctx, timeout)
+	defer cancel()
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	//nolint:gosec
+	cmd := exec.CommandContext(ctx, name, conv.ToStrings(args...)...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("command %q timed out after %v", name, timeout)
+	}
+
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("command %q failed: %w: %s", name, err, msg)
+		}
+
+		return "", fmt.Errorf("command %q failed: %w", name, err)
+	}
+
+	return strings.TrimRight(stdout.String(), "\r\n"), nil
+}
@@ -0,0 +1,65 @@
+//go:build !windows
+// +build !windows
+
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateExecFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateExecFuncs(ctx)
+			actual := fmap["exec"].(func(string, ...interface{}) (string, error))
+
+			_, err := actual("echo")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestExec(t *testing.T) {
+	t.Parallel()
+
+	// disabled by default
+	f := ExecFuncs{ctx: context.Background()}
+	_, err := f.Exec("echo", "hello")
+	assert.EqualError(t, err, "exec is disabled, and must be enabled with --allow-exec")
+
+	f = ExecFuncs{ctx: config.SetExecAllowed(context.Background(), 0)}
+
+	out, err := f.Exec("echo", "hello", 42)
+	require.NoError(t, err)
+	assert.Equal(t, "hello 42", out)
+
+	out, err = f.Exec("printf", `one\ntwo\n\n`)
+	require.NoError(t, err)
+	assert.Equal(t, "one\ntwo", out)
+
+	_, err = f.Exec("sh", "-c", "echo oops >&2; exit 3")
+	assert.EqualError(t, err, `command "sh" failed: exit status 3: oops`)
+
+	_, err = f.Exec("sh", "-c", "exit 1")
+	assert.EqualError(t, err, `command "sh" failed: exit status 1`)
+
+	_, err = f.Exec("bogus-command-that-does-not-exist")
+	assert.Error(t, err)
+
+	f = ExecFuncs{ctx: config.SetExecAllowed(context.Background(), 50*time.Millisecond)}
+	_, err = f.Exec("sleep", "5")
+	assert.EqualError(t, err, `command "sleep" timed out after 50ms`)
+}
@@ -91,6 +91,23 @@ func (f *FileFuncs) Walk(path interface{}) ([]string, error) {
 	return files, err
 }
 
+// Glob - return the names of all files matching the pattern, in lexical
+// order. The pattern syntax is the same as in path.Match, and is applied to
+// each path component, so "*" doesn't match across directories.
+func (f *FileFuncs) Glob(pattern interface{}) ([]string, error) {
+	matches, err := fs.Glob(f.fs, filepath.ToSlash(conv.ToString(pattern)))
+	if err != nil {
+		return nil, err
+	}
+
+	// as with Walk, use the OS-specific separator
+	for i, m := range matches {
+		matches[i] = filepath.FromSlash(m)
+	}
+
+	return matches, nil
+}
+
 // Write -
 func (f *FileFuncs) Write(path interface{}, data interface{}) (s string, err error) {
 	type byteser interface{ Bytes() []byte }
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"testing"
 	"testing/fstest"
+	"time"
 
 	"github.com/hack-pad/hackpadfs"
 	osfs "github.com/hack-pad/hackpadfs/os"
@@ -48,6 +49,77 @@ func TestFileExists(t *testing.T) {
 	assert.False(t, ff.Exists("/tmp/bar"))
 }
 
+func TestFileRead(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"tmp":     &fstest.MapFile{Mode: fs.ModeDir | 0o777},
+		"tmp/foo": &fstest.MapFile{Data: []byte("hello\nworld\n")},
+	}
+	ff := &FileFuncs{fs: datafs.WrapWdFS(fsys)}
+
+	out, err := ff.Read("/tmp/foo")
+	require.NoError(t, err)
+	assert.Equal(t, "hello\nworld\n", out)
+
+	_, err = ff.Read("/tmp/bar")
+	require.Error(t, err)
+}
+
+func TestFileStat(t *testing.T) {
+	t.Parallel()
+
+	mtime := time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC)
+	fsys := fstest.MapFS{
+		"tmp":     &fstest.MapFile{Mode: fs.ModeDir | 0o755},
+		"tmp/foo": &fstest.MapFile{Data: []byte("foo"), Mode: 0o640, ModTime: mtime},
+	}
+	ff := &FileFuncs{fs: datafs.WrapWdFS(fsys)}
+
+	fi, err := ff.Stat("/tmp/foo")
+	require.NoError(t, err)
+	assert.Equal(t, "foo", fi.Name())
+	assert.Equal(t, int64(3), fi.Size())
+	assert.Equal(t, fs.FileMode(0o640), fi.Mode())
+	assert.True(t, mtime.Equal(fi.ModTime()))
+	assert.False(t, fi.IsDir())
+
+	_, err = ff.Stat("/tmp/bar")
+	require.Error(t, err)
+}
+
+func TestFileGlob(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"tmp":              &fstest.MapFile{Mode: fs.ModeDir | 0o777},
+		"tmp/ca.pem":       &fstest.MapFile{Data: []byte("ca")},
+		"tmp/server.pem":   &fstest.MapFile{Data: []byte("server")},
+		"tmp/server.key":   &fstest.MapFile{Data: []byte("key")},
+		"tmp/sub":          &fstest.MapFile{Mode: fs.ModeDir | 0o777},
+		"tmp/sub/ext.pem":  &fstest.MapFile{Data: []byte("ext")},
+		"tmp/sub/ext2.pem": &fstest.MapFile{Data: []byte("ext2")},
+	}
+	ff := &FileFuncs{fs: datafs.WrapWdFS(fsys)}
+
+	sep := string(filepath.Separator)
+
+	matches, err := ff.Glob("/tmp/*.pem")
+	require.NoError(t, err)
+	assert.Equal(t, []string{sep + filepath.Join("tmp", "ca.pem"), sep + filepath.Join("tmp", "server.pem")}, matches)
+
+	matches, err = ff.Glob("/tmp/*/ext?.pem")
+	require.NoError(t, err)
+	assert.Equal(t, []string{sep + filepath.Join("tmp", "sub", "ext2.pem")}, matches)
+
+	matches, err = ff.Glob("/tmp/*.crt")
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+
+	_, err = ff.Glob("/tmp/[")
+	require.Error(t, err)
+}
+
 func TestFileIsDir(t *testing.T) {
 	t.Parallel()
 
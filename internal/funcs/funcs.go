@@ -13,3 +13,10 @@ func checkExperimental(ctx context.Context) error {
 	}
 	return nil
 }
+
+func checkNetworkEnabled(ctx context.Context) error {
+	if config.NetworkDisabled(ctx) {
+		return fmt.Errorf("network lookups are disabled (see --disable-network)")
+	}
+	return nil
+}
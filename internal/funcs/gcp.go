@@ -22,15 +22,55 @@ func CreateGCPFuncs(ctx context.Context) map[string]interface{} {
 type GcpFuncs struct {
 	ctx context.Context
 
-	meta    *gcp.MetaClient
-	gcpopts gcp.ClientOptions
+	meta     *gcp.MetaClient
+	gcpopts  gcp.ClientOptions
+	metaInit sync.Once
 }
 
 // Meta -
 func (a *GcpFuncs) Meta(key string, def ...string) (string, error) {
-	a.meta = sync.OnceValue[*gcp.MetaClient](func() *gcp.MetaClient {
-		return gcp.NewMetaClient(a.ctx, a.gcpopts)
-	})()
-
+	a.metaInit.Do(a.initMeta)
 	return a.meta.Meta(key, def...)
 }
+
+// ProjectMeta -
+func (a *GcpFuncs) ProjectMeta(key string, def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.ProjectMeta(key, def...)
+}
+
+// ProjectID -
+func (a *GcpFuncs) ProjectID(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.ProjectID(def...)
+}
+
+// Zone -
+func (a *GcpFuncs) Zone(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.Zone(def...)
+}
+
+// Region -
+func (a *GcpFuncs) Region(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.Region(def...)
+}
+
+// Attribute -
+func (a *GcpFuncs) Attribute(key string, def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.Attribute(key, def...)
+}
+
+// ProjectAttribute -
+func (a *GcpFuncs) ProjectAttribute(key string, def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.ProjectAttribute(key, def...)
+}
+
+func (a *GcpFuncs) initMeta() {
+	if a.meta == nil {
+		a.meta = gcp.NewMetaClient(a.ctx, a.gcpopts)
+	}
+}
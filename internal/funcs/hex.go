@@ -0,0 +1,39 @@
+package funcs
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+)
+
+// CreateHexFuncs -
+func CreateHexFuncs(ctx context.Context) map[string]interface{} {
+	f := map[string]interface{}{}
+
+	ns := &HexFuncs{ctx}
+	f["hex"] = func() interface{} { return ns }
+
+	return f
+}
+
+// HexFuncs -
+type HexFuncs struct {
+	ctx context.Context
+}
+
+// Encode -
+func (HexFuncs) Encode(in interface{}) (string, error) {
+	return hex.EncodeToString(toBytes(in)), nil
+}
+
+// Decode -
+func (f HexFuncs) Decode(in interface{}) (string, error) {
+	out, err := f.DecodeBytes(in)
+	return string(out), err
+}
+
+// DecodeBytes -
+func (HexFuncs) DecodeBytes(in interface{}) ([]byte, error) {
+	return hex.DecodeString(conv.ToString(in))
+}
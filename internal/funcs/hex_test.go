@@ -0,0 +1,48 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateHexFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateHexFuncs(ctx)
+			actual := fmap["hex"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*HexFuncs).ctx)
+		})
+	}
+}
+
+func TestHex(t *testing.T) {
+	t.Parallel()
+
+	hf := &HexFuncs{}
+	assert.Equal(t, "666f6f626172", must(hf.Encode("foobar")))
+	assert.Equal(t, "0003fe", must(hf.Encode([]byte{0, 3, 0xfe})))
+
+	assert.Equal(t, "foobar", must(hf.Decode("666f6f626172")))
+	assert.Equal(t, "foobar", must(hf.Decode("666F6F626172")))
+
+	out, err := hf.DecodeBytes("0003fe")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 3, 0xfe}, out)
+
+	_, err = hf.Decode("abc")
+	require.Error(t, err)
+
+	_, err = hf.Decode("zz")
+	require.Error(t, err)
+}
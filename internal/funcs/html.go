@@ -0,0 +1,37 @@
+package funcs
+
+import (
+	"context"
+	"text/template"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/sanitize"
+)
+
+// CreateHTMLFuncs -
+func CreateHTMLFuncs(ctx context.Context) map[string]interface{} {
+	f := map[string]interface{}{}
+
+	ns := &HTMLFuncs{ctx}
+
+	// html is also a text/template builtin, so keep the builtin's escaping
+	// behaviour when it's called with arguments
+	f["html"] = func(args ...interface{}) interface{} {
+		if len(args) == 0 {
+			return ns
+		}
+		return template.HTMLEscaper(args...)
+	}
+
+	return f
+}
+
+// HTMLFuncs -
+type HTMLFuncs struct {
+	ctx context.Context
+}
+
+// Sanitize -
+func (HTMLFuncs) Sanitize(in interface{}) string {
+	return sanitize.HTML(conv.ToString(in))
+}
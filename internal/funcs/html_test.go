@@ -0,0 +1,35 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateHTMLFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateHTMLFuncs(ctx)
+			actual := fmap["html"].(func(...interface{}) interface{})
+
+			assert.Equal(t, ctx, actual().(*HTMLFuncs).ctx)
+			assert.Equal(t, "&lt;b&gt;", actual("<b>"))
+		})
+	}
+}
+
+func TestHTMLSanitize(t *testing.T) {
+	t.Parallel()
+
+	h := HTMLFuncs{ctx: context.Background()}
+	assert.Equal(t, "<p>hi x</p>",
+		h.Sanitize(`<p style="color: red">hi<script>alert(1)</script> <a href="javascript:void(0)">x</a></p>`))
+}
@@ -0,0 +1,90 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/data"
+	"github.com/hairyhenderson/gomplate/v4/internal/i18n"
+)
+
+// CreateI18nFuncs -
+func CreateI18nFuncs(ctx context.Context, d *data.Data) map[string]interface{} {
+	f := map[string]interface{}{}
+
+	ns := &I18nFuncs{ctx: ctx, data: d, catalogs: map[string]*i18n.Catalog{}}
+	f["i18n"] = func() interface{} { return ns }
+
+	return f
+}
+
+// I18nFuncs -
+type I18nFuncs struct {
+	ctx  context.Context
+	data *data.Data
+
+	// the catalog used by T and N, set with Use
+	active   *i18n.Catalog
+	catalogs map[string]*i18n.Catalog
+	mu       sync.RWMutex
+}
+
+// Catalog - load (and cache) the translation catalog from the named datasource
+func (f *I18nFuncs) Catalog(alias string) (*i18n.Catalog, error) {
+	f.mu.RLock()
+	c, ok := f.catalogs[alias]
+	f.mu.RUnlock()
+	if ok {
+		return c, nil
+	}
+
+	if f.data == nil {
+		return nil, fmt.Errorf("i18n.Catalog: no datasources available")
+	}
+	in, err := f.data.Include(alias)
+	if err != nil {
+		return nil, fmt.Errorf("i18n.Catalog: %w", err)
+	}
+	c, err = i18n.Parse(in)
+	if err != nil {
+		return nil, fmt.Errorf("i18n.Catalog: failed to parse catalog %q: %w", alias, err)
+	}
+
+	f.mu.Lock()
+	f.catalogs[alias] = c
+	f.mu.Unlock()
+
+	return c, nil
+}
+
+// Use - set the catalog used by T and N to the one in the named datasource
+func (f *I18nFuncs) Use(alias string) (string, error) {
+	c, err := f.Catalog(alias)
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	f.active = c
+	f.mu.Unlock()
+
+	return "", nil
+}
+
+func (f *I18nFuncs) activeCatalog() *i18n.Catalog {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.active
+}
+
+// T -
+func (f *I18nFuncs) T(key interface{}, args ...interface{}) string {
+	return f.activeCatalog().T(conv.ToString(key), args...)
+}
+
+// N -
+func (f *I18nFuncs) N(key interface{}, n interface{}, args ...interface{}) string {
+	return f.activeCatalog().N(conv.ToString(key), conv.ToInt(n), args...)
+}
@@ -0,0 +1,82 @@
+package funcs
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hairyhenderson/gomplate/v4/data"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateI18nFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateI18nFuncs(ctx, nil)
+			actual := fmap["i18n"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*I18nFuncs).ctx)
+		})
+	}
+}
+
+func TestI18n(t *testing.T) {
+	t.Parallel()
+
+	fsys := datafs.WrapWdFS(fstest.MapFS{
+		"tmp/fr.json":  &fstest.MapFile{Data: []byte(`{"hello": "Bonjour, %s !", "files": ["%d fichier", "%d fichiers"]}`)},
+		"tmp/de.po":    &fstest.MapFile{Data: []byte("msgid \"hello\"\nmsgstr \"Hallo, %s!\"\n")},
+		"tmp/bad.json": &fstest.MapFile{Data: []byte(`{"hello": 42}`)},
+	})
+	ctx := datafs.ContextWithFSProvider(context.Background(), datafs.WrappedFSProvider(fsys, "file", ""))
+
+	d := &data.Data{
+		Ctx: ctx,
+		Sources: map[string]config.DataSource{
+			"fr":  {URL: &url.URL{Scheme: "file", Path: "/tmp/fr.json"}},
+			"de":  {URL: &url.URL{Scheme: "file", Path: "/tmp/de.po"}},
+			"bad": {URL: &url.URL{Scheme: "file", Path: "/tmp/bad.json"}},
+		},
+	}
+	f := CreateI18nFuncs(ctx, d)["i18n"].(func() interface{})().(*I18nFuncs)
+
+	// with no catalog, the key is used
+	assert.Equal(t, "hello, world", f.T("hello, %s", "world"))
+	assert.Equal(t, "2 files", f.N("%d files", 2))
+
+	out, err := f.Use("fr")
+	require.NoError(t, err)
+	assert.Empty(t, out)
+	assert.Equal(t, "Bonjour, Marie !", f.T("hello", "Marie"))
+	assert.Equal(t, "1 fichier", f.N("files", 1))
+	assert.Equal(t, "3 fichiers", f.N("files", "3"))
+	assert.Equal(t, "missing", f.T("missing"))
+
+	_, err = f.Use("de")
+	require.NoError(t, err)
+	assert.Equal(t, "Hallo, Welt!", f.T("hello", "Welt"))
+
+	c, err := f.Catalog("fr")
+	require.NoError(t, err)
+	assert.Equal(t, "Bonjour, Paul !", c.T("hello", "Paul"))
+
+	_, err = f.Use("bad")
+	assert.Error(t, err)
+
+	_, err = f.Use("missing")
+	assert.Error(t, err)
+
+	// a failed Use doesn't change the active catalog
+	assert.Equal(t, "Hallo, Welt!", f.T("hello", "Welt"))
+}
@@ -0,0 +1,40 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/jsonschema"
+	"github.com/hairyhenderson/gomplate/v4/internal/parsers"
+)
+
+// CreateJSONSchemaFuncs -
+func CreateJSONSchemaFuncs(ctx context.Context) map[string]interface{} {
+	ns := &JSONSchemaFuncs{ctx}
+	return map[string]interface{}{
+		"jsonschema": func() interface{} { return ns },
+	}
+}
+
+// JSONSchemaFuncs -
+type JSONSchemaFuncs struct {
+	ctx context.Context
+}
+
+// Validate - validates the input against the schema, returning the input
+// unchanged when it's valid. The schema can be given as a JSON or YAML string.
+func (JSONSchemaFuncs) Validate(schema, in interface{}) (interface{}, error) {
+	if s, ok := schema.(string); ok {
+		var err error
+		schema, err = parsers.YAML(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := jsonschema.Validate(schema, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return in, nil
+}
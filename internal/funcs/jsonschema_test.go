@@ -0,0 +1,56 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateJSONSchemaFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateJSONSchemaFuncs(ctx)
+			actual := fmap["jsonschema"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*JSONSchemaFuncs).ctx)
+		})
+	}
+}
+
+func TestJSONSchemaValidate(t *testing.T) {
+	t.Parallel()
+
+	ns := JSONSchemaFuncs{}
+
+	in := map[string]interface{}{"port": 8080}
+
+	out, err := ns.Validate(`{"properties": {"port": {"type": "integer"}}}`, in)
+	require.NoError(t, err)
+	assert.Equal(t, in, out)
+
+	// YAML schemas and pre-parsed schemas work too
+	out, err = ns.Validate("properties:\n  port:\n    type: integer\n", in)
+	require.NoError(t, err)
+	assert.Equal(t, in, out)
+
+	schema := map[string]interface{}{
+		"properties": map[string]interface{}{
+			"port": map[string]interface{}{"type": "string"},
+		},
+	}
+	_, err = ns.Validate(schema, in)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "/port: expected string, but got number")
+
+	_, err = ns.Validate(`{"type": `, in)
+	require.Error(t, err)
+}
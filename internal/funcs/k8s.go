@@ -0,0 +1,164 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"math"
+	"path"
+	"strings"
+
+	osfs "github.com/hack-pad/hackpadfs/os"
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/env"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// defaultServiceAccountPath is where the service account credentials are
+// mounted in pods
+const defaultServiceAccountPath = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// CreateK8sFuncs -
+func CreateK8sFuncs(ctx context.Context) map[string]interface{} {
+	fsys, err := datafs.FSysForPath(ctx, "/")
+	if err != nil {
+		fsys = datafs.WrapWdFS(osfs.NewFS())
+	}
+
+	ns := &K8sFuncs{
+		ctx:    ctx,
+		fs:     fsys,
+		saPath: env.Getenv("K8S_SERVICEACCOUNT_PATH", defaultServiceAccountPath),
+	}
+
+	return map[string]interface{}{
+		"k8s": func() interface{} { return ns },
+	}
+}
+
+// K8sFuncs -
+type K8sFuncs struct {
+	ctx    context.Context
+	fs     fs.FS
+	saPath string
+}
+
+// Token - the pod's service account token
+func (f *K8sFuncs) Token(def ...string) (string, error) {
+	return f.readServiceAccount("token", def)
+}
+
+// Namespace - the namespace the pod is running in
+func (f *K8sFuncs) Namespace(def ...string) (string, error) {
+	return f.readServiceAccount("namespace", def)
+}
+
+func (f *K8sFuncs) readServiceAccount(name string, def []string) (string, error) {
+	b, err := fs.ReadFile(f.fs, path.Join(f.saPath, name))
+	if err != nil {
+		if len(def) > 0 {
+			return def[0], nil
+		}
+		return "", fmt.Errorf("unable to read service account %s: %w", name, err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// ParseQuantity - parse a resource quantity like "500Mi" or "250m". An int64
+// is returned when the value is a whole number, otherwise a float64.
+func (K8sFuncs) ParseQuantity(in interface{}) (interface{}, error) {
+	q, err := resource.ParseQuantity(strings.TrimSpace(conv.ToString(in)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse quantity %q: %w", conv.ToString(in), err)
+	}
+
+	// AsInt64 only succeeds when the quantity is stored as an integer, which
+	// isn't the case for whole numbers like "1.5Gi"
+	if i := q.Value(); q.Cmp(*resource.NewQuantity(i, q.Format)) == 0 {
+		return i, nil
+	}
+
+	out := q.AsApproximateFloat64()
+	if math.IsInf(out, 0) {
+		return nil, fmt.Errorf("quantity %q is out of range", conv.ToString(in))
+	}
+	return out, nil
+}
+
+// LabelSelector - build a label selector from a map of labels, or from
+// key/value pairs
+func (K8sFuncs) LabelSelector(args ...interface{}) (string, error) {
+	m := map[string][]string{}
+
+	switch {
+	case len(args) == 1:
+		in, ok := args[0].(map[string]interface{})
+		if !ok {
+			if sm, sok := args[0].(map[string]string); sok {
+				in = make(map[string]interface{}, len(sm))
+				for k, v := range sm {
+					in[k] = v
+				}
+			} else {
+				return "", fmt.Errorf("k8s.LabelSelector: expected a map, got %T", args[0])
+			}
+		}
+		for k, v := range in {
+			m[k] = labelValues(v)
+		}
+	case len(args)%2 == 0:
+		for i := 0; i < len(args); i += 2 {
+			m[conv.ToString(args[i])] = labelValues(args[i+1])
+		}
+	default:
+		return "", fmt.Errorf("k8s.LabelSelector: expected a map or key/value pairs, got %d arguments", len(args))
+	}
+
+	sel, err := labelSelector(m)
+	if err != nil {
+		return "", fmt.Errorf("k8s.LabelSelector: %w", err)
+	}
+	return sel.String(), nil
+}
+
+// labelSelector builds a selector with an equality-based requirement for each
+// single-valued label, or a set-based ("in") requirement for labels with
+// multiple values. Keys and values are validated by NewRequirement, and the
+// selector's requirements are sorted by key.
+func labelSelector(m map[string][]string) (labels.Selector, error) {
+	sel := labels.NewSelector()
+
+	for k, values := range m {
+		if len(values) == 0 {
+			return nil, fmt.Errorf("no values given for label %q", k)
+		}
+
+		op := selection.Equals
+		if len(values) > 1 {
+			op = selection.In
+		}
+
+		r, err := labels.NewRequirement(k, op, values)
+		if err != nil {
+			return nil, err
+		}
+
+		sel = sel.Add(*r)
+	}
+
+	return sel, nil
+}
+
+func labelValues(v interface{}) []string {
+	switch v := v.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		return conv.ToStrings(v...)
+	default:
+		return []string{conv.ToString(v)}
+	}
+}
@@ -0,0 +1,142 @@
+package funcs
+
+import (
+	"context"
+	"io/fs"
+	"strconv"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateK8sFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateK8sFuncs(ctx)
+			actual := fmap["k8s"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*K8sFuncs).ctx)
+		})
+	}
+}
+
+func TestK8sServiceAccount(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"var/run/secrets/kubernetes.io/serviceaccount":           &fstest.MapFile{Mode: fs.ModeDir | 0o755},
+		"var/run/secrets/kubernetes.io/serviceaccount/token":     &fstest.MapFile{Data: []byte("eyJhbGciOi...")},
+		"var/run/secrets/kubernetes.io/serviceaccount/namespace": &fstest.MapFile{Data: []byte("default\n")},
+	}
+	kf := &K8sFuncs{fs: datafs.WrapWdFS(fsys), saPath: defaultServiceAccountPath}
+
+	out, err := kf.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "eyJhbGciOi...", out)
+
+	out, err = kf.Namespace()
+	require.NoError(t, err)
+	assert.Equal(t, "default", out)
+
+	kf = &K8sFuncs{fs: datafs.WrapWdFS(fstest.MapFS{}), saPath: defaultServiceAccountPath}
+
+	_, err = kf.Namespace()
+	require.Error(t, err)
+
+	out, err = kf.Namespace("local")
+	require.NoError(t, err)
+	assert.Equal(t, "local", out)
+}
+
+func TestK8sParseQuantity(t *testing.T) {
+	t.Parallel()
+
+	kf := &K8sFuncs{}
+
+	out, err := kf.ParseQuantity("500Mi")
+	require.NoError(t, err)
+	assert.Equal(t, int64(524288000), out)
+
+	out, err = kf.ParseQuantity("250m")
+	require.NoError(t, err)
+	assert.InEpsilon(t, 0.25, out, 1e-12)
+
+	out, err = kf.ParseQuantity(2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), out)
+
+	out, err = kf.ParseQuantity("16E")
+	require.NoError(t, err)
+	assert.InEpsilon(t, 1.6e19, out, 1e-12)
+
+	out, err = kf.ParseQuantity(" 1.5Gi ")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3*512*1024*1024), out)
+
+	out, err = kf.ParseQuantity("5E-2")
+	require.NoError(t, err)
+	assert.InEpsilon(t, 0.05, out, 1e-12)
+
+	for _, in := range []string{"lots", "", "1.2.3", "12Xi", "1 Mi", "10mi"} {
+		_, err = kf.ParseQuantity(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestK8sLabelSelector(t *testing.T) {
+	t.Parallel()
+
+	kf := &K8sFuncs{}
+
+	out, err := kf.LabelSelector(map[string]interface{}{
+		"app": "web",
+		"env": []interface{}{"prod", "staging"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "app=web,env in (prod,staging)", out)
+
+	out, err = kf.LabelSelector(map[string]string{"app": "web"})
+	require.NoError(t, err)
+	assert.Equal(t, "app=web", out)
+
+	out, err = kf.LabelSelector("tier", "frontend", "app", "web")
+	require.NoError(t, err)
+	assert.Equal(t, "app=web,tier=frontend", out)
+
+	_, err = kf.LabelSelector("app")
+	require.Error(t, err)
+
+	_, err = kf.LabelSelector("app", "web", "tier")
+	require.Error(t, err)
+
+	out, err = kf.LabelSelector(map[string]interface{}{
+		"app.kubernetes.io/name": "web",
+		"empty":                  "",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "app.kubernetes.io/name=web,empty=", out)
+
+	_, err = kf.LabelSelector("app", "not valid")
+	require.Error(t, err)
+
+	_, err = kf.LabelSelector("app", []string{})
+	require.Error(t, err)
+
+	_, err = kf.LabelSelector("app", "web,tier=x")
+	require.Error(t, err)
+
+	_, err = kf.LabelSelector("-app", "web")
+	require.Error(t, err)
+
+	_, err = kf.LabelSelector("Example.com/app", "web")
+	require.Error(t, err)
+}
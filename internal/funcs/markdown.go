@@ -0,0 +1,28 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/markdown"
+)
+
+// CreateMarkdownFuncs -
+func CreateMarkdownFuncs(ctx context.Context) map[string]interface{} {
+	f := map[string]interface{}{}
+
+	ns := &MarkdownFuncs{ctx}
+	f["markdown"] = func() interface{} { return ns }
+
+	return f
+}
+
+// MarkdownFuncs -
+type MarkdownFuncs struct {
+	ctx context.Context
+}
+
+// ToHTML -
+func (MarkdownFuncs) ToHTML(in interface{}) (string, error) {
+	return markdown.ToHTML(conv.ToString(in))
+}
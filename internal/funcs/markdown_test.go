@@ -0,0 +1,40 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateMarkdownFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateMarkdownFuncs(ctx)
+			actual := fmap["markdown"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*MarkdownFuncs).ctx)
+		})
+	}
+}
+
+func TestMarkdownToHTML(t *testing.T) {
+	t.Parallel()
+
+	m := MarkdownFuncs{ctx: context.Background()}
+	out, err := m.ToHTML("## Hi\n\nsome **bold** <b>text</b>")
+	require.NoError(t, err)
+	assert.Equal(t, "<h2>Hi</h2>\n<p>some <strong>bold</strong> <!-- raw HTML omitted -->text<!-- raw HTML omitted --></p>\n", out)
+
+	out, err = m.ToHTML(42)
+	require.NoError(t, err)
+	assert.Equal(t, "<p>42</p>\n", out)
+}
@@ -2,9 +2,12 @@ package funcs
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	gmath "math"
+	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
 
@@ -36,11 +39,12 @@ type MathFuncs struct {
 // IsInt -
 func (f MathFuncs) IsInt(n interface{}) bool {
 	switch i := n.(type) {
-	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, *big.Int:
 		return true
 	case string:
 		_, err := strconv.ParseInt(i, 0, 64)
-		return err == nil
+		// integers too large for an int64 are still integers
+		return err == nil || errors.Is(err, strconv.ErrRange)
 	}
 	return false
 }
@@ -80,11 +84,10 @@ func (f MathFuncs) IsNum(n interface{}) bool {
 
 // Abs -
 func (f MathFuncs) Abs(n interface{}) interface{} {
-	m := gmath.Abs(conv.ToFloat64(n))
 	if f.IsInt(n) {
-		return conv.ToInt64(m)
+		return bigResult(new(big.Int).Abs(toBigInt(n)))
 	}
-	return m
+	return gmath.Abs(conv.ToFloat64(n))
 }
 
 // Add -
@@ -97,12 +100,11 @@ func (f MathFuncs) Add(n ...interface{}) interface{} {
 		}
 		return x
 	}
-	nums := conv.ToInt64s(n...)
-	var x int64
-	for _, v := range nums {
-		x += v
+	x := new(big.Int)
+	for _, v := range n {
+		x.Add(x, toBigInt(v))
 	}
-	return x
+	return bigResult(x)
 }
 
 // Mul -
@@ -115,12 +117,11 @@ func (f MathFuncs) Mul(n ...interface{}) interface{} {
 		}
 		return x
 	}
-	nums := conv.ToInt64s(n...)
-	x := int64(1)
-	for _, v := range nums {
-		x *= v
+	x := big.NewInt(1)
+	for _, v := range n {
+		x.Mul(x, toBigInt(v))
 	}
-	return x
+	return bigResult(x)
 }
 
 // Sub -
@@ -128,7 +129,7 @@ func (f MathFuncs) Sub(a, b interface{}) interface{} {
 	if f.containsFloat(a, b) {
 		return conv.ToFloat64(a) - conv.ToFloat64(b)
 	}
-	return conv.ToInt64(a) - conv.ToInt64(b)
+	return bigResult(new(big.Int).Sub(toBigInt(a), toBigInt(b)))
 }
 
 // Div -
@@ -148,6 +149,15 @@ func (f MathFuncs) Rem(a, b interface{}) interface{} {
 
 // Pow -
 func (f MathFuncs) Pow(a, b interface{}) interface{} {
+	if f.IsInt(a) && f.IsInt(b) {
+		base, exp := toBigInt(a), toBigInt(b)
+		// compute exactly, unless the result would be unreasonably large
+		if exp.Sign() >= 0 && (base.CmpAbs(big.NewInt(1)) <= 0 ||
+			(exp.IsInt64() && exp.Int64() <= maxPowBits && exp.Int64()*int64(base.BitLen()) <= maxPowBits)) {
+			return bigResult(new(big.Int).Exp(base, exp, nil))
+		}
+	}
+
 	r := gmath.Pow(conv.ToFloat64(a), conv.ToFloat64(b))
 	if f.IsFloat(a) {
 		return r
@@ -188,13 +198,13 @@ func (f MathFuncs) Max(a interface{}, b ...interface{}) (interface{}, error) {
 		}
 		return m, nil
 	}
-	m := conv.ToInt64(a)
-	for _, n := range conv.ToInt64s(b...) {
-		if n > m {
+	m := toBigInt(a)
+	for _, v := range b {
+		if n := toBigInt(v); n.Cmp(m) > 0 {
 			m = n
 		}
 	}
-	return m, nil
+	return bigResult(m), nil
 }
 
 // Min -
@@ -206,13 +216,13 @@ func (f MathFuncs) Min(a interface{}, b ...interface{}) (interface{}, error) {
 		}
 		return m, nil
 	}
-	m := conv.ToInt64(a)
-	for _, n := range conv.ToInt64s(b...) {
-		if n < m {
+	m := toBigInt(a)
+	for _, v := range b {
+		if n := toBigInt(v); n.Cmp(m) < 0 {
 			m = n
 		}
 	}
-	return m, nil
+	return bigResult(m), nil
 }
 
 // Ceil -
@@ -229,3 +239,36 @@ func (f MathFuncs) Floor(n interface{}) interface{} {
 func (f MathFuncs) Round(n interface{}) interface{} {
 	return gmath.Round(conv.ToFloat64(n))
 }
+
+// maxPowBits limits the size (in bits) of exact integer results from Pow
+const maxPowBits = 1 << 20
+
+// toBigInt converts an integer value to a *big.Int, so that integer
+// arithmetic can't overflow. Values that aren't integers are converted with
+// conv.ToInt64.
+func toBigInt(v interface{}) *big.Int {
+	switch i := v.(type) {
+	case *big.Int:
+		if i != nil {
+			return new(big.Int).Set(i)
+		}
+	case uint:
+		return new(big.Int).SetUint64(uint64(i))
+	case uint64:
+		return new(big.Int).SetUint64(i)
+	case string:
+		if b, ok := new(big.Int).SetString(strings.ReplaceAll(i, ",", ""), 0); ok {
+			return b
+		}
+	}
+	return big.NewInt(conv.ToInt64(v))
+}
+
+// bigResult returns the value as an int64 when it fits, or as a *big.Int
+// otherwise
+func bigResult(b *big.Int) interface{} {
+	if b.IsInt64() {
+		return b.Int64()
+	}
+	return b
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	gmath "math"
+	"math/big"
 	"strconv"
 	"testing"
 
@@ -95,6 +96,42 @@ func TestPow(t *testing.T) {
 	m := MathFuncs{}
 	assert.Equal(t, int64(4), m.Pow(2, "2"))
 	assert.Equal(t, 2.25, m.Pow(1.5, 2))
+	assert.Equal(t, int64(0), m.Pow(2, -1))
+	assert.Equal(t, int64(1), m.Pow(-1, "1000000000000000000000"))
+	assert.Equal(t, mustBigInt(t, "18446744073709551616"), m.Pow(2, 64))
+}
+
+func mustBigInt(t *testing.T, s string) *big.Int {
+	t.Helper()
+
+	b, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		t.Fatalf("invalid integer %q", s)
+	}
+	return b
+}
+
+func TestBigIntArithmetic(t *testing.T) {
+	t.Parallel()
+
+	m := MathFuncs{}
+
+	assert.Equal(t, mustBigInt(t, "9223372036854775808"), m.Add(int64(gmath.MaxInt64), 1))
+	assert.Equal(t, int64(gmath.MaxInt64), m.Add("9223372036854775808", -1))
+	assert.Equal(t, mustBigInt(t, "-9223372036854775817"), m.Sub(int64(gmath.MinInt64), 9))
+	assert.Equal(t, int64(1), m.Sub("100000000000000000000", "99999999999999999999"))
+	assert.Equal(t, mustBigInt(t, "18446744073709551614"), m.Mul(int64(gmath.MaxInt64), 2))
+	assert.Equal(t, mustBigInt(t, "36893488147419103230"), m.Mul(uint64(gmath.MaxUint64), 2))
+	assert.Equal(t, mustBigInt(t, "9223372036854775808"), m.Abs(int64(gmath.MinInt64)))
+	assert.Equal(t, 1.5, m.Add(big.NewInt(1), 0.5))
+
+	x, err := m.Max(1, "18446744073709551616", uint64(gmath.MaxUint64))
+	assert.NoError(t, err)
+	assert.Equal(t, mustBigInt(t, "18446744073709551616"), x)
+
+	x, err = m.Min(uint64(gmath.MaxUint64), big.NewInt(-3))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-3), x)
 }
 
 func mustSeq(t *testing.T, n ...interface{}) []int64 {
@@ -149,6 +186,8 @@ func TestIsIntFloatNum(t *testing.T) {
 		{"0xff", true, false},
 		{"-42", true, false},
 		{"-0", true, false},
+		{"18446744073709551616", true, false},
+		{big.NewInt(42), true, false},
 		{"3.14", false, true},
 		{"-3.14", false, true},
 		{"0.00", false, true},
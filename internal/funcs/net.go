@@ -6,6 +6,7 @@ import (
 	"math/big"
 	stdnet "net"
 	"net/netip"
+	"strings"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
 	"github.com/hairyhenderson/gomplate/v4/internal/cidr"
@@ -30,31 +31,49 @@ type NetFuncs struct {
 
 // LookupIP -
 func (f NetFuncs) LookupIP(name interface{}) (string, error) {
+	if err := checkNetworkEnabled(f.ctx); err != nil {
+		return "", err
+	}
 	return net.LookupIP(conv.ToString(name))
 }
 
 // LookupIPs -
 func (f NetFuncs) LookupIPs(name interface{}) ([]string, error) {
+	if err := checkNetworkEnabled(f.ctx); err != nil {
+		return nil, err
+	}
 	return net.LookupIPs(conv.ToString(name))
 }
 
 // LookupCNAME -
 func (f NetFuncs) LookupCNAME(name interface{}) (string, error) {
+	if err := checkNetworkEnabled(f.ctx); err != nil {
+		return "", err
+	}
 	return net.LookupCNAME(conv.ToString(name))
 }
 
 // LookupSRV -
 func (f NetFuncs) LookupSRV(name interface{}) (*stdnet.SRV, error) {
+	if err := checkNetworkEnabled(f.ctx); err != nil {
+		return nil, err
+	}
 	return net.LookupSRV(conv.ToString(name))
 }
 
 // LookupSRVs -
 func (f NetFuncs) LookupSRVs(name interface{}) ([]*stdnet.SRV, error) {
+	if err := checkNetworkEnabled(f.ctx); err != nil {
+		return nil, err
+	}
 	return net.LookupSRVs(conv.ToString(name))
 }
 
 // LookupTXT -
 func (f NetFuncs) LookupTXT(name interface{}) ([]string, error) {
+	if err := checkNetworkEnabled(f.ctx); err != nil {
+		return nil, err
+	}
 	return net.LookupTXT(conv.ToString(name))
 }
 
@@ -135,6 +154,25 @@ func (f *NetFuncs) parseNetipPrefix(prefix interface{}) (netip.Prefix, error) {
 	}
 }
 
+func (f *NetFuncs) parseNetipAddr(addr interface{}) (netip.Addr, error) {
+	switch a := addr.(type) {
+	case netip.Addr:
+		return a, nil
+	case stdnet.IP:
+		ip, ok := netip.AddrFromSlice(a)
+		if !ok {
+			return netip.Addr{}, fmt.Errorf("invalid IP address %v", a)
+		}
+		return ip.Unmap(), nil
+	case netaddr.IP:
+		deprecated.WarnDeprecated(f.ctx,
+			"support for netaddr.IP is deprecated - use net.ParseAddr to produce a netip.Addr instead")
+		return netip.ParseAddr(a.String())
+	default:
+		return netip.ParseAddr(conv.ToString(addr))
+	}
+}
+
 // func (f NetFuncs) ipFromNetIP(n stdnet.IP) netip.Addr {
 // 	ip, _ := netip.AddrFromSlice(n)
 // 	return ip
@@ -282,3 +320,67 @@ func (f *NetFuncs) CIDRSubnetSizes(args ...interface{}) ([]netip.Prefix, error)
 
 	return retValues, nil
 }
+
+// CIDRContains -
+// Experimental!
+func (f *NetFuncs) CIDRContains(prefix interface{}, addr interface{}) (bool, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return false, err
+	}
+
+	network, err := f.parseNetipPrefix(prefix)
+	if err != nil {
+		return false, err
+	}
+
+	network = network.Masked()
+
+	// the second argument may be either an address or a prefix
+	var p netip.Prefix
+	switch a := addr.(type) {
+	case netip.Prefix, *stdnet.IPNet, netaddr.IPPrefix:
+		p, err = f.parseNetipPrefix(a)
+	default:
+		if s := conv.ToString(a); strings.Contains(s, "/") {
+			p, err = netip.ParsePrefix(s)
+		} else {
+			var ip netip.Addr
+			ip, err = f.parseNetipAddr(a)
+			p = netip.PrefixFrom(ip, ip.BitLen())
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return network.Bits() <= p.Bits() && network.Contains(p.Addr()), nil
+}
+
+// CIDRFromNetmask -
+// Experimental!
+func (f *NetFuncs) CIDRFromNetmask(netmask interface{}, addr interface{}) (netip.Prefix, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return netip.Prefix{}, err
+	}
+
+	ip, err := f.parseNetipAddr(addr)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	m, err := f.parseNetipAddr(netmask)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid netmask: %w", err)
+	}
+
+	if m.BitLen() != ip.BitLen() {
+		return netip.Prefix{}, fmt.Errorf("netmask %s does not match the address family of %s", m, ip)
+	}
+
+	bits, size := stdnet.IPMask(m.AsSlice()).Size()
+	if size == 0 {
+		return netip.Prefix{}, fmt.Errorf("invalid netmask %s: must be contiguous", m)
+	}
+
+	return netip.PrefixFrom(ip, bits).Masked(), nil
+}
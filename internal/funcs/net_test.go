@@ -33,10 +33,35 @@ func TestCreateNetFuncs(t *testing.T) {
 func TestNetLookupIP(t *testing.T) {
 	t.Parallel()
 
-	n := NetFuncs{}
+	n := NetFuncs{ctx: context.Background()}
 	assert.Equal(t, "127.0.0.1", must(n.LookupIP("localhost")))
 }
 
+func TestNetLookupDisabled(t *testing.T) {
+	t.Parallel()
+
+	n := NetFuncs{ctx: config.SetNetworkDisabled(context.Background())}
+
+	_, err := n.LookupIP("localhost")
+	require.Error(t, err)
+
+	_, err = n.LookupIPs("localhost")
+	require.Error(t, err)
+
+	_, err = n.LookupCNAME("localhost")
+	require.Error(t, err)
+
+	_, err = n.LookupSRV("_http._tcp.localhost")
+	require.Error(t, err)
+
+	_, err = n.LookupSRVs("_http._tcp.localhost")
+	require.Error(t, err)
+
+	_, err = n.LookupTXT("localhost")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "network lookups are disabled")
+}
+
 func TestParseIP(t *testing.T) {
 	t.Parallel()
 
@@ -185,6 +210,81 @@ func TestCIDRHost(t *testing.T) {
 	ip, err = n.CIDRHost(34, prefix)
 	require.NoError(t, err)
 	assert.Equal(t, "fd00:fd12:3456:7890::22", ip.String())
+
+	// negative numbers count back from the end of the range
+	ip, err = n.CIDRHost(-1, "10.0.0.0/24")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.255", ip.String())
+
+	ip, err = n.CIDRHost(-2, "10.0.0.0/24")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.254", ip.String())
+
+	_, err = n.CIDRHost(256, "10.0.0.0/24")
+	require.Error(t, err)
+}
+
+func TestCIDRContains(t *testing.T) {
+	n := testNetNS()
+
+	testdata := []struct {
+		prefix   interface{}
+		addr     interface{}
+		expected bool
+	}{
+		{"10.0.0.0/8", "10.1.2.3", true},
+		{"10.0.0.0/8", "11.1.2.3", false},
+		{"10.1.2.3/8", "10.255.255.255", true},
+		{"10.0.0.0/8", "10.12.0.0/16", true},
+		{"10.0.0.0/16", "10.0.0.0/8", false},
+		{"10.0.0.0/8", netip.MustParseAddr("10.0.0.1"), true},
+		{"10.0.0.0/8", stdnet.ParseIP("10.0.0.1"), true},
+		{"10.0.0.0/8", netip.MustParsePrefix("10.0.0.0/24"), true},
+		{netip.MustParsePrefix("10.0.0.0/8"), "10.0.0.1", true},
+		{"fd00::/8", "fd12:3456::1", true},
+		{"fd00::/8", "10.0.0.1", false},
+		{"10.0.0.0/8", "::ffff:10.0.0.1", false},
+	}
+
+	for _, d := range testdata {
+		out, err := n.CIDRContains(d.prefix, d.addr)
+		require.NoError(t, err)
+		assert.Equal(t, d.expected, out, "%v contains %v", d.prefix, d.addr)
+	}
+
+	_, err := n.CIDRContains("10.0.0.0", "10.0.0.1")
+	require.Error(t, err)
+
+	_, err = n.CIDRContains("10.0.0.0/8", "bogus")
+	require.Error(t, err)
+
+	_, err = n.CIDRContains("10.0.0.0/8", "10.0.0.0/33")
+	require.Error(t, err)
+}
+
+func TestCIDRFromNetmask(t *testing.T) {
+	n := testNetNS()
+
+	p, err := n.CIDRFromNetmask("255.255.255.0", "192.168.1.10")
+	require.NoError(t, err)
+	assert.Equal(t, "192.168.1.0/24", p.String())
+
+	p, err = n.CIDRFromNetmask(netip.MustParseAddr("255.240.0.0"), stdnet.ParseIP("10.20.30.40"))
+	require.NoError(t, err)
+	assert.Equal(t, "10.16.0.0/12", p.String())
+
+	p, err = n.CIDRFromNetmask("ffff:ffff:ffff:ffff::", "fd00:1:2:3:4::5")
+	require.NoError(t, err)
+	assert.Equal(t, "fd00:1:2:3::/64", p.String())
+
+	_, err = n.CIDRFromNetmask("255.0.255.0", "10.0.0.1")
+	require.Error(t, err)
+
+	_, err = n.CIDRFromNetmask("ffff:ffff::", "10.0.0.1")
+	require.Error(t, err)
+
+	_, err = n.CIDRFromNetmask("255.255.255.0", "bogus")
+	require.Error(t, err)
 }
 
 func TestCIDRNetmask(t *testing.T) {
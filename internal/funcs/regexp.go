@@ -28,22 +28,52 @@ func (ReFuncs) Find(re, input interface{}) (string, error) {
 
 // FindAll -
 func (ReFuncs) FindAll(args ...interface{}) ([]string, error) {
-	re := ""
-	n := 0
-	input := ""
+	re, n, input, err := findAllArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return regexp.FindAll(re, n, input)
+}
+
+// FindSubmatch -
+func (ReFuncs) FindSubmatch(re, input interface{}) ([]string, error) {
+	return regexp.FindSubmatch(conv.ToString(re), conv.ToString(input))
+}
+
+// FindAllSubmatch -
+func (ReFuncs) FindAllSubmatch(args ...interface{}) ([][]string, error) {
+	re, n, input, err := findAllArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return regexp.FindAllSubmatch(re, n, input)
+}
+
+// FindNamed -
+func (ReFuncs) FindNamed(re, input interface{}) (map[string]interface{}, error) {
+	return regexp.FindNamed(conv.ToString(re), conv.ToString(input))
+}
+
+// FindAllNamed -
+func (ReFuncs) FindAllNamed(args ...interface{}) ([]map[string]interface{}, error) {
+	re, n, input, err := findAllArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return regexp.FindAllNamed(re, n, input)
+}
+
+// findAllArgs parses the arguments to the FindAll* functions - the expression,
+// an optional number of matches (default -1, for all matches), and the input
+func findAllArgs(args []interface{}) (re string, n int, input string, err error) {
 	switch len(args) {
 	case 2:
-		n = -1
-		re = conv.ToString(args[0])
-		input = conv.ToString(args[1])
+		return conv.ToString(args[0]), -1, conv.ToString(args[1]), nil
 	case 3:
-		re = conv.ToString(args[0])
-		n = conv.ToInt(args[1])
-		input = conv.ToString(args[2])
+		return conv.ToString(args[0]), conv.ToInt(args[1]), conv.ToString(args[2]), nil
 	default:
-		return nil, fmt.Errorf("wrong number of args: want 2 or 3, got %d", len(args))
+		return "", 0, "", fmt.Errorf("wrong number of args: want 2 or 3, got %d", len(args))
 	}
-	return regexp.FindAll(re, n, input)
 }
 
 // Match -
@@ -144,3 +144,34 @@ func TestReplaceLiteral(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "hello$1 world", r)
 }
+
+func TestFindSubmatchFuncs(t *testing.T) {
+	t.Parallel()
+
+	re := &ReFuncs{}
+	m, err := re.FindSubmatch(`(\w+)@(\w+)`, "bob@example")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bob@example", "bob", "example"}, m)
+
+	all, err := re.FindAllSubmatch(`(\w)(\d)`, "a1 b2 c3")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"a1", "a", "1"}, {"b2", "b", "2"}, {"c3", "c", "3"}}, all)
+
+	all, err = re.FindAllSubmatch(`(\w)(\d)`, 2, "a1 b2 c3")
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	_, err = re.FindAllSubmatch(`(\w)(\d)`)
+	assert.Error(t, err)
+
+	named, err := re.FindNamed(`(?P<user>\w+)@(?P<host>\w+)`, "bob@example")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"user": "bob", "host": "example"}, named)
+
+	allNamed, err := re.FindAllNamed(`(?P<k>\w)(?P<v>\d)`, "a1 b2")
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{"k": "a", "v": "1"}, {"k": "b", "v": "2"}}, allNamed)
+
+	_, err = re.FindAllNamed("", "", "", "")
+	assert.Error(t, err)
+}
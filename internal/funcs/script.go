@@ -0,0 +1,38 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/script"
+)
+
+// CreateScriptFuncs -
+func CreateScriptFuncs(ctx context.Context) map[string]interface{} {
+	ns := &ScriptFuncs{ctx}
+	return map[string]interface{}{
+		"script": func() interface{} { return ns },
+	}
+}
+
+// ScriptFuncs -
+type ScriptFuncs struct {
+	ctx context.Context
+}
+
+// Run - evaluate a Starlark script, with the optional input available to the
+// script as 'ctx'. Functions defined in scripts preloaded with --script can be
+// called.
+func (f *ScriptFuncs) Run(src string, in ...interface{}) (interface{}, error) {
+	var input interface{}
+
+	switch len(in) {
+	case 0:
+	case 1:
+		input = in[0]
+	default:
+		return nil, fmt.Errorf("wrong number of args: wanted 1 or 2, got %d", len(in)+1)
+	}
+
+	return script.Run(f.ctx, src, input)
+}
@@ -0,0 +1,55 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/script"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateScriptFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateScriptFuncs(ctx)
+			actual := fmap["script"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*ScriptFuncs).ctx)
+		})
+	}
+}
+
+func TestScriptRun(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ns := &ScriptFuncs{ctx}
+
+	out, err := ns.Run(`"hello"`)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", out)
+
+	out, err = ns.Run(`sorted(ctx.keys())`, map[string]interface{}{"b": 1, "a": 2})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "b"}, out)
+
+	_, err = ns.Run(`ctx`, 1, 2)
+	require.Error(t, err)
+
+	globals, err := script.Load(ctx, "lib.star", []byte("def greet(name):\n    return 'hello, ' + name\n"), nil)
+	require.NoError(t, err)
+
+	ns = &ScriptFuncs{script.ContextWithGlobals(ctx, globals)}
+
+	out, err = ns.Run(`greet(ctx)`, "world")
+	require.NoError(t, err)
+	assert.Equal(t, "hello, world", out)
+}
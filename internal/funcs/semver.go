@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/hairyhenderson/gomplate/v4/conv"
 )
 
 // CreateSemverFuncs -
@@ -24,17 +25,50 @@ func (SemverFuncs) Semver(version string) (*semver.Version, error) {
 	return semver.NewVersion(version)
 }
 
+// Parse - parse the input as a semantic version. The input can be a string,
+// or an already-parsed version.
+func (SemverFuncs) Parse(in interface{}) (*semver.Version, error) {
+	return toSemver(in)
+}
+
+// Compare - compare two versions, returning -1, 0, or 1 if a is less than,
+// equal to, or greater than b. Build metadata is ignored.
+func (SemverFuncs) Compare(a, b interface{}) (int, error) {
+	va, err := toSemver(a)
+	if err != nil {
+		return 0, err
+	}
+
+	vb, err := toSemver(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return va.Compare(vb), nil
+}
+
 // CheckConstraint -
-func (SemverFuncs) CheckConstraint(constraint, in string) (bool, error) {
+func (SemverFuncs) CheckConstraint(constraint string, in interface{}) (bool, error) {
 	c, err := semver.NewConstraint(constraint)
 	if err != nil {
 		return false, err
 	}
 
-	v, err := semver.NewVersion(in)
+	v, err := toSemver(in)
 	if err != nil {
 		return false, err
 	}
 
 	return c.Check(v), nil
 }
+
+func toSemver(in interface{}) (*semver.Version, error) {
+	switch v := in.(type) {
+	case *semver.Version:
+		return v, nil
+	case semver.Version:
+		return &v, nil
+	default:
+		return semver.NewVersion(conv.ToString(in))
+	}
+}
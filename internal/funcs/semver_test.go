@@ -4,7 +4,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSemverFuncs_MatchConstraint(t *testing.T) {
@@ -59,3 +61,48 @@ func TestSemverFuncs_MatchConstraint(t *testing.T) {
 		})
 	}
 }
+
+func TestSemverParse(t *testing.T) {
+	s := SemverFuncs{ctx: context.Background()}
+
+	v, err := s.Parse("v1.2.3-beta.1+build.5")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), v.Minor())
+	assert.Equal(t, "beta.1", v.Prerelease())
+	assert.Equal(t, "1.2.3-beta.1+build.5", v.String())
+
+	v2, err := s.Parse(v)
+	require.NoError(t, err)
+	assert.Same(t, v, v2)
+
+	_, err = s.Parse("one.two")
+	require.Error(t, err)
+}
+
+func TestSemverCompare(t *testing.T) {
+	s := SemverFuncs{ctx: context.Background()}
+
+	testdata := []struct {
+		a, b     interface{}
+		expected int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"v1.2.3", "1.2.3+build.1", 0},
+		{"1.2.3", "1.10.0", -1},
+		{"2.0.0", "2.0.0-rc.1", 1},
+		{"2.0.0-alpha", "2.0.0-beta", -1},
+		{semver.MustParse("1.0.0"), "0.9", 1},
+	}
+
+	for _, d := range testdata {
+		out, err := s.Compare(d.a, d.b)
+		require.NoError(t, err)
+		assert.Equal(t, d.expected, out, "%v <=> %v", d.a, d.b)
+	}
+
+	_, err := s.Compare("1.0.0", "bogus")
+	require.Error(t, err)
+
+	_, err = s.Compare("bogus", "1.0.0")
+	require.Error(t, err)
+}
@@ -114,3 +114,8 @@ func (SockaddrFuncs) GetInterfaceIP(namedIfRE string) (string, error) {
 func (SockaddrFuncs) GetInterfaceIPs(namedIfRE string) (string, error) {
 	return sockaddr.GetInterfaceIPs(namedIfRE)
 }
+
+// Parse -
+func (SockaddrFuncs) Parse(in string) (string, error) {
+	return template.Parse(in)
+}
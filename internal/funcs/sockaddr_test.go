@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateSockaddrFuncs(t *testing.T) {
@@ -24,3 +25,21 @@ func TestCreateSockaddrFuncs(t *testing.T) {
 		})
 	}
 }
+
+func TestSockaddrParse(t *testing.T) {
+	t.Parallel()
+
+	s := SockaddrFuncs{}
+
+	out, err := s.Parse(`{{ "hello" }}`)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", out)
+
+	// the loopback interface is always present
+	out, err = s.Parse(`{{ GetAllInterfaces | include "flags" "loopback" | include "type" "IPv4" | limit 1 | attr "address" }}`)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", out)
+
+	_, err = s.Parse(`{{ GetAllInterfaces | bogus }}`)
+	require.Error(t, err)
+}
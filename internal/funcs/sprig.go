@@ -0,0 +1,433 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	gotime "time"
+
+	"github.com/hairyhenderson/gomplate/v4/coll"
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/env"
+	"golang.org/x/text/language"
+)
+
+// CreateSprigFuncs - functions compatible with the Sprig library (as used in
+// Helm charts), only added in Sprig compatibility mode. Where gomplate already
+// has a function of the same name and signature, it's reused. Where the
+// signatures differ (like join or contains), the Sprig variant takes
+// precedence.
+func CreateSprigFuncs(ctx context.Context) map[string]interface{} {
+	ns := &SprigFuncs{ctx}
+
+	sf := &StringFuncs{ctx, language.Und}
+	cf := &ConvFuncs{ctx}
+	df := &DataFuncs{ctx}
+	kf := &CollFuncs{ctx}
+	mf := &MathFuncs{ctx}
+	rf := &ReFuncs{ctx}
+	tf := &TestFuncs{ctx}
+
+	return map[string]interface{}{
+		// strings
+		"upper":      sf.ToUpper,
+		"lower":      sf.ToLower,
+		"trim":       sf.TrimSpace,
+		"trimAll":    sf.Trim,
+		"trimPrefix": sf.TrimPrefix,
+		"trimSuffix": sf.TrimSuffix,
+		"contains":   sf.Contains,
+		"hasPrefix":  sf.HasPrefix,
+		"hasSuffix":  sf.HasSuffix,
+		"replace":    sf.ReplaceAll,
+		"repeat":     sf.Repeat,
+		"trunc":      sf.Trunc,
+		"snakecase":  sf.SnakeCase,
+		"kebabcase":  sf.KebabCase,
+		"camelcase":  sf.CamelCase,
+		"splitList":  sf.Split,
+		"nindent":    ns.Nindent,
+		"cat":        ns.Cat,
+		"substr":     ns.Substr,
+		"join":       ns.Join,
+
+		// conversion and encoding
+		"toString":     cf.ToString,
+		"toStrings":    cf.ToStrings,
+		"int":          cf.ToInt,
+		"int64":        cf.ToInt64,
+		"float64":      cf.ToFloat64,
+		"atoi":         cf.Atoi,
+		"toJson":       df.ToJSON,
+		"toPrettyJson": ns.ToPrettyJSON,
+		"fromJson":     df.JSON,
+		"toYaml":       ns.ToYAML,
+		"fromYaml":     df.YAML,
+		"b64enc":       ns.B64Enc,
+		"b64dec":       ns.B64Dec,
+		"sha1sum":      ns.SHA1Sum,
+		"sha256sum":    ns.SHA256Sum,
+		"expandenv":    env.ExpandEnv,
+
+		// collections
+		"list":      kf.Slice,
+		"hasKey":    kf.Has,
+		"get":       ns.Get,
+		"set":       ns.Set,
+		"unset":     ns.Unset,
+		"pick":      ns.Pick,
+		"omit":      ns.Omit,
+		"has":       ns.Has,
+		"append":    ns.Append,
+		"prepend":   ns.Prepend,
+		"first":     ns.First,
+		"last":      ns.Last,
+		"rest":      ns.Rest,
+		"initial":   ns.Initial,
+		"compact":   ns.Compact,
+		"sortAlpha": sf.Sort,
+		"until":     ns.Until,
+
+		// logic and types
+		"empty":  ns.Empty,
+		"kindOf": tf.Kind,
+		"kindIs": tf.IsKind,
+		"typeOf": ns.TypeOf,
+		"typeIs": ns.TypeIs,
+
+		// math
+		"add1": ns.Add1,
+		"div":  ns.Div,
+		"mod":  ns.Mod,
+		"max":  mf.Max,
+		"min":  mf.Min,
+
+		// regular expressions
+		"regexMatch":      rf.Match,
+		"regexFind":       rf.Find,
+		"regexFindAll":    ns.RegexFindAll,
+		"regexReplaceAll": ns.RegexReplaceAll,
+		"regexSplit":      ns.RegexSplit,
+
+		// semantic versions
+		"semver":        (&SemverFuncs{ctx}).Parse,
+		"semverCompare": (&SemverFuncs{ctx}).CheckConstraint,
+
+		// dates, paths, and identifiers
+		"now":          ns.Now,
+		"date":         ns.Date,
+		"base":         path.Base,
+		"dir":          path.Dir,
+		"ext":          path.Ext,
+		"clean":        path.Clean,
+		"isAbs":        path.IsAbs,
+		"uuidv4":       (&UUIDFuncs{ctx}).V4,
+		"randAlphaNum": (&RandomFuncs{ctx}).AlphaNum,
+		"randAlpha":    (&RandomFuncs{ctx}).Alpha,
+	}
+}
+
+// SprigFuncs - Sprig-compatible variants of functions whose arguments or
+// results differ from gomplate's equivalents
+type SprigFuncs struct {
+	ctx context.Context
+}
+
+// Nindent - indent every line by the given number of spaces, with a leading
+// newline
+func (SprigFuncs) Nindent(width int, s interface{}) (string, error) {
+	out, err := (StringFuncs{}).Indent(width, s)
+	if err != nil {
+		return "", err
+	}
+	return "\n" + out, nil
+}
+
+// Cat - concatenate the non-nil arguments, separated by spaces
+func (SprigFuncs) Cat(args ...interface{}) string {
+	parts := make([]string, 0, len(args))
+	for _, a := range args {
+		if a != nil {
+			parts = append(parts, conv.ToString(a))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Substr - the substring of s from start (inclusive) to end (exclusive). A
+// negative start or end is treated as the start or end of the string.
+func (SprigFuncs) Substr(start, end int, s interface{}) string {
+	str := conv.ToString(s)
+	if start < 0 {
+		start = 0
+	}
+	if end < 0 || end > len(str) {
+		end = len(str)
+	}
+	if start > end {
+		return ""
+	}
+	return str[start:end]
+}
+
+// Join - join the elements of a list with the separator (note the order of
+// arguments is the reverse of conv.Join)
+func (SprigFuncs) Join(sep string, list interface{}) (string, error) {
+	return conv.Join(list, sep)
+}
+
+// ToYAML - marshal to YAML, without the trailing newline
+func (f SprigFuncs) ToYAML(in interface{}) (string, error) {
+	out, err := (&DataFuncs{f.ctx}).ToYAML(in)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(out, "\n"), nil
+}
+
+// ToPrettyJSON - marshal to JSON, indented with 2 spaces
+func (f SprigFuncs) ToPrettyJSON(in interface{}) (string, error) {
+	return (&DataFuncs{f.ctx}).ToJSONPretty("  ", in)
+}
+
+// B64Enc -
+func (SprigFuncs) B64Enc(in interface{}) (string, error) {
+	return (Base64Funcs{}).Encode(in)
+}
+
+// B64Dec -
+func (SprigFuncs) B64Dec(in interface{}) (string, error) {
+	return (Base64Funcs{}).Decode(in)
+}
+
+// SHA1Sum -
+func (f SprigFuncs) SHA1Sum(in interface{}) string {
+	return (CryptoFuncs{ctx: f.ctx}).SHA1(in)
+}
+
+// SHA256Sum -
+func (f SprigFuncs) SHA256Sum(in interface{}) string {
+	return (CryptoFuncs{ctx: f.ctx}).SHA256(in)
+}
+
+// Get - the value of the key in the map, or an empty string if it isn't set
+func (SprigFuncs) Get(m map[string]interface{}, key string) interface{} {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	return ""
+}
+
+// Set - set the key in the map to the value, returning the (modified) map
+func (SprigFuncs) Set(m map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	m[key] = value
+	return m
+}
+
+// Unset - remove the key from the map, returning the (modified) map
+func (SprigFuncs) Unset(m map[string]interface{}, key string) map[string]interface{} {
+	delete(m, key)
+	return m
+}
+
+// Pick - a new map with only the given keys (note the map is the first
+// argument, unlike coll.Pick)
+func (SprigFuncs) Pick(m map[string]interface{}, keys ...string) map[string]interface{} {
+	return coll.Pick(m, keys...)
+}
+
+// Omit - a new map without the given keys (note the map is the first
+// argument, unlike coll.Omit)
+func (SprigFuncs) Omit(m map[string]interface{}, keys ...string) map[string]interface{} {
+	return coll.Omit(m, keys...)
+}
+
+// Has - whether the list contains the needle (note the order of arguments is
+// the reverse of coll.Has)
+func (SprigFuncs) Has(needle interface{}, list interface{}) bool {
+	return coll.Has(list, needle)
+}
+
+// Append - append the value to the list (note the order of arguments is the
+// reverse of coll.Append)
+func (SprigFuncs) Append(list interface{}, v interface{}) ([]interface{}, error) {
+	return coll.Append(v, list)
+}
+
+// Prepend - prepend the value to the list (note the order of arguments is the
+// reverse of coll.Prepend)
+func (SprigFuncs) Prepend(list interface{}, v interface{}) ([]interface{}, error) {
+	return coll.Prepend(v, list)
+}
+
+// First - the first element of the list, or nil if it's empty
+func (f SprigFuncs) First(list interface{}) (interface{}, error) {
+	l, err := f.toList(list)
+	if err != nil || len(l) == 0 {
+		return nil, err
+	}
+	return l[0], nil
+}
+
+// Last - the last element of the list, or nil if it's empty
+func (f SprigFuncs) Last(list interface{}) (interface{}, error) {
+	l, err := f.toList(list)
+	if err != nil || len(l) == 0 {
+		return nil, err
+	}
+	return l[len(l)-1], nil
+}
+
+// Rest - all but the first element of the list
+func (f SprigFuncs) Rest(list interface{}) ([]interface{}, error) {
+	l, err := f.toList(list)
+	if err != nil || len(l) == 0 {
+		return []interface{}{}, err
+	}
+	return l[1:], nil
+}
+
+// Initial - all but the last element of the list
+func (f SprigFuncs) Initial(list interface{}) ([]interface{}, error) {
+	l, err := f.toList(list)
+	if err != nil || len(l) == 0 {
+		return []interface{}{}, err
+	}
+	return l[:len(l)-1], nil
+}
+
+// Compact - the list, without empty values
+func (f SprigFuncs) Compact(list interface{}) ([]interface{}, error) {
+	l, err := f.toList(list)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]interface{}, 0, len(l))
+	for _, v := range l {
+		if !f.Empty(v) {
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+// Until - a list of integers from 0 up to (but not including) n
+func (SprigFuncs) Until(n interface{}) []int {
+	count := conv.ToInt(n)
+	if count < 0 {
+		count = 0
+	}
+	out := make([]int, count)
+	for i := range out {
+		out[i] = i
+	}
+	return out
+}
+
+func (SprigFuncs) toList(list interface{}) ([]interface{}, error) {
+	if list == nil {
+		return nil, nil
+	}
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a list, got %T", list)
+	}
+	out := make([]interface{}, v.Len())
+	for i := range out {
+		out[i] = v.Index(i).Interface()
+	}
+	return out, nil
+}
+
+// Empty - whether the value is empty (the zero value for its type, or an
+// empty list or map)
+func (SprigFuncs) Empty(in interface{}) bool {
+	if in == nil {
+		return true
+	}
+	v := reflect.ValueOf(in)
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	default:
+		return v.IsZero()
+	}
+}
+
+// TypeOf - the Go type of the value, like "[]interface {}"
+func (SprigFuncs) TypeOf(in interface{}) string {
+	return fmt.Sprintf("%T", in)
+}
+
+// TypeIs - whether the value is of the given Go type
+func (f SprigFuncs) TypeIs(typ string, in interface{}) bool {
+	return f.TypeOf(in) == typ
+}
+
+// Add1 - increment by 1
+func (SprigFuncs) Add1(n interface{}) int64 {
+	return conv.ToInt64(n) + 1
+}
+
+// Div - integer division (unlike math.Div, which returns a float)
+func (SprigFuncs) Div(a, b interface{}) (int64, error) {
+	divisor := conv.ToInt64(b)
+	if divisor == 0 {
+		return 0, fmt.Errorf("error: division by 0")
+	}
+	return conv.ToInt64(a) / divisor, nil
+}
+
+// Mod - the remainder of integer division
+func (SprigFuncs) Mod(a, b interface{}) (int64, error) {
+	divisor := conv.ToInt64(b)
+	if divisor == 0 {
+		return 0, fmt.Errorf("error: division by 0")
+	}
+	return conv.ToInt64(a) % divisor, nil
+}
+
+// RegexFindAll - all matches of the expression in the input, up to n (or all
+// matches when n is negative)
+func (SprigFuncs) RegexFindAll(re string, s interface{}, n int) ([]string, error) {
+	return (ReFuncs{}).FindAll(re, n, s)
+}
+
+// RegexReplaceAll - replace matches of the expression in the input (note the
+// order of arguments differs from regexp.Replace)
+func (SprigFuncs) RegexReplaceAll(re string, s interface{}, replacement string) string {
+	return (ReFuncs{}).Replace(re, replacement, s)
+}
+
+// RegexSplit - split the input around matches of the expression, into at
+// most n substrings (or all substrings when n is negative)
+func (SprigFuncs) RegexSplit(re string, s interface{}, n int) ([]string, error) {
+	return (ReFuncs{}).Split(re, n, s)
+}
+
+// Now - the current time
+func (f SprigFuncs) Now() (gotime.Time, error) {
+	return (&TimeFuncs{ctx: f.ctx}).Now()
+}
+
+// Date - format the time with the given (Go reference time) layout. The time
+// can be a time.Time or a Unix timestamp.
+func (SprigFuncs) Date(layout string, t interface{}) (string, error) {
+	switch v := t.(type) {
+	case gotime.Time:
+		return v.Format(layout), nil
+	case *gotime.Time:
+		return v.Format(layout), nil
+	default:
+		sec, err := strconv.ParseInt(conv.ToString(t), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("date: expected a time or Unix timestamp, got %T", t)
+		}
+		return gotime.Unix(sec, 0).Format(layout), nil
+	}
+}
@@ -0,0 +1,170 @@
+package funcs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSprigFuncs(t *testing.T) {
+	t.Parallel()
+
+	fmap := CreateSprigFuncs(context.Background())
+
+	// the Sprig variants have different signatures to gomplate's
+	_, ok := fmap["join"].(func(string, interface{}) (string, error))
+	assert.True(t, ok)
+	_, ok = fmap["contains"].(func(string, interface{}) bool)
+	assert.True(t, ok)
+
+	// namespaces are never overridden
+	for _, ns := range []string{"env", "strings", "coll", "conv", "data"} {
+		assert.NotContains(t, fmap, ns)
+	}
+}
+
+func TestSprigStrings(t *testing.T) {
+	t.Parallel()
+
+	f := SprigFuncs{ctx: context.Background()}
+
+	out, err := f.Nindent(2, "foo\nbar")
+	require.NoError(t, err)
+	assert.Equal(t, "\n  foo\n  bar", out)
+
+	assert.Equal(t, "hello world 42", f.Cat("hello", nil, "world", 42))
+	assert.Equal(t, "", f.Cat())
+
+	assert.Equal(t, "ell", f.Substr(1, 4, "hello"))
+	assert.Equal(t, "hello", f.Substr(-1, 42, "hello"))
+	assert.Equal(t, "", f.Substr(4, 1, "hello"))
+
+	out, err = f.Join("-", []string{"a", "b", "c"})
+	require.NoError(t, err)
+	assert.Equal(t, "a-b-c", out)
+
+	out, err = f.ToYAML(map[string]interface{}{"foo": "bar"})
+	require.NoError(t, err)
+	assert.Equal(t, "foo: bar", out)
+}
+
+func TestSprigLists(t *testing.T) {
+	t.Parallel()
+
+	f := SprigFuncs{ctx: context.Background()}
+	list := []interface{}{"a", "", "b", nil, "c"}
+
+	v, err := f.First(list)
+	require.NoError(t, err)
+	assert.Equal(t, "a", v)
+
+	v, err = f.Last(list)
+	require.NoError(t, err)
+	assert.Equal(t, "c", v)
+
+	v, err = f.First([]string{})
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	l, err := f.Rest([]int{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{2, 3}, l)
+
+	l, err = f.Initial([]int{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2}, l)
+
+	l, err = f.Compact(list)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "b", "c"}, l)
+
+	_, err = f.First("not a list")
+	assert.Error(t, err)
+
+	l, err = f.Append([]string{"a"}, "b")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "b"}, l)
+
+	l, err = f.Prepend([]string{"a"}, "b")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"b", "a"}, l)
+
+	assert.True(t, f.Has("b", list))
+	assert.False(t, f.Has("z", list))
+
+	assert.Equal(t, []int{0, 1, 2}, f.Until(3))
+	assert.Equal(t, []int{}, f.Until(-1))
+
+	m := map[string]interface{}{"foo": 1, "bar": 2}
+	assert.Equal(t, 1, f.Get(m, "foo"))
+	assert.Equal(t, "", f.Get(m, "baz"))
+	assert.Equal(t, map[string]interface{}{"foo": 1}, f.Pick(m, "foo"))
+	assert.Equal(t, map[string]interface{}{"bar": 2}, f.Omit(m, "foo"))
+	assert.Equal(t, 3, f.Set(m, "baz", 3)["baz"])
+	assert.NotContains(t, f.Unset(m, "baz"), "baz")
+}
+
+func TestSprigEmpty(t *testing.T) {
+	t.Parallel()
+
+	f := SprigFuncs{ctx: context.Background()}
+
+	for _, v := range []interface{}{nil, "", 0, 0.0, false, []string{}, map[string]int{}, (*int)(nil)} {
+		assert.True(t, f.Empty(v), "%#v", v)
+	}
+
+	for _, v := range []interface{}{"a", 1, true, []int{0}, map[string]int{"a": 0}, struct{ A int }{1}} {
+		assert.False(t, f.Empty(v), "%#v", v)
+	}
+}
+
+func TestSprigMath(t *testing.T) {
+	t.Parallel()
+
+	f := SprigFuncs{ctx: context.Background()}
+
+	assert.Equal(t, int64(43), f.Add1(42))
+	assert.Equal(t, int64(43), f.Add1("42"))
+
+	n, err := f.Div(10, 3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	n, err = f.Mod(10, "3")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	_, err = f.Div(1, 0)
+	assert.Error(t, err)
+
+	_, err = f.Mod(1, 0)
+	assert.Error(t, err)
+}
+
+func TestSprigDate(t *testing.T) {
+	t.Parallel()
+
+	f := SprigFuncs{ctx: context.Background()}
+	tm := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	out, err := f.Date("2006-01-02", tm)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-04", out)
+
+	out, err = f.Date("2006-01-02", &tm)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-04", out)
+
+	out, err = f.Date(time.RFC3339, tm.Unix())
+	require.NoError(t, err)
+	assert.Equal(t, tm.Local().Format(time.RFC3339), out)
+
+	_, err = f.Date("2006", "bogus")
+	assert.Error(t, err)
+
+	assert.Equal(t, "[]interface {}", f.TypeOf([]interface{}{}))
+	assert.True(t, f.TypeIs("int", 42))
+}
@@ -242,8 +242,26 @@ func (StringFuncs) Trunc(length int, s interface{}) string {
 	return gompstrings.Trunc(length, conv.ToString(s))
 }
 
+// Truncate - truncate the input to the given length, ending it with an
+// ellipsis ("..." by default) when it's truncated
+func (StringFuncs) Truncate(args ...interface{}) (string, error) {
+	ellipsis := "..."
+	switch len(args) {
+	case 2:
+	case 3:
+		ellipsis = conv.ToString(args[1])
+	default:
+		return "", fmt.Errorf("expected 2 or 3 args, got %d", len(args))
+	}
+
+	return gompstrings.Truncate(conv.ToInt(args[0]), ellipsis, conv.ToString(args[len(args)-1])), nil
+}
+
 // Indent -
 func (StringFuncs) Indent(args ...interface{}) (string, error) {
+	if len(args) == 0 || len(args) > 3 {
+		return "", fmt.Errorf("expected 1, 2, or 3 args, got %d", len(args))
+	}
 	input := conv.ToString(args[len(args)-1])
 	indent := " "
 	width := 1
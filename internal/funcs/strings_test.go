@@ -57,6 +57,12 @@ func TestIndent(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, d.out, out)
 	}
+
+	_, err := sf.Indent()
+	require.Error(t, err)
+
+	_, err = sf.Indent(1, " ", "foo", "bar")
+	require.Error(t, err)
 }
 
 func TestTrimPrefix(t *testing.T) {
@@ -100,6 +106,26 @@ func TestTrunc(t *testing.T) {
 	assert.Equal(t, "hello, world", sf.Trunc(-1, "hello, world"))
 }
 
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	sf := &StringFuncs{}
+	_, err := sf.Truncate("hello")
+	assert.Error(t, err)
+
+	s, err := sf.Truncate(10, "hello, world")
+	require.NoError(t, err)
+	assert.Equal(t, "hello,...", s)
+
+	s, err = sf.Truncate("8", " [more]", "hello, world")
+	require.NoError(t, err)
+	assert.Equal(t, "h [more]", s)
+
+	s, err = sf.Truncate(20, "…", "hello, world")
+	require.NoError(t, err)
+	assert.Equal(t, "hello, world", s)
+}
+
 func TestAbbrev(t *testing.T) {
 	t.Parallel()
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"text/template"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
 	"github.com/hairyhenderson/gomplate/v4/test"
@@ -22,6 +23,7 @@ func CreateTestFuncs(ctx context.Context) map[string]interface{} {
 	f["ternary"] = ns.Ternary
 	f["kind"] = ns.Kind
 	f["isKind"] = ns.IsKind
+	f["coalesce"] = ns.Coalesce
 	return f
 }
 
@@ -32,6 +34,10 @@ type TestFuncs struct {
 
 // Assert -
 func (TestFuncs) Assert(args ...interface{}) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf("wrong number of args: want 1 or 2, got 0")
+	}
+
 	input := conv.ToBool(args[len(args)-1])
 	switch len(args) {
 	case 1:
@@ -83,6 +89,16 @@ func (TestFuncs) Ternary(tval, fval, b interface{}) interface{} {
 	return fval
 }
 
+// Coalesce - return the first non-empty argument, or nil if all are empty
+func (TestFuncs) Coalesce(args ...interface{}) interface{} {
+	for _, arg := range args {
+		if truth, ok := template.IsTrue(arg); truth && ok {
+			return arg
+		}
+	}
+	return nil
+}
+
 // Kind - return the kind of the argument
 func (TestFuncs) Kind(arg interface{}) string {
 	return reflect.ValueOf(arg).Kind().String()
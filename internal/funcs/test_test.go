@@ -41,6 +41,29 @@ func TestAssert(t *testing.T) {
 
 	_, err = f.Assert("foo", "false")
 	assert.EqualError(t, err, "assertion failed: foo")
+
+	_, err = f.Assert()
+	assert.EqualError(t, err, "wrong number of args: want 1 or 2, got 0")
+
+	_, err = f.Assert("foo", "bar", true)
+	assert.EqualError(t, err, "wrong number of args: want 1 or 2, got 3")
+}
+
+func TestFail(t *testing.T) {
+	t.Parallel()
+
+	f := TestFuncs{ctx: context.Background()}
+	_, err := f.Fail()
+	assert.EqualError(t, err, "template generation failed")
+
+	_, err = f.Fail("the sky is falling")
+	assert.EqualError(t, err, "template generation failed: the sky is falling")
+
+	_, err = f.Fail(42)
+	assert.EqualError(t, err, "template generation failed: 42")
+
+	_, err = f.Fail("foo", "bar")
+	assert.EqualError(t, err, "wrong number of args: want 0 or 1, got 2")
 }
 
 func TestRequired(t *testing.T) {
@@ -105,6 +128,27 @@ func TestTernary(t *testing.T) {
 	}
 }
 
+func TestCoalesce(t *testing.T) {
+	t.Parallel()
+
+	f := TestFuncs{ctx: context.Background()}
+	testdata := []struct {
+		expected interface{}
+		args     []interface{}
+	}{
+		{nil, nil},
+		{nil, []interface{}{nil, "", 0, false, []string{}, map[string]interface{}{}}},
+		{"foo", []interface{}{"foo", "bar"}},
+		{"bar", []interface{}{nil, "", "bar"}},
+		{42, []interface{}{0, 42}},
+		{[]int{1}, []interface{}{[]int{}, []int{1}}},
+		{true, []interface{}{false, true, "baz"}},
+	}
+	for _, d := range testdata {
+		assert.Equal(t, d.expected, f.Coalesce(d.args...), d.args)
+	}
+}
+
 func TestKind(t *testing.T) {
 	t.Parallel()
 
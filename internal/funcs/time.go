@@ -88,9 +88,10 @@ func (TimeFuncs) ParseInLocation(layout, location string, value interface{}) (go
 	return gotime.ParseInLocation(layout, conv.ToString(value), loc)
 }
 
-// Now -
-func (TimeFuncs) Now() gotime.Time {
-	return gotime.Now()
+// Now - returns the current time, or the time given by SOURCE_DATE_EPOCH
+// when it is set
+func (TimeFuncs) Now() (gotime.Time, error) {
+	return time.Now()
 }
 
 // Unix - convert UNIX time (in seconds since the UNIX epoch) into a time.Time for further processing
@@ -139,13 +140,59 @@ func (TimeFuncs) ParseDuration(n interface{}) (gotime.Duration, error) {
 }
 
 // Since -
-func (TimeFuncs) Since(n gotime.Time) gotime.Duration {
-	return gotime.Since(n)
+func (TimeFuncs) Since(n gotime.Time) (gotime.Duration, error) {
+	now, err := time.Now()
+	if err != nil {
+		return 0, err
+	}
+	return now.Sub(n), nil
 }
 
 // Until -
-func (TimeFuncs) Until(n gotime.Time) gotime.Duration {
-	return gotime.Until(n)
+func (TimeFuncs) Until(n gotime.Time) (gotime.Duration, error) {
+	now, err := time.Now()
+	if err != nil {
+		return 0, err
+	}
+	return n.Sub(now), nil
+}
+
+// Add - add the given duration to the time. The duration can be a
+// time.Duration or a string in the format accepted by time.ParseDuration
+// (e.g. "-1h30m").
+func (TimeFuncs) Add(d interface{}, t gotime.Time) (gotime.Time, error) {
+	dur, err := toDuration(d)
+	if err != nil {
+		return gotime.Time{}, err
+	}
+	return t.Add(dur), nil
+}
+
+// Strftime - format the time with a C strftime-style format string. The time
+// can be a time.Time, or a UNIX timestamp (see time.Unix).
+func (f TimeFuncs) Strftime(format string, t interface{}) (string, error) {
+	tm, ok := t.(gotime.Time)
+	if !ok {
+		var err error
+		tm, err = f.Unix(t)
+		if err != nil {
+			return "", fmt.Errorf("expected a time or UNIX timestamp, got %T: %w", t, err)
+		}
+	}
+	return time.Strftime(format, tm)
+}
+
+func toDuration(d interface{}) (gotime.Duration, error) {
+	switch d := d.(type) {
+	case gotime.Duration:
+		return d, nil
+	case string:
+		return gotime.ParseDuration(d)
+	case fmt.Stringer:
+		return gotime.ParseDuration(d.String())
+	default:
+		return 0, fmt.Errorf("expected a duration, got %T", d)
+	}
 }
 
 // convert a number input to a pair of int64s, representing the integer portion and the decimal remainder
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"strconv"
 	"testing"
+	gotime "time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -80,3 +81,71 @@ func TestParseNum(t *testing.T) {
 	assert.Zero(t, f)
 	require.NoError(t, err)
 }
+
+func TestTimeNow(t *testing.T) {
+	tf := &TimeFuncs{}
+
+	t.Setenv("SOURCE_DATE_EPOCH", "1483228800")
+	epoch := gotime.Date(2017, gotime.January, 1, 0, 0, 0, 0, gotime.UTC)
+
+	now, err := tf.Now()
+	require.NoError(t, err)
+	assert.Equal(t, epoch, now)
+
+	d, err := tf.Since(epoch.Add(-gotime.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, gotime.Hour, d)
+
+	d, err = tf.Until(epoch.Add(gotime.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, gotime.Minute, d)
+
+	t.Setenv("SOURCE_DATE_EPOCH", "bogus")
+	_, err = tf.Now()
+	require.Error(t, err)
+
+	_, err = tf.Since(epoch)
+	require.Error(t, err)
+}
+
+func TestTimeAdd(t *testing.T) {
+	t.Parallel()
+
+	tf := &TimeFuncs{}
+	in := gotime.Date(2017, gotime.January, 1, 0, 0, 0, 0, gotime.UTC)
+
+	out, err := tf.Add("1h30m", in)
+	require.NoError(t, err)
+	assert.Equal(t, in.Add(90*gotime.Minute), out)
+
+	out, err = tf.Add(-48*gotime.Hour, in)
+	require.NoError(t, err)
+	assert.Equal(t, gotime.Date(2016, gotime.December, 30, 0, 0, 0, 0, gotime.UTC), out)
+
+	_, err = tf.Add("1 day", in)
+	require.Error(t, err)
+
+	_, err = tf.Add(42, in)
+	require.Error(t, err)
+}
+
+func TestStrftime(t *testing.T) {
+	t.Parallel()
+
+	tf := &TimeFuncs{}
+	in := gotime.Date(2017, gotime.January, 1, 13, 4, 5, 0, gotime.UTC)
+
+	out, err := tf.Strftime("%Y-%m-%d %I:%M:%S %p", in)
+	require.NoError(t, err)
+	assert.Equal(t, "2017-01-01 01:04:05 PM", out)
+
+	out, err = tf.Strftime("%s", "1483275845")
+	require.NoError(t, err)
+	assert.Equal(t, "1483275845", out)
+
+	_, err = tf.Strftime("%F", "not a time")
+	require.Error(t, err)
+
+	_, err = tf.Strftime("%Q", in)
+	require.Error(t, err)
+}
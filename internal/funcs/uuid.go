@@ -2,6 +2,8 @@ package funcs
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
 
@@ -40,6 +42,37 @@ func (UUIDFuncs) V4() (string, error) {
 	return u.String(), nil
 }
 
+// V5 - return a version 5 (name-based, SHA-1) UUID, derived from the given
+// namespace and name. The same namespace and name always produce the same
+// UUID. The namespace can be a UUID, or one of the predefined namespaces
+// "dns", "url", "oid", or "x500".
+func (f UUIDFuncs) V5(namespace, name interface{}) (string, error) {
+	ns, err := uuidNamespace(conv.ToString(namespace))
+	if err != nil {
+		return "", err
+	}
+	return uuid.NewSHA1(ns, []byte(conv.ToString(name))).String(), nil
+}
+
+func uuidNamespace(ns string) (uuid.UUID, error) {
+	switch strings.ToLower(ns) {
+	case "dns":
+		return uuid.NameSpaceDNS, nil
+	case "url":
+		return uuid.NameSpaceURL, nil
+	case "oid":
+		return uuid.NameSpaceOID, nil
+	case "x500":
+		return uuid.NameSpaceX500, nil
+	}
+
+	u, err := uuid.Parse(ns)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid UUID namespace %q: must be a UUID, or one of dns, url, oid, or x500", ns)
+	}
+	return u, nil
+}
+
 // Nil -
 func (UUIDFuncs) Nil() (string, error) {
 	return uuid.Nil.String(), nil
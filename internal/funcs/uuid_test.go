@@ -50,6 +50,33 @@ func TestV4(t *testing.T) {
 	assert.Regexp(t, uuidV4Pattern, i)
 }
 
+func TestV5(t *testing.T) {
+	t.Parallel()
+
+	u := UUIDFuncs{ctx: context.Background()}
+
+	testdata := []struct {
+		namespace interface{}
+		name      interface{}
+		expected  string
+	}{
+		{"dns", "example.com", "cfbff0d1-9375-5685-968c-48ce8b15ae17"},
+		{"DNS", "example.com", "cfbff0d1-9375-5685-968c-48ce8b15ae17"},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "example.com", "cfbff0d1-9375-5685-968c-48ce8b15ae17"},
+		{"url", "https://example.com", "4fd35a71-71ef-5a55-a9d9-aa75c889a6d0"},
+		{"oid", "1.2.3", "42d5e23b-3a02-5135-85c6-52d1102f1f00"},
+	}
+
+	for _, d := range testdata {
+		i, err := u.V5(d.namespace, d.name)
+		require.NoError(t, err)
+		assert.Equal(t, d.expected, i)
+	}
+
+	_, err := u.V5("foo", "example.com")
+	require.Error(t, err)
+}
+
 func TestNil(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,221 @@
+// Package i18n contains support for translation catalogs, used to produce
+// localized output.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chai2010/gettext-go/po"
+)
+
+// Catalog is a set of translated messages, keyed by message ID. Each message
+// has one or more forms - the first is used for singular, and the rest for
+// plurals.
+type Catalog struct {
+	messages map[string][]string
+	plural   pluralFunc
+}
+
+// Parse a translation catalog, in either JSON or gettext PO format. JSON
+// catalogs are detected by a leading '{'.
+//
+// JSON catalogs map message IDs to translations. Nested objects are
+// flattened, joining keys with '.', and arrays give the plural forms of a
+// message.
+//
+// PO catalogs are read with gettext-go, and the plural rule is chosen from
+// the Plural-Forms or Language header (see pluralRule). Fuzzy and untranslated
+// entries, and entries with a message context (msgctxt), are ignored.
+func Parse(in string) (*Catalog, error) {
+	if strings.HasPrefix(strings.TrimSpace(in), "{") {
+		return parseJSON(in)
+	}
+	return parsePO(in)
+}
+
+// T - translate the message with the given ID, formatting it with the given
+// arguments (with fmt.Sprintf). When the message isn't in the catalog, the ID
+// itself is used.
+func (c *Catalog) T(key string, args ...interface{}) string {
+	msg := key
+	if c != nil {
+		if forms, ok := c.messages[key]; ok {
+			msg = forms[0]
+		}
+	}
+	return format(msg, args)
+}
+
+// N - translate the message with the given ID, choosing the plural form for n,
+// and formatting it with the given arguments (with fmt.Sprintf). When no
+// arguments are given, n is used (if the message has any verbs, since forms
+// like "one file" often don't). When the message isn't in the catalog, the ID
+// itself is used.
+func (c *Catalog) N(key string, n int, args ...interface{}) string {
+	implicit := len(args) == 0
+	if implicit {
+		args = []interface{}{n}
+	}
+
+	msg := key
+	if c != nil {
+		if forms, ok := c.messages[key]; ok {
+			i := c.plural(n)
+			if i < 0 || i >= len(forms) {
+				i = len(forms) - 1
+			}
+			msg = forms[i]
+		}
+	}
+	if implicit && !strings.Contains(msg, "%") {
+		return msg
+	}
+	return format(msg, args)
+}
+
+// Has - whether the catalog contains a translation for the message ID
+func (c *Catalog) Has(key string) bool {
+	if c == nil {
+		return false
+	}
+	_, ok := c.messages[key]
+	return ok
+}
+
+// Keys - the message IDs in the catalog, sorted
+func (c *Catalog) Keys() []string {
+	if c == nil {
+		return []string{}
+	}
+	keys := make([]string, 0, len(c.messages))
+	for k := range c.messages {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func format(msg string, args []interface{}) string {
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+func parseJSON(in string) (*Catalog, error) {
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(in), &obj); err != nil {
+		return nil, fmt.Errorf("unable to parse JSON catalog: %w", err)
+	}
+
+	c := &Catalog{messages: map[string][]string{}, plural: defaultPlural}
+	if err := c.addJSON("", obj); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Catalog) addJSON(prefix string, obj map[string]interface{}) error {
+	for k, v := range obj {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		switch v := v.(type) {
+		case string:
+			c.messages[key] = []string{v}
+		case []interface{}:
+			if len(v) == 0 {
+				return fmt.Errorf("invalid JSON catalog: message %q has no forms", key)
+			}
+			forms := make([]string, len(v))
+			for i, f := range v {
+				s, ok := f.(string)
+				if !ok {
+					return fmt.Errorf("invalid JSON catalog: plural forms of message %q must be strings", key)
+				}
+				forms[i] = s
+			}
+			c.messages[key] = forms
+		case map[string]interface{}:
+			if err := c.addJSON(key, v); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("invalid JSON catalog: message %q must be a string, an array of strings, or an object", key)
+		}
+	}
+	return nil
+}
+
+func parsePO(in string) (*Catalog, error) {
+	if err := checkPO(in); err != nil {
+		return nil, fmt.Errorf("invalid PO catalog: %w", err)
+	}
+
+	f, err := po.Load([]byte(in))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PO catalog: %w", err)
+	}
+
+	c := &Catalog{
+		messages: map[string][]string{},
+		plural:   pluralRule(f.MimeHeader.PluralForms, f.MimeHeader.Language),
+	}
+	for _, m := range f.Messages {
+		if m.GetFuzzy() || m.MsgContext != "" {
+			continue
+		}
+
+		forms := []string{m.MsgStr}
+		if m.MsgIdPlural != "" {
+			forms = m.MsgStrPlural
+		}
+		if translated(forms) {
+			c.messages[m.MsgId] = forms
+		}
+	}
+
+	return c, nil
+}
+
+var msgstrIndexRe = regexp.MustCompile(`^msgstr\s*\[(\d+)\]`)
+
+// checkPO rejects input that gettext-go's PO parser can't handle safely - it
+// never returns when a string doesn't follow a keyword or another string, and
+// allocates plural forms up to any msgstr index.
+func checkPO(in string) error {
+	prev := ""
+	for i, line := range strings.Split(strings.ReplaceAll(in, "\r", ""), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, `"`) && (prev == "" || strings.HasPrefix(prev, "#")) {
+			return fmt.Errorf("unexpected string on line %d", i+1)
+		}
+		if m := msgstrIndexRe.FindStringSubmatch(line); m != nil {
+			if n, err := strconv.Atoi(m[1]); err != nil || n > 100 {
+				return fmt.Errorf("bad plural index on line %d", i+1)
+			}
+		}
+		prev = line
+	}
+	return nil
+}
+
+// translated - whether all forms of a message are translated
+func translated(forms []string) bool {
+	if len(forms) == 0 {
+		return false
+	}
+	for _, f := range forms {
+		if f == "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSON(t *testing.T) {
+	c, err := Parse(`{
+  "hello": "Bonjour",
+  "greet": "Bonjour, %s !",
+  "apples": ["%d pomme", "%d pommes"],
+  "files": ["un fichier", "%d fichiers"],
+  "menu": {"file": "Fichier", "edit": {"copy": "Copier"}}
+}`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"apples", "files", "greet", "hello", "menu.edit.copy", "menu.file"}, c.Keys())
+	assert.Equal(t, "Bonjour", c.T("hello"))
+	assert.Equal(t, "Bonjour, Marie !", c.T("greet", "Marie"))
+	assert.Equal(t, "Copier", c.T("menu.edit.copy"))
+	assert.Equal(t, "missing", c.T("missing"))
+	assert.Equal(t, "%d pomme", c.T("apples"))
+	assert.Equal(t, "1 pomme", c.N("apples", 1))
+	assert.Equal(t, "3 pommes", c.N("apples", 3))
+	assert.Equal(t, "Bonjour", c.N("hello", 3))
+	assert.Equal(t, "un fichier", c.N("files", 1))
+	assert.Equal(t, "2 fichiers", c.N("files", 2))
+	assert.True(t, c.Has("menu.file"))
+	assert.False(t, c.Has("menu"))
+
+	for _, in := range []string{`{`, `{"a": 1}`, `{"a": []}`, `{"a": ["x", 2]}`} {
+		_, err = Parse(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestParsePO(t *testing.T) {
+	c, err := Parse(`# Polish translations
+msgid ""
+msgstr ""
+"Language: pl\n"
+"Plural-Forms: nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && "
+"(n%100<10 || n%100>=20) ? 1 : 2);\n"
+
+#: motd.tmpl:1
+msgid "Welcome to %s"
+msgstr "Witamy na %s"
+
+msgid "%d file"
+msgid_plural "%d files"
+msgstr[0] "%d plik"
+msgstr[1] "%d pliki"
+msgstr[2] "%d plików"
+
+msgid "long"
+msgstr ""
+"multi-"
+"line"
+
+#, fuzzy
+msgid "unsure"
+msgstr "niepewny"
+
+msgid "untranslated"
+msgstr ""
+
+msgctxt "menu"
+msgid "Open"
+msgstr "Otwórz"
+`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"%d file", "Welcome to %s", "long"}, c.Keys())
+	assert.Equal(t, "Witamy na web01", c.T("Welcome to %s", "web01"))
+	assert.Equal(t, "multi-line", c.T("long"))
+	assert.Equal(t, "unsure", c.T("unsure"))
+	assert.Equal(t, "untranslated", c.T("untranslated"))
+	assert.Equal(t, "1 plik", c.N("%d file", 1))
+	assert.Equal(t, "3 pliki", c.N("%d file", 3))
+	assert.Equal(t, "5 plików", c.N("%d file", 5))
+	assert.Equal(t, "22 pliki", c.N("%d file", 22))
+	assert.Equal(t, "2 pliki", c.N("%d file", 2, 2))
+
+	for _, in := range []string{`msgid "a`, `"orphan"`, "msgid \"a\"\n\n\"orphan\"", `bogus "a"`, "msgid \"a\"\nmsgstr[1000000000] \"b\""} {
+		_, err = Parse(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestNilCatalog(t *testing.T) {
+	var c *Catalog
+	assert.Equal(t, "hello", c.T("hello"))
+	assert.Equal(t, "hello world", c.T("hello %s", "world"))
+	assert.Equal(t, "2 files", c.N("%d files", 2))
+	assert.False(t, c.Has("hello"))
+	assert.Empty(t, c.Keys())
+}
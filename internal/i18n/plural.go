@@ -0,0 +1,67 @@
+package i18n
+
+import (
+	"strings"
+
+	"github.com/chai2010/gettext-go/plural"
+)
+
+// pluralFunc returns the index of the plural form to use for n
+type pluralFunc func(n int) int
+
+// defaultPlural is the plural rule used when a catalog doesn't declare one -
+// the first form is used when n is 1, otherwise the second (as in English)
+var defaultPlural = plural.Formula("en")
+
+// pluralRule finds the plural rule for a PO catalog, from gettext's table of
+// standard rules. The Plural-Forms header is used when it's one of the
+// standard rules, otherwise the rule for the Language header is used. When
+// neither is known, defaultPlural is used.
+func pluralRule(forms, lang string) pluralFunc {
+	if f := formulaFor(normalizeForms(forms)); f != nil {
+		return f
+	}
+
+	// use the most specific language, so that "pt_BR" isn't treated as "pt"
+	best, value := "", ""
+	for _, r := range plural.FormsTable {
+		if r.Lang != "??" && strings.HasPrefix(lang, r.Lang) && len(r.Lang) > len(best) {
+			best, value = r.Lang, r.Value
+		}
+	}
+	if f := formulaFor(normalizeForms(value)); f != nil {
+		return f
+	}
+
+	return defaultPlural
+}
+
+// formulaFor returns gettext-go's formula for the given (normalized) plural
+// forms, or nil when they aren't in its table. As plural.Formula looks up
+// languages by prefix, entries shadowed by an earlier one can't be used.
+func formulaFor(forms string) pluralFunc {
+	if forms == "" {
+		return nil
+	}
+	for i, r := range plural.FormsTable {
+		if normalizeForms(r.Value) == forms && !shadowed(i) {
+			return plural.Formula(r.Lang)
+		}
+	}
+	return nil
+}
+
+func shadowed(i int) bool {
+	for _, r := range plural.FormsTable[:i] {
+		if strings.HasPrefix(plural.FormsTable[i].Lang, r.Lang) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeForms removes whitespace and any trailing ';' from a Plural-Forms
+// value, so that equivalent rules can be compared
+func normalizeForms(forms string) string {
+	return strings.TrimSuffix(strings.Join(strings.Fields(forms), ""), ";")
+}
@@ -0,0 +1,36 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluralRule(t *testing.T) {
+	testdata := []struct {
+		forms, lang string
+		expected    map[int]int
+	}{
+		{"", "", map[int]int{0: 1, 1: 0, 2: 1}},
+		{"nplurals=2; plural=(n > 1);", "", map[int]int{0: 0, 1: 0, 2: 1}},
+		{"nplurals=1;  plural=0", "en", map[int]int{0: 0, 1: 0, 5: 0}},
+		// Russian
+		{
+			"nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);", "",
+			map[int]int{1: 0, 2: 1, 5: 2, 11: 2, 21: 0, 22: 1, 111: 2, 104: 1},
+		},
+		// non-standard rules fall back to the language's rule
+		{"nplurals=2; plural=n == 1 ? 0 : 1;", "fr", map[int]int{0: 0, 1: 0, 2: 1}},
+		{"", "pl_PL", map[int]int{1: 0, 2: 1, 5: 2, 22: 1}},
+		{"", "pt_BR", map[int]int{0: 0, 1: 0, 2: 1}},
+		{"", "pt", map[int]int{0: 1, 1: 0, 2: 1}},
+		{"", "xx", map[int]int{0: 1, 1: 0, 2: 1}},
+	}
+
+	for _, d := range testdata {
+		f := pluralRule(d.forms, d.lang)
+		for n, expected := range d.expected {
+			assert.Equal(t, expected, f(n), "%q/%q, n=%d", d.forms, d.lang, n)
+		}
+	}
+}
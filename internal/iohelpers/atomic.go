@@ -0,0 +1,109 @@
+package iohelpers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/hack-pad/hackpadfs"
+)
+
+type atomicFile struct {
+	fsys    fs.FS
+	f       fs.File
+	name    string
+	tmpName string
+}
+
+var (
+	_ io.WriteCloser = (*atomicFile)(nil)
+	_ Aborter        = (*atomicFile)(nil)
+)
+
+// AtomicFile creates an io.WriteCloser that writes to a temporary file in the
+// same directory as the named file, which is synced and renamed into place
+// when closed. This way the file is never seen partially written, even if
+// writing fails part-way through. Call Abort instead of Close to discard the
+// output, leaving any existing file untouched.
+//
+// The file is given the mode exactly, regardless of the umask. Since an
+// existing file is replaced rather than overwritten, its owner isn't kept (the
+// new file is owned by the current user), and any hard links to it keep
+// referring to the old content.
+func AtomicFile(fsys fs.FS, filename string, mode fs.FileMode) (io.WriteCloser, error) {
+	dir, base := filepath.Dir(filename), filepath.Base(filename)
+
+	for i := 0; i < 100; i++ {
+		suffix := make([]byte, 4)
+		_, err := rand.Read(suffix)
+		if err != nil {
+			return nil, err
+		}
+
+		tmpName := filepath.Join(dir, "."+base+".tmp-"+hex.EncodeToString(suffix))
+
+		f, err := hackpadfs.OpenFile(fsys, tmpName, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary file for %q: %w", filename, err)
+		}
+
+		// the mode given when creating the file is subject to the umask, so
+		// it must be set explicitly
+		err = hackpadfs.Chmod(fsys, tmpName, mode)
+		if err != nil && !errors.Is(err, hackpadfs.ErrNotImplemented) {
+			_ = f.Close()
+			_ = hackpadfs.Remove(fsys, tmpName)
+			return nil, fmt.Errorf("failed to set mode of temporary file for %q: %w", filename, err)
+		}
+
+		return &atomicFile{fsys: fsys, f: f, name: filename, tmpName: tmpName}, nil
+	}
+
+	return nil, fmt.Errorf("failed to create temporary file for %q: %w", filename, fs.ErrExist)
+}
+
+func (a *atomicFile) Write(p []byte) (int, error) {
+	return hackpadfs.WriteFile(a.f, p)
+}
+
+// Close - syncs the temporary file and renames it into place
+func (a *atomicFile) Close() error {
+	err := hackpadfs.SyncFile(a.f)
+	if errors.Is(err, hackpadfs.ErrNotImplemented) {
+		// not all filesystems support syncing
+		err = nil
+	}
+
+	if cerr := a.f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err == nil {
+		err = hackpadfs.Rename(a.fsys, a.tmpName, a.name)
+	}
+
+	if err != nil {
+		_ = hackpadfs.Remove(a.fsys, a.tmpName)
+		return fmt.Errorf("failed to write %q: %w", a.name, err)
+	}
+
+	return nil
+}
+
+// Abort - discards the temporary file
+func (a *atomicFile) Abort() error {
+	err := a.f.Close()
+	if rerr := hackpadfs.Remove(a.fsys, a.tmpName); err == nil {
+		err = rerr
+	}
+
+	return err
+}
@@ -0,0 +1,71 @@
+package iohelpers_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	osfs "github.com/hack-pad/hackpadfs/os"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtomicFile(t *testing.T) {
+	dir := t.TempDir()
+	fsys := datafs.WrapWdFS(osfs.NewFS())
+
+	foopath := filepath.Join(dir, "foo")
+	require.NoError(t, os.WriteFile(foopath, []byte("old"), 0o600))
+
+	w, err := iohelpers.AtomicFile(fsys, foopath, 0o640)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("new content"))
+	require.NoError(t, err)
+
+	// the original file is untouched until the write is complete
+	out, err := fs.ReadFile(fsys, foopath)
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(out))
+
+	require.NoError(t, w.Close())
+
+	out, err = fs.ReadFile(fsys, foopath)
+	require.NoError(t, err)
+	assert.Equal(t, "new content", string(out))
+
+	if runtime.GOOS != "windows" {
+		fi, err := os.Stat(foopath)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o640), fi.Mode().Perm())
+	}
+
+	// the temporary file is gone
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// aborted writes are discarded
+	w, err = iohelpers.AtomicFile(fsys, foopath, 0o640)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("partial"))
+	require.NoError(t, err)
+	require.NoError(t, iohelpers.Abort(w))
+
+	out, err = fs.ReadFile(fsys, foopath)
+	require.NoError(t, err)
+	assert.Equal(t, "new content", string(out))
+
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// the directory must exist
+	_, err = iohelpers.AtomicFile(fsys, filepath.Join(dir, "missing", "foo"), 0o640)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
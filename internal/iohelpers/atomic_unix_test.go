@@ -0,0 +1,43 @@
+//go:build !windows
+
+package iohelpers_test
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	osfs "github.com/hack-pad/hackpadfs/os"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtomicFileMode_UNIX(t *testing.T) {
+	// the umask would otherwise remove the group write bit
+	oldUmask := syscall.Umask(0o022)
+	defer syscall.Umask(oldUmask)
+
+	dir := t.TempDir()
+	fsys := datafs.WrapWdFS(osfs.NewFS())
+
+	foopath := filepath.Join(dir, "foo")
+	require.NoError(t, os.WriteFile(foopath, []byte("old"), 0o600))
+	require.NoError(t, os.Chmod(foopath, 0o664))
+
+	fi, err := os.Stat(foopath)
+	require.NoError(t, err)
+
+	w, err := iohelpers.AtomicFile(fsys, foopath, fi.Mode().Perm())
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("new"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	fi, err = os.Stat(foopath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o664), fi.Mode().Perm())
+}
@@ -7,19 +7,33 @@ import (
 const (
 	TextMimetype      = "text/plain"
 	CSVMimetype       = "text/csv"
+	TSVMimetype       = "text/tab-separated-values"
 	JSONMimetype      = "application/json"
 	JSONArrayMimetype = "application/array+json"
 	TOMLMimetype      = "application/toml"
 	YAMLMimetype      = "application/yaml"
 	EnvMimetype       = "application/x-env"
 	CUEMimetype       = "application/cue"
+	XMLMimetype       = "application/xml"
+	INIMimetype       = "application/x-ini"
+	PropsMimetype     = "text/x-java-properties"
+	NDJSONMimetype    = "application/x-ndjson"
+	ProtobufMimetype  = "application/x-protobuf"
+	MsgpackMimetype   = "application/msgpack"
+	CBORMimetype      = "application/cbor"
+	BinaryMimetype    = "application/octet-stream"
 )
 
 // mimeTypeAliases defines a mapping for non-canonical mime types that are
 // sometimes seen in the wild
 var mimeTypeAliases = map[string]string{
-	"application/x-yaml": YAMLMimetype,
-	"application/text":   TextMimetype,
+	"application/x-yaml":      YAMLMimetype,
+	"application/text":        TextMimetype,
+	"text/xml":                XMLMimetype,
+	"application/jsonl":       NDJSONMimetype,
+	"application/protobuf":    ProtobufMimetype,
+	"application/x-msgpack":   MsgpackMimetype,
+	"application/vnd.msgpack": MsgpackMimetype,
 }
 
 func MimeAlias(m string) string {
@@ -70,6 +70,11 @@ func (f *emptySkipper) Close() error {
 	return nil
 }
 
+// Abort - implements Aborter
+func (f *emptySkipper) Abort() error {
+	return Abort(f.w)
+}
+
 func allWhitespace(p []byte) bool {
 	for _, b := range p {
 		if b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\v' {
@@ -80,6 +85,25 @@ func allWhitespace(p []byte) bool {
 	return true
 }
 
+// Aborter is implemented by writers which can discard their output instead of
+// committing it, such as when rendering fails part-way through.
+type Aborter interface {
+	Abort() error
+}
+
+// Abort discards the output written to w if it's an Aborter, and otherwise
+// closes it if it's an io.Closer.
+func Abort(w io.Writer) error {
+	switch w := w.(type) {
+	case Aborter:
+		return w.Abort()
+	case io.Closer:
+		return w.Close()
+	default:
+		return nil
+	}
+}
+
 // NopCloser returns a WriteCloser with a no-op Close method wrapping
 // the provided io.Writer.
 type NopCloser struct {
@@ -95,6 +119,8 @@ var (
 	_ io.WriteCloser = (*NopCloser)(nil)
 	_ io.WriteCloser = (*emptySkipper)(nil)
 	_ io.WriteCloser = (*sameSkipper)(nil)
+	_ Aborter        = (*emptySkipper)(nil)
+	_ Aborter        = (*sameSkipper)(nil)
 )
 
 type sameSkipper struct {
@@ -125,12 +151,14 @@ func SameSkipper(r io.Reader, open func() (io.WriteCloser, error)) io.WriteClose
 // then flushes and writes to the wrapped writer.
 func (f *sameSkipper) Write(p []byte) (n int, err error) {
 	if !f.diff {
+		// read exactly as much as is being written, so that short reads (at
+		// the reader's buffer boundaries) aren't mistaken for differences
 		in := make([]byte, len(p))
-		_, err := f.r.Read(in)
-		if err != nil && err != io.EOF {
+		n, err := io.ReadFull(f.r, in)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
 			return 0, fmt.Errorf("failed to read: %w", err)
 		}
-		if bytes.Equal(in, p) {
+		if bytes.Equal(in[:n], p) {
 			return f.buf.Write(p)
 		}
 
@@ -177,6 +205,15 @@ func (f *sameSkipper) Close() error {
 	return nil
 }
 
+// Abort - implements Aborter
+func (f *sameSkipper) Abort() error {
+	// nothing has been written yet
+	if f.w == nil {
+		return nil
+	}
+	return Abort(f.w)
+}
+
 // LazyWriteCloser provides an interface to a WriteCloser that will open on the
 // first access. The wrapped io.WriteCloser must be provided by 'open'.
 func LazyWriteCloser(open func() (io.WriteCloser, error)) io.WriteCloser {
@@ -211,6 +248,13 @@ func (l *lazyWriteCloser) Close() error {
 	return w.Close()
 }
 
+// Abort - implements Aborter. The wrapped writer is not opened if it hasn't
+// been already.
+func (l *lazyWriteCloser) Abort() error {
+	l.opened.Do(func() {})
+	return Abort(l.w)
+}
+
 func (l *lazyWriteCloser) Write(p []byte) (n int, err error) {
 	w, err := l.openWriter()
 	if err != nil {
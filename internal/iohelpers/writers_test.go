@@ -123,6 +123,24 @@ func TestSameSkipper(t *testing.T) {
 	}
 }
 
+func TestSameSkipperMultipleWrites(t *testing.T) {
+	// larger than the read buffer, so some writes straddle buffer boundaries
+	content := bytes.Repeat([]byte("0123456789abcdefghi\n"), 1000)
+
+	opened := false
+	f := SameSkipper(bytes.NewReader(content), func() (io.WriteCloser, error) {
+		opened = true
+		return newBufferCloser(&bytes.Buffer{}), nil
+	})
+
+	for i := 0; i < len(content); i += 30 {
+		_, err := f.Write(content[i:min(i+30, len(content))])
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+	assert.False(t, opened)
+}
+
 func TestLazyWriteCloser(t *testing.T) {
 	w := newBufferCloser(&bytes.Buffer{})
 	opened := false
@@ -0,0 +1,140 @@
+// Package jsonschema validates data against JSON Schema documents, using
+// github.com/santhosh-tekuri/jsonschema.
+//
+// Schemas without a '$schema' keyword are treated as draft 2020-12. The
+// 'format' keyword is treated as an annotation (except in schemas for drafts
+// before 2019-09, where it's asserted), and only references within the schema
+// itself (like '#/$defs/server') can be resolved.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// schemaURL is the URL the schema is compiled at - it's never loaded, but
+// references within the schema are resolved relative to it
+const schemaURL = "mem:///schema.json"
+
+// ValidationError is returned when an instance doesn't match its schema, and
+// describes every failing location
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// FieldError describes a single validation failure
+type FieldError struct {
+	// Path is a JSON Pointer (RFC 6901) to the failing value in the instance
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	sb := &strings.Builder{}
+	sb.WriteString("JSON Schema validation failed:")
+
+	for _, fe := range e.Errors {
+		p := fe.Path
+		if p == "" {
+			p = "(root)"
+		}
+
+		fmt.Fprintf(sb, "\n- %s: %s", p, fe.Message)
+	}
+
+	return sb.String()
+}
+
+// Validate - validate the instance against the schema. Both are converted to
+// their JSON representations first, so any value which can be marshalled as
+// JSON can be validated. A *ValidationError is returned when the instance is
+// invalid, and other errors are returned when the schema itself is invalid.
+func Validate(schema, instance interface{}) error {
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+
+	c := jsonschema.NewCompiler()
+	c.Draft = jsonschema.Draft2020
+	c.LoadURL = func(s string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("can't load %s: only references within the schema are supported", s)
+	}
+
+	err = c.AddResource(schemaURL, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+
+	s, err := c.Compile(schemaURL)
+	if err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+
+	in, err := normalize(instance)
+	if err != nil {
+		return fmt.Errorf("unable to validate %T: %w", instance, err)
+	}
+
+	err = s.Validate(in)
+
+	var verr *jsonschema.ValidationError
+	if errors.As(err, &verr) {
+		return &ValidationError{Errors: fieldErrors(verr)}
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+
+	return nil
+}
+
+// normalize converts the value to the types produced by decoding JSON, with
+// numbers decoded as json.Number
+func normalize(in interface{}) (interface{}, error) {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var out interface{}
+	err = dec.Decode(&out)
+
+	return out, err
+}
+
+// fieldErrors flattens the tree of validation errors to the leaf errors, which
+// describe the actual failures, sorted by path
+func fieldErrors(verr *jsonschema.ValidationError) []FieldError {
+	var errs []FieldError
+
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			errs = append(errs, FieldError{Path: e.InstanceLocation, Message: e.Message})
+			return
+		}
+
+		for _, c := range e.Causes {
+			walk(c)
+		}
+	}
+	walk(verr)
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Path < errs[j].Path
+	})
+
+	return errs
+}
@@ -0,0 +1,181 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParse(t *testing.T, s string) interface{} {
+	t.Helper()
+
+	var out interface{}
+	require.NoError(t, json.Unmarshal([]byte(s), &out))
+
+	return out
+}
+
+func TestValidate(t *testing.T) {
+	schema := mustParse(t, `{
+		"type": "object",
+		"required": ["name", "port"],
+		"properties": {
+			"name": {"type": "string", "minLength": 1, "pattern": "^[a-z]+$"},
+			"port": {"type": "integer", "minimum": 1, "maximum": 65535},
+			"ratio": {"type": "number", "exclusiveMaximum": 1, "multipleOf": 0.25},
+			"env": {"enum": ["dev", "prod"]},
+			"tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true, "maxItems": 3},
+			"servers": {"type": "array", "items": {"$ref": "#/$defs/server"}}
+		},
+		"additionalProperties": false,
+		"$defs": {
+			"server": {
+				"type": "object",
+				"required": ["host"],
+				"properties": {"host": {"type": "string"}}
+			}
+		}
+	}`)
+
+	valid := []interface{}{
+		map[string]interface{}{"name": "web", "port": 8080},
+		map[string]interface{}{"name": "web", "port": 8080.0, "ratio": 0.75},
+		map[string]interface{}{"name": "web", "port": 80, "env": "prod", "tags": []string{"a", "b"}},
+		map[string]interface{}{"name": "web", "port": 80, "servers": []map[string]string{{"host": "a"}}},
+	}
+
+	for _, in := range valid {
+		assert.NoError(t, Validate(schema, in), in)
+	}
+
+	testdata := []struct {
+		in       interface{}
+		path     string
+		contains string
+	}{
+		{map[string]interface{}{"port": 80}, "", "missing properties: 'name'"},
+		{map[string]interface{}{"name": "web", "port": "80"}, "/port", "expected integer, but got string"},
+		{map[string]interface{}{"name": "web", "port": 1.5}, "/port", "expected integer, but got number"},
+		{map[string]interface{}{"name": "web", "port": 70000}, "/port", "must be <= 65535"},
+		{map[string]interface{}{"name": "", "port": 80}, "/name", "length must be >= 1"},
+		{map[string]interface{}{"name": "Web", "port": 80}, "/name", "pattern"},
+		{map[string]interface{}{"name": "web", "port": 80, "ratio": 1}, "/ratio", "must be < 1"},
+		{map[string]interface{}{"name": "web", "port": 80, "ratio": 0.3}, "/ratio", "not multipleOf 0.25"},
+		{map[string]interface{}{"name": "web", "port": 80, "env": "test"}, "/env", `one of "dev", "prod"`},
+		{map[string]interface{}{"name": "web", "port": 80, "tags": []int{1}}, "/tags/0", "expected string"},
+		{map[string]interface{}{"name": "web", "port": 80, "tags": []string{"a", "a"}}, "/tags", "items at index 0 and 1 are equal"},
+		{map[string]interface{}{"name": "web", "port": 80, "tags": []string{"a", "b", "c", "d"}}, "/tags", "maximum 3 items"},
+		{map[string]interface{}{"name": "web", "port": 80, "extra": true}, "", "'extra' not allowed"},
+		{map[string]interface{}{"name": "web", "port": 80, "servers": []interface{}{map[string]interface{}{}}}, "/servers/0", "missing properties: 'host'"},
+		{"hello", "", "expected object, but got string"},
+	}
+
+	for _, d := range testdata {
+		err := Validate(schema, d.in)
+
+		var verr *ValidationError
+		require.ErrorAs(t, err, &verr, d.in)
+		require.NotEmpty(t, verr.Errors)
+		assert.Equal(t, d.path, verr.Errors[0].Path, d.in)
+		assert.Contains(t, verr.Errors[0].Message, d.contains, d.in)
+	}
+
+	// all failures are reported
+	err := Validate(schema, map[string]interface{}{"name": 42, "port": 0})
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Len(t, verr.Errors, 2)
+	assert.Equal(t, `JSON Schema validation failed:
+- /name: expected string, but got number
+- /port: must be >= 1 but found 0`, err.Error())
+}
+
+func TestValidateCombinators(t *testing.T) {
+	schema := mustParse(t, `{
+		"anyOf": [{"type": "string"}, {"type": "integer"}],
+		"not": {"const": "forbidden"}
+	}`)
+
+	require.NoError(t, Validate(schema, "hello"))
+	require.NoError(t, Validate(schema, 42))
+	require.Error(t, Validate(schema, true))
+	require.Error(t, Validate(schema, "forbidden"))
+
+	schema = mustParse(t, `{"oneOf": [{"type": "number"}, {"type": "integer"}]}`)
+	require.NoError(t, Validate(schema, 1.5))
+	require.Error(t, Validate(schema, 1))
+
+	schema = mustParse(t, `{
+		"if": {"properties": {"tls": {"const": true}}},
+		"then": {"required": ["cert"]},
+		"else": {"properties": {"cert": false}}
+	}`)
+	require.NoError(t, Validate(schema, map[string]interface{}{"tls": true, "cert": "x"}))
+	require.NoError(t, Validate(schema, map[string]interface{}{"tls": false}))
+	require.Error(t, Validate(schema, map[string]interface{}{"tls": true}))
+	require.Error(t, Validate(schema, map[string]interface{}{"tls": false, "cert": "x"}))
+
+	schema = mustParse(t, `{"allOf": [{"minimum": 1}, {"maximum": 10}]}`)
+	require.NoError(t, Validate(schema, 5))
+	require.Error(t, Validate(schema, 11))
+}
+
+func TestValidateDraft07(t *testing.T) {
+	schema := mustParse(t, `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"definitions": {"port": {"type": "integer"}},
+		"type": "object",
+		"properties": {
+			"pair": {"type": "array", "items": [{"type": "string"}, {"$ref": "#/definitions/port"}], "additionalItems": false}
+		},
+		"dependencies": {"user": ["password"]}
+	}`)
+
+	require.NoError(t, Validate(schema, map[string]interface{}{"pair": []interface{}{"a", 1}}))
+	require.Error(t, Validate(schema, map[string]interface{}{"pair": []interface{}{"a", "b"}}))
+	require.Error(t, Validate(schema, map[string]interface{}{"pair": []interface{}{"a", 1, 2}}))
+	require.NoError(t, Validate(schema, map[string]interface{}{"user": "u", "password": "p"}))
+	require.Error(t, Validate(schema, map[string]interface{}{"user": "u"}))
+}
+
+func TestValidateArrays(t *testing.T) {
+	schema := mustParse(t, `{
+		"prefixItems": [{"const": "header"}],
+		"items": {"type": "integer"},
+		"contains": {"type": "integer", "minimum": 10},
+		"minContains": 2
+	}`)
+
+	require.NoError(t, Validate(schema, []interface{}{"header", 10, 11, 1}))
+	require.Error(t, Validate(schema, []interface{}{"header", 10, 1}))
+	require.Error(t, Validate(schema, []interface{}{"header", 10, 11, "x"}))
+}
+
+func TestValidateInvalidSchema(t *testing.T) {
+	testdata := []string{
+		`"a string"`,
+		`{"type": 42}`,
+		`{"pattern": "("}`,
+		`{"$ref": "https://example.com/schema.json"}`,
+		`{"$ref": "file:///etc/hosts"}`,
+		`{"$ref": "#/$defs/missing"}`,
+		`{"$ref": "#"}`,
+		`{"minLength": -1}`,
+		`{"anyOf": []}`,
+	}
+
+	for _, d := range testdata {
+		err := Validate(mustParse(t, d), "hello")
+		require.Error(t, err, d)
+
+		var verr *ValidationError
+		assert.False(t, errors.As(err, &verr), d)
+	}
+
+	require.NoError(t, Validate(true, "anything"))
+	require.Error(t, Validate(false, "anything"))
+}
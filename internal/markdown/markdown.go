@@ -0,0 +1,32 @@
+// Package markdown renders Markdown as HTML, using github.com/yuin/goldmark.
+//
+// Input is parsed as CommonMark, with the GitHub Flavored Markdown extensions
+// (tables, strikethrough, autolinks, and task lists). Raw HTML in the input is
+// omitted rather than passed through, and links and images with unsafe URLs
+// (like "javascript:") have their URLs removed, so the output is safe to embed
+// in other HTML documents.
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+var md = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+)
+
+// ToHTML renders the given Markdown as HTML.
+func ToHTML(in string) (string, error) {
+	buf := &bytes.Buffer{}
+
+	err := md.Convert([]byte(in), buf)
+	if err != nil {
+		return "", fmt.Errorf("failed to render markdown: %w", err)
+	}
+
+	return buf.String(), nil
+}
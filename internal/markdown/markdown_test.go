@@ -0,0 +1,41 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToHTML(t *testing.T) {
+	testdata := []struct{ in, out string }{
+		{"", ""},
+		{"# Hello *world*", "<h1>Hello <em>world</em></h1>\n"},
+		{"foo **bar** _baz_ ~~qux~~\nnext  \nline", "<p>foo <strong>bar</strong> <em>baz</em> <del>qux</del>\nnext<br>\nline</p>\n"},
+		{"<script>alert(1)</script>", "<!-- raw HTML omitted -->\n"},
+		{"some <b>text</b>", "<p>some <!-- raw HTML omitted -->text<!-- raw HTML omitted --></p>\n"},
+		{
+			`[x](javascript:alert(1)) [y](https://e.com "T") ![i](/a.png)`,
+			`<p><a href="">x</a> <a href="https://e.com" title="T">y</a> <img src="/a.png" alt="i"></p>` + "\n",
+		},
+		{
+			"https://x.io and <me@x.io>",
+			`<p><a href="https://x.io">https://x.io</a> and <a href="mailto:me@x.io">me@x.io</a></p>` + "\n",
+		},
+		{
+			"| a | b |\n|---|---|\n| 1 | 2 |",
+			"<table>\n<thead>\n<tr>\n<th>a</th>\n<th>b</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>1</td>\n<td>2</td>\n</tr>\n</tbody>\n</table>\n",
+		},
+		{
+			"- [x] done\n- [ ] todo",
+			"<ul>\n<li><input checked=\"\" disabled=\"\" type=\"checkbox\"> done</li>\n<li><input disabled=\"\" type=\"checkbox\"> todo</li>\n</ul>\n",
+		},
+		{"```go\nfunc <x>\n```\n", "<pre><code class=\"language-go\">func &lt;x&gt;\n</code></pre>\n"},
+	}
+
+	for _, d := range testdata {
+		out, err := ToHTML(d.in)
+		require.NoError(t, err)
+		assert.Equal(t, d.out, out, d.in)
+	}
+}
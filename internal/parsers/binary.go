@@ -0,0 +1,79 @@
+package parsers
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/ugorji/go/codec"
+)
+
+var mapStringInterfaceType = reflect.TypeOf(map[string]interface{}(nil))
+
+func msgpackHandle() *codec.MsgpackHandle {
+	h := &codec.MsgpackHandle{}
+	h.MapType = mapStringInterfaceType
+	h.RawToString = true
+	h.SignedInteger = true
+	h.WriteExt = true
+	h.Canonical = true
+
+	return h
+}
+
+func cborHandle() *codec.CborHandle {
+	h := &codec.CborHandle{}
+	h.MapType = mapStringInterfaceType
+	h.SignedInteger = true
+	h.Canonical = true
+
+	return h
+}
+
+// Msgpack - Unmarshal a MessagePack document. Maps are returned with string
+// keys, integers are returned as int64s, and binary values are returned as
+// byte arrays.
+func Msgpack(in string) (interface{}, error) {
+	return decodeBinary(msgpackHandle(), "MessagePack", in)
+}
+
+// CBOR - Unmarshal a CBOR (RFC 8949) document. Maps are returned with string
+// keys, integers are returned as int64s, and byte strings are returned as byte
+// arrays.
+func CBOR(in string) (interface{}, error) {
+	return decodeBinary(cborHandle(), "CBOR", in)
+}
+
+// ToMsgpack - Serialize a value as MessagePack. Map keys are sorted, so the
+// output is deterministic.
+func ToMsgpack(in interface{}) (string, error) {
+	return encodeBinary(msgpackHandle(), "MessagePack", in)
+}
+
+// ToCBOR - Serialize a value as CBOR (RFC 8949). Map keys are sorted, so the
+// output is deterministic.
+func ToCBOR(in interface{}) (string, error) {
+	return encodeBinary(cborHandle(), "CBOR", in)
+}
+
+func decodeBinary(h codec.Handle, format, in string) (interface{}, error) {
+	var out interface{}
+
+	err := codec.NewDecoderBytes([]byte(in), h).Decode(&out)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal %s: %w", format, err)
+	}
+
+	return out, nil
+}
+
+func encodeBinary(h codec.Handle, format string, in interface{}) (string, error) {
+	buf := &bytes.Buffer{}
+
+	err := codec.NewEncoder(buf, h).Encode(in)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal %s: %w", format, err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,83 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgpack(t *testing.T) {
+	out, err := Msgpack("\x81\xa1a\x01")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": int64(1)}, out)
+
+	out, err = Msgpack("\xc4\x02\x01\x02")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, out)
+
+	_, err = Msgpack("\x81\xa1")
+	require.Error(t, err)
+
+	s, err := ToMsgpack(map[string]interface{}{"a": 1})
+	require.NoError(t, err)
+	assert.Equal(t, "\x81\xa1a\x01", s)
+}
+
+func TestCBOR(t *testing.T) {
+	out, err := CBOR("\xa1\x61a\x01")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": int64(1)}, out)
+
+	out, err = CBOR("\x42\x01\x02")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, out)
+
+	_, err = CBOR("\xa1\x61")
+	require.Error(t, err)
+
+	s, err := ToCBOR(map[string]interface{}{"a": 1})
+	require.NoError(t, err)
+	assert.Equal(t, "\xa1\x61a\x01", s)
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	in := map[string]interface{}{
+		"name":    "web",
+		"port":    8080,
+		"enabled": true,
+		"ratio":   0.5,
+		"tags":    []interface{}{"a", "b"},
+		"nested":  map[string]interface{}{"x": -1},
+	}
+
+	expected := map[string]interface{}{
+		"name":    "web",
+		"port":    int64(8080),
+		"enabled": true,
+		"ratio":   0.5,
+		"tags":    []interface{}{"a", "b"},
+		"nested":  map[string]interface{}{"x": int64(-1)},
+	}
+
+	s, err := ToMsgpack(in)
+	require.NoError(t, err)
+
+	out, err := Msgpack(s)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	s, err = ToCBOR(in)
+	require.NoError(t, err)
+
+	out, err = CBOR(s)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	// canonical output doesn't depend on map iteration order
+	for range 10 {
+		s2, err := ToCBOR(in)
+		require.NoError(t, err)
+		assert.Equal(t, s, s2)
+	}
+}
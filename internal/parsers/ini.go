@@ -0,0 +1,154 @@
+package parsers
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+)
+
+// INI - Unmarshal an INI document. Keys outside of any section are returned at
+// the top level, and each section is returned as a map of its keys. All values
+// are strings.
+func INI(in string) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	cur := out
+
+	s := bufio.NewScanner(strings.NewReader(in))
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+
+		if line[0] == '[' {
+			if line[len(line)-1] != ']' {
+				return nil, fmt.Errorf("unable to parse INI: invalid section header on line %d: %q", n, line)
+			}
+
+			name := strings.TrimSpace(line[1 : len(line)-1])
+
+			section, ok := out[name].(map[string]interface{})
+			if !ok {
+				if _, exists := out[name]; exists {
+					return nil, fmt.Errorf("unable to parse INI: section %q on line %d conflicts with a key of the same name", name, n)
+				}
+
+				section = map[string]interface{}{}
+				out[name] = section
+			}
+
+			cur = section
+
+			continue
+		}
+
+		// keys with no value (like MySQL's "skip-name-resolve") are allowed
+		sep := "="
+		if !strings.Contains(line, sep) {
+			sep = ":"
+		}
+
+		k, v, _ := strings.Cut(line, sep)
+
+		k = strings.TrimSpace(k)
+		if k == "" {
+			return nil, fmt.Errorf("unable to parse INI: missing key on line %d: %q", n, line)
+		}
+
+		cur[k] = unquoteINIValue(strings.TrimSpace(v))
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("unable to parse INI: %w", err)
+	}
+
+	return out, nil
+}
+
+func unquoteINIValue(v string) string {
+	if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') && v[len(v)-1] == v[0] {
+		return v[1 : len(v)-1]
+	}
+
+	return v
+}
+
+// ToINI - Stringify a map as an INI document. Top-level values which are maps
+// are written as sections, after all other top-level keys. Keys are sorted.
+func ToINI(in interface{}) (string, error) {
+	m, ok := toStringMap(in)
+	if !ok {
+		return "", fmt.Errorf("unable to marshal %T as INI: must be a map", in)
+	}
+
+	sb := &strings.Builder{}
+	sections := []string{}
+
+	for _, k := range sortedKeys(m) {
+		if _, ok := toStringMap(m[k]); ok {
+			sections = append(sections, k)
+			continue
+		}
+
+		fmt.Fprintf(sb, "%s = %s\n", k, conv.ToString(m[k]))
+	}
+
+	for _, name := range sections {
+		section, _ := toStringMap(m[name])
+
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+
+		fmt.Fprintf(sb, "[%s]\n", name)
+
+		for _, k := range sortedKeys(section) {
+			if _, ok := toStringMap(section[k]); ok {
+				return "", fmt.Errorf("unable to marshal INI: nested sections are not supported (in [%s], key %q)", name, k)
+			}
+
+			fmt.Fprintf(sb, "%s = %s\n", k, conv.ToString(section[k]))
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// toStringMap converts the map types commonly produced by the parsers to
+// map[string]interface{}
+func toStringMap(in interface{}) (map[string]interface{}, bool) {
+	switch m := in.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[string]string:
+		out := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			out[k] = v
+		}
+
+		return out, true
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			out[conv.ToString(k)] = v
+		}
+
+		return out, true
+	default:
+		return nil, false
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,87 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestINI(t *testing.T) {
+	in := `; comment
+global = 1
+
+[server]
+host = example.com
+port: 8080
+name = "quoted value"
+skip-name-resolve
+# another comment
+
+[server]
+extra = x=y
+
+[empty]
+`
+	expected := map[string]interface{}{
+		"global": "1",
+		"server": map[string]interface{}{
+			"host":              "example.com",
+			"port":              "8080",
+			"name":              "quoted value",
+			"skip-name-resolve": "",
+			"extra":             "x=y",
+		},
+		"empty": map[string]interface{}{},
+	}
+
+	out, err := INI(in)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	_, err = INI("[bad\n")
+	require.Error(t, err)
+
+	_, err = INI("a = 1\n[a]\n")
+	require.Error(t, err)
+
+	_, err = INI("= 1\n")
+	require.Error(t, err)
+}
+
+func TestToINI(t *testing.T) {
+	in := map[string]interface{}{
+		"z": 1,
+		"a": "b",
+		"sec": map[string]interface{}{
+			"k": "v",
+			"n": 2,
+		},
+		"abc": map[interface{}]interface{}{"x": "y"},
+	}
+	expected := `a = b
+z = 1
+
+[abc]
+x = y
+
+[sec]
+k = v
+n = 2
+`
+
+	out, err := ToINI(in)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	// round-trip
+	parsed, err := INI(out)
+	require.NoError(t, err)
+	assert.Equal(t, "v", parsed["sec"].(map[string]interface{})["k"])
+
+	_, err = ToINI(map[string]interface{}{"sec": map[string]interface{}{"k": map[string]interface{}{}}})
+	require.Error(t, err)
+
+	_, err = ToINI([]string{"foo"})
+	require.Error(t, err)
+}
@@ -8,10 +8,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"reflect"
+	"regexp"
+	"sort"
 	"strings"
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
+	cueerrors "cuelang.org/go/cue/errors"
 	"cuelang.org/go/cue/format"
 	"github.com/Shopify/ejson"
 	ejsonJson "github.com/Shopify/ejson/json"
@@ -106,6 +110,38 @@ func JSONArray(in string) ([]interface{}, error) {
 	return unmarshalArray(obj, in, yaml.Unmarshal)
 }
 
+// NDJSON - Unmarshal newline-delimited JSON (also known as JSON Lines) into an
+// array, with one element per line. Blank lines are ignored.
+func NDJSON(in string) ([]interface{}, error) {
+	out := []interface{}{}
+
+	for n, line := range strings.Split(in, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if !json.Valid([]byte(line)) {
+			return nil, fmt.Errorf("unable to unmarshal NDJSON: invalid JSON on line %d", n+1)
+		}
+
+		// unmarshal with the YAML parser for consistency with JSON, so that
+		// integers aren't converted to floats
+		var v interface{}
+		if err := yaml.Unmarshal([]byte(line), &v); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal NDJSON on line %d: %w", n+1, err)
+		}
+
+		if sv, changed := stringifyMapKeys(v); changed {
+			v = sv
+		}
+
+		out = append(out, v)
+	}
+
+	return out, nil
+}
+
 // YAML - Unmarshal a YAML Object
 func YAML(in string) (map[string]interface{}, error) {
 	obj := make(map[string]interface{})
@@ -149,6 +185,33 @@ func YAMLArray(in string) ([]interface{}, error) {
 	return obj, err
 }
 
+// YAMLDocs - Unmarshal all documents in a multi-document YAML stream into an
+// array, skipping empty documents
+func YAMLDocs(in string) ([]interface{}, error) {
+	docs := []interface{}{}
+	d := yaml.NewDecoder(strings.NewReader(in))
+	for {
+		var doc interface{}
+		err := d.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if doc == nil {
+			continue
+		}
+
+		if v, changed := stringifyMapKeys(doc); changed {
+			doc = v
+		}
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
 // stringifyYAMLArrayMapKeys recurses into the input array and changes all
 // non-string map keys to string map keys. Modifies the input array.
 func stringifyYAMLArrayMapKeys(in []interface{}) error {
@@ -224,6 +287,52 @@ func DotEnv(in string) (interface{}, error) {
 	return out, nil
 }
 
+// ToEnv - Stringify a map as a dotenv file. Keys are sorted, and must be valid
+// shell variable names. Values are quoted when necessary, so that the output
+// can be parsed by DotEnv (and sourced by a POSIX shell).
+func ToEnv(in interface{}) (string, error) {
+	m, ok := toStringMap(in)
+	if !ok {
+		return "", fmt.Errorf("unable to marshal %T as dotenv: must be a map", in)
+	}
+
+	sb := &strings.Builder{}
+
+	for _, k := range sortedKeys(m) {
+		if !envKeyRegexp.MatchString(k) {
+			return "", fmt.Errorf("unable to marshal dotenv: invalid key %q", k)
+		}
+
+		if _, ok := toStringMap(m[k]); ok {
+			return "", fmt.Errorf("unable to marshal dotenv: value for key %q must not be a map", k)
+		}
+
+		fmt.Fprintf(sb, "%s=%s\n", k, quoteEnvValue(conv.ToString(m[k])))
+	}
+
+	return sb.String(), nil
+}
+
+//nolint:gochecknoglobals
+var (
+	envKeyRegexp        = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	envUnquotedRegexp   = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,=-]*$`)
+	envDoubleQuoteChars = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`, "\r", `\r`)
+)
+
+// quoteEnvValue quotes the value if necessary - single quotes are preferred,
+// as no escapes or variables are expanded within them
+func quoteEnvValue(v string) string {
+	switch {
+	case envUnquotedRegexp.MatchString(v):
+		return v
+	case !strings.ContainsAny(v, "'\n\r"):
+		return "'" + v + "'"
+	default:
+		return `"` + envDoubleQuoteChars.Replace(v) + `"`
+	}
+}
+
 func parseCSV(args ...string) ([][]string, []string, error) {
 	in, delim, hdr := csvParseArgs(args...)
 	c := csv.NewReader(strings.NewReader(in))
@@ -372,7 +481,32 @@ func ToCSV(args ...interface{}) (string, error) {
 			for i, v := range a {
 				in[i] = conv.ToStrings(v...)
 			}
+		case []map[string]string:
+			rows := make([]map[string]interface{}, len(a))
+			for i, v := range a {
+				rows[i] = make(map[string]interface{}, len(v))
+				for k, cell := range v {
+					rows[i][k] = cell
+				}
+			}
+			in = csvRecordsFromMaps(rows)
+		case []map[string]interface{}:
+			in = csvRecordsFromMaps(a)
 		case []interface{}:
+			if len(a) > 0 {
+				if _, ok := a[0].(map[string]interface{}); ok {
+					rows := make([]map[string]interface{}, len(a))
+					for i, v := range a {
+						rows[i], ok = v.(map[string]interface{})
+						if !ok {
+							return "", fmt.Errorf("can't parse ToCSV input - all rows must be maps (was %T)", v)
+						}
+					}
+					in = csvRecordsFromMaps(rows)
+					break
+				}
+			}
+
 			in = make([][]string, len(a))
 			for i, v := range a {
 				ar, ok := v.([]interface{})
@@ -406,9 +540,21 @@ func marshalObj(obj interface{}, f func(interface{}) ([]byte, error)) (string, e
 	return string(b), nil
 }
 
-func toJSONBytes(in interface{}) ([]byte, error) {
+// JSONOptions - options for marshaling JSON with ToJSONWithOptions
+type JSONOptions struct {
+	// Indent - the string to indent nested values with. When empty, the output
+	// is compact.
+	Indent string
+	// NoEscapeHTML - output the characters <, >, and & as-is, rather than
+	// escaping them as \u003c, \u003e, and \u0026
+	NoEscapeHTML bool
+}
+
+func toJSONBytes(in interface{}, opts JSONOptions) ([]byte, error) {
 	h := &codec.JsonHandle{}
+	// always sort map keys, so output is stable
 	h.Canonical = true
+	h.HTMLCharsAsIs = opts.NoEscapeHTML
 	buf := new(bytes.Buffer)
 	err := codec.NewEncoder(buf, h).Encode(in)
 	if err != nil {
@@ -419,21 +565,27 @@ func toJSONBytes(in interface{}) ([]byte, error) {
 
 // ToJSON - Stringify a struct as JSON
 func ToJSON(in interface{}) (string, error) {
-	s, err := toJSONBytes(in)
-	if err != nil {
-		return "", err
-	}
-	return string(s), nil
+	return ToJSONWithOptions(in, JSONOptions{})
 }
 
 // ToJSONPretty - Stringify a struct as JSON (indented)
 func ToJSONPretty(indent string, in interface{}) (string, error) {
-	out := new(bytes.Buffer)
-	b, err := toJSONBytes(in)
+	return ToJSONWithOptions(in, JSONOptions{Indent: indent})
+}
+
+// ToJSONWithOptions - Stringify a struct as JSON, with the given options. Map
+// keys are always sorted.
+func ToJSONWithOptions(in interface{}, opts JSONOptions) (string, error) {
+	b, err := toJSONBytes(in, opts)
 	if err != nil {
 		return "", err
 	}
-	err = json.Indent(out, b, "", indent)
+	if opts.Indent == "" {
+		return string(b), nil
+	}
+
+	out := new(bytes.Buffer)
+	err = json.Indent(out, b, "", opts.Indent)
 	if err != nil {
 		return "", fmt.Errorf("unable to indent JSON %s: %w", b, err)
 	}
@@ -441,14 +593,59 @@ func ToJSONPretty(indent string, in interface{}) (string, error) {
 	return out.String(), nil
 }
 
+// YAMLOptions - options for marshaling YAML with ToYAMLWithOptions
+type YAMLOptions struct {
+	// Indent - the number of spaces to indent nested values with (between 2
+	// and 9). Defaults to 2.
+	Indent int
+	// BlockStyle - the style to use for multi-line strings: "literal" (|),
+	// "folded" (>), or "quoted" (a double-quoted string). Defaults to
+	// "literal".
+	BlockStyle string
+}
+
 // ToYAML - Stringify a struct as YAML
 func ToYAML(in interface{}) (string, error) {
+	return ToYAMLWithOptions(in, YAMLOptions{})
+}
+
+// ToYAMLWithOptions - Stringify a struct as YAML, with the given options
+func ToYAMLWithOptions(in interface{}, opts YAMLOptions) (string, error) {
+	if opts.Indent == 0 {
+		opts.Indent = 2
+	}
+	if opts.Indent < 2 || opts.Indent > 9 {
+		return "", fmt.Errorf("invalid YAML indent %d: must be between 2 and 9", opts.Indent)
+	}
+
+	var style yaml.Style
+	switch opts.BlockStyle {
+	case "", "literal":
+		// the encoder's default for multi-line strings
+	case "folded":
+		style = yaml.FoldedStyle
+	case "quoted":
+		style = yaml.DoubleQuotedStyle
+	default:
+		return "", fmt.Errorf("invalid YAML block style %q: must be literal, folded, or quoted", opts.BlockStyle)
+	}
+
 	// I'd use yaml.Marshal, but between v2 and v3 the indent has changed from
-	// 2 to 4. This explicitly sets it back to 2.
+	// 2 to 4. This explicitly sets it back to 2 (unless otherwise specified).
 	marshal := func(in interface{}) (out []byte, err error) {
+		if style != 0 {
+			n := &yaml.Node{}
+			err = n.Encode(in)
+			if err != nil {
+				return nil, err
+			}
+			setBlockStyle(n, style)
+			in = n
+		}
+
 		buf := &bytes.Buffer{}
 		e := yaml.NewEncoder(buf)
-		e.SetIndent(2)
+		e.SetIndent(opts.Indent)
 		defer e.Close()
 		err = e.Encode(in)
 		return buf.Bytes(), err
@@ -457,8 +654,25 @@ func ToYAML(in interface{}) (string, error) {
 	return marshalObj(in, marshal)
 }
 
+// setBlockStyle sets the style of all multi-line strings in the node tree
+func setBlockStyle(n *yaml.Node, style yaml.Style) {
+	if n.Kind == yaml.ScalarNode && n.ShortTag() == "!!str" && strings.Contains(n.Value, "\n") {
+		n.Style = style
+	}
+	for _, c := range n.Content {
+		setBlockStyle(c, style)
+	}
+}
+
 // ToTOML - Stringify a struct as TOML
 func ToTOML(in interface{}) (string, error) {
+	// TOML documents are always tables, so only maps and structs can be
+	// marshalled at the top level
+	v := reflect.Indirect(reflect.ValueOf(in))
+	if v.Kind() != reflect.Map && v.Kind() != reflect.Struct {
+		return "", fmt.Errorf("unable to marshal %T as TOML: the top-level value must be a map or struct", in)
+	}
+
 	buf := new(bytes.Buffer)
 	err := toml.NewEncoder(buf).Encode(in)
 	if err != nil {
@@ -467,6 +681,36 @@ func ToTOML(in interface{}) (string, error) {
 	return buf.String(), nil
 }
 
+// csvRecordsFromMaps converts rows of maps (like those produced by CSVByRow)
+// to CSV records, with a header row of all keys, sorted alphabetically
+func csvRecordsFromMaps(rows []map[string]interface{}) [][]string {
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, row := range rows {
+		for k := range row {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	records := make([][]string, 0, len(rows)+1)
+	records = append(records, keys)
+	for _, row := range rows {
+		record := make([]string, len(keys))
+		for i, k := range keys {
+			if v, ok := row[k]; ok {
+				record[i] = conv.ToString(v)
+			}
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
 // CUE - Unmarshal a CUE expression into the appropriate type
 func CUE(in string) (interface{}, error) {
 	cuectx := cuecontext.New()
@@ -512,6 +756,30 @@ func CUE(in string) (interface{}, error) {
 	}
 }
 
+// CUEValidate - Validate a value against a CUE schema. The value is returned
+// unchanged when it's valid, so it can be used in a pipeline. All fields
+// must be concrete after unification with the schema.
+func CUEValidate(schema string, in interface{}) (interface{}, error) {
+	cuectx := cuecontext.New()
+
+	sv := cuectx.CompileString(schema)
+	if sv.Err() != nil {
+		return nil, fmt.Errorf("unable to process CUE schema: %w", sv.Err())
+	}
+
+	dv := cuectx.Encode(in)
+	if dv.Err() != nil {
+		return nil, fmt.Errorf("unable to encode value as CUE: %w", dv.Err())
+	}
+
+	err := sv.Unify(dv).Validate(cue.Concrete(true))
+	if err != nil {
+		return nil, fmt.Errorf("CUE validation failed:\n%s", strings.TrimSpace(cueerrors.Details(err, nil)))
+	}
+
+	return in, nil
+}
+
 func ToCUE(in interface{}) (string, error) {
 	cuectx := cuecontext.New()
 	v := cuectx.Encode(in)
@@ -160,11 +160,11 @@ func TestMarshalObj(t *testing.T) {
 
 func TestToJSONBytes(t *testing.T) {
 	expected := []byte("null")
-	actual, err := toJSONBytes(nil)
+	actual, err := toJSONBytes(nil, JSONOptions{})
 	require.NoError(t, err)
 	assert.Equal(t, expected, actual)
 
-	_, err = toJSONBytes(&badObject{})
+	_, err = toJSONBytes(&badObject{}, JSONOptions{})
 	assert.Error(t, err)
 }
 
@@ -232,6 +232,32 @@ func TestToJSONPretty(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestToJSONWithOptions(t *testing.T) {
+	in := map[string]interface{}{
+		"z": "<b>&</b>",
+		"a": []interface{}{1, map[interface{}]interface{}{"y": 2, "x": 1}},
+	}
+
+	out, err := ToJSONWithOptions(in, JSONOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":[1,{"x":1,"y":2}],"z":"\u003cb\u003e\u0026\u003c/b\u003e"}`, out)
+
+	out, err = ToJSONWithOptions(in, JSONOptions{NoEscapeHTML: true})
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":[1,{"x":1,"y":2}],"z":"<b>&</b>"}`, out)
+
+	out, err = ToJSONWithOptions(in, JSONOptions{Indent: "\t", NoEscapeHTML: true})
+	require.NoError(t, err)
+	assert.Equal(t, "{\n\t\"a\": [\n\t\t1,\n\t\t{\n\t\t\t\"x\": 1,\n\t\t\t\"y\": 2\n\t\t}\n\t],\n\t\"z\": \"<b>&</b>\"\n}", out)
+
+	// the output is the same every time
+	for i := 0; i < 10; i++ {
+		again, err := ToJSONWithOptions(in, JSONOptions{Indent: "\t", NoEscapeHTML: true})
+		require.NoError(t, err)
+		assert.Equal(t, out, again)
+	}
+}
+
 func TestToYAML(t *testing.T) {
 	expected := `d: 2006-01-02T15:04:05.999999999-07:00
 foo: bar
@@ -260,6 +286,55 @@ key`: map[string]interface{}{
 	assert.Equal(t, expected, out)
 }
 
+func TestToYAMLWithOptions(t *testing.T) {
+	in := map[string]interface{}{
+		"script": "echo hello\necho world\n",
+		"list":   []interface{}{map[string]interface{}{"a": 1}},
+	}
+
+	out, err := ToYAMLWithOptions(in, YAMLOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, `list:
+  - a: 1
+script: |
+  echo hello
+  echo world
+`, out)
+
+	out, err = ToYAMLWithOptions(in, YAMLOptions{Indent: 4})
+	require.NoError(t, err)
+	assert.Equal(t, `list:
+    - a: 1
+script: |
+    echo hello
+    echo world
+`, out)
+
+	out, err = ToYAMLWithOptions(in, YAMLOptions{BlockStyle: "folded"})
+	require.NoError(t, err)
+	assert.Equal(t, `list:
+  - a: 1
+script: >
+  echo hello
+
+  echo world
+
+`, out)
+
+	out, err = ToYAMLWithOptions(in, YAMLOptions{BlockStyle: "quoted"})
+	require.NoError(t, err)
+	assert.Equal(t, `list:
+  - a: 1
+script: "echo hello\necho world\n"
+`, out)
+
+	_, err = ToYAMLWithOptions(in, YAMLOptions{Indent: 1})
+	assert.Error(t, err)
+
+	_, err = ToYAMLWithOptions(in, YAMLOptions{BlockStyle: "bogus"})
+	assert.Error(t, err)
+}
+
 func TestCSV(t *testing.T) {
 	expected := [][]string{
 		{"first", "second", "third"},
@@ -400,6 +475,28 @@ func TestToCSV(t *testing.T) {
 	})
 	require.NoError(t, err)
 	assert.Equal(t, expected, out)
+
+	// rows of maps are output with a header row of sorted keys
+	expected = "first,second,third\r\n1,2,3\r\n4,,6\r\n"
+	out, err = ToCSV([]map[string]string{
+		{"first": "1", "second": "2", "third": "3"},
+		{"first": "4", "third": "6"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	out, err = ToCSV([]interface{}{
+		map[string]interface{}{"first": 1, "second": "2", "third": 3},
+		map[string]interface{}{"first": "4", "third": 6},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	_, err = ToCSV([]interface{}{
+		map[string]interface{}{"first": 1},
+		[]interface{}{"4", 5, "6"},
+	})
+	assert.Error(t, err)
 }
 
 func TestTOML(t *testing.T) {
@@ -502,6 +599,12 @@ true = true
 	out, err := ToTOML(in)
 	require.NoError(t, err)
 	assert.Equal(t, expected, out)
+
+	_, err = ToTOML([]interface{}{"foo", "bar"})
+	require.ErrorContains(t, err, "top-level value must be a map or struct")
+
+	_, err = ToTOML("foo")
+	require.Error(t, err)
 }
 
 func TestDecryptEJSON(t *testing.T) {
@@ -567,6 +670,118 @@ QUX='single quotes ignore $variables'
 	assert.EqualValues(t, expected, out)
 }
 
+func TestToEnv(t *testing.T) {
+	// note: godotenv can't parse double-quoted values that end in an escaped
+	// quote, so QUOTE's quoted word isn't last
+	in := map[string]interface{}{
+		"PLAIN":   "value",
+		"NUM":     42,
+		"EMPTY":   "",
+		"SPACES":  "has spaces",
+		"DOLLAR":  "costs $5",
+		"QUOTE":   `it's "quoted" here`,
+		"NEWLINE": "line1\nline2",
+	}
+	expected := `DOLLAR='costs $5'
+EMPTY=
+NEWLINE="line1\nline2"
+NUM=42
+PLAIN=value
+QUOTE="it's \"quoted\" here"
+SPACES='has spaces'
+`
+
+	out, err := ToEnv(in)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	// round-trip
+	parsed, err := DotEnv(out)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"PLAIN":   "value",
+		"NUM":     "42",
+		"EMPTY":   "",
+		"SPACES":  "has spaces",
+		"DOLLAR":  "costs $5",
+		"QUOTE":   `it's "quoted" here`,
+		"NEWLINE": "line1\nline2",
+	}, parsed)
+
+	_, err = ToEnv(map[string]interface{}{"1BAD": "x"})
+	require.Error(t, err)
+
+	// dotted keys can't be sourced by a shell
+	_, err = ToEnv(map[string]interface{}{"FOO.BAR": "x"})
+	require.Error(t, err)
+
+	_, err = ToEnv(map[string]interface{}{"NESTED": map[string]interface{}{"a": "b"}})
+	require.Error(t, err)
+
+	_, err = ToEnv("FOO=bar")
+	require.Error(t, err)
+}
+
+func TestNDJSON(t *testing.T) {
+	in := `{"level": "info", "msg": "started", "pid": 42}
+{"level": "error", "msg": "failed", "ctx": {"retries": 3}}
+
+["arrays", "are", "fine"]
+"so are scalars"
+`
+	expected := []interface{}{
+		map[string]interface{}{"level": "info", "msg": "started", "pid": 42},
+		map[string]interface{}{"level": "error", "msg": "failed", "ctx": map[string]interface{}{"retries": 3}},
+		[]interface{}{"arrays", "are", "fine"},
+		"so are scalars",
+	}
+
+	out, err := NDJSON(in)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	out, err = NDJSON("")
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	_, err = NDJSON("{\"ok\": true}\nnot: json\n")
+	require.ErrorContains(t, err, "line 2")
+}
+
+func TestYAMLDocs(t *testing.T) {
+	in := `---
+foo: bar
+---
+# empty documents are skipped
+---
+- one
+- 2
+---
+1: numeric keys
+nested:
+  2: two
+`
+	expected := []interface{}{
+		map[string]interface{}{"foo": "bar"},
+		[]interface{}{"one", 2},
+		map[string]interface{}{
+			"1":      "numeric keys",
+			"nested": map[string]interface{}{"2": "two"},
+		},
+	}
+
+	out, err := YAMLDocs(in)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	out, err = YAMLDocs("")
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	_, err = YAMLDocs("foo: bar\n---\n[unclosed")
+	require.Error(t, err)
+}
+
 func TestStringifyYAMLArrayMapKeys(t *testing.T) {
 	cases := []struct {
 		input    []interface{}
@@ -718,6 +933,34 @@ list: [ 1, 2, 3 ]
 	require.Error(t, err)
 }
 
+func TestCUEValidate(t *testing.T) {
+	schema := `
+name: string
+port: int & >0 & <65536
+tags?: [...string]
+`
+
+	in := map[string]interface{}{"name": "web", "port": 8080}
+	out, err := CUEValidate(schema, in)
+	require.NoError(t, err)
+	assert.Equal(t, in, out)
+
+	_, err = CUEValidate(schema, map[string]interface{}{"name": "web", "port": 70000})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "port")
+
+	_, err = CUEValidate(schema, map[string]interface{}{"name": 42, "port": 80})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "name")
+
+	// missing required fields aren't concrete
+	_, err = CUEValidate(schema, map[string]interface{}{"port": 80})
+	require.Error(t, err)
+
+	_, err = CUEValidate("name: string &", in)
+	require.Error(t, err)
+}
+
 func TestToCUE(t *testing.T) {
 	in := map[string]interface{}{
 		"matches": []interface{}{
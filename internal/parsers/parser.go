@@ -2,6 +2,8 @@ package parsers
 
 import (
 	"fmt"
+	"mime"
+	"strconv"
 
 	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
 )
@@ -16,24 +18,92 @@ func ParseData(mimeType, s string) (out any, err error) {
 		}
 	case iohelpers.JSONArrayMimetype:
 		out, err = JSONArray(s)
+	case iohelpers.NDJSONMimetype:
+		out, err = NDJSON(s)
 	case iohelpers.YAMLMimetype:
+		// multi-document streams are returned as an array of documents
+		if docs, derr := YAMLDocs(s); derr == nil && len(docs) > 1 {
+			return docs, nil
+		}
+
 		out, err = YAML(s)
 		if err != nil {
 			// maybe it's a YAML array
 			out, err = YAMLArray(s)
 		}
-	case iohelpers.CSVMimetype:
-		out, err = CSV(s)
+	case iohelpers.CSVMimetype, iohelpers.TSVMimetype:
+		out, err = csvData(mimeType, s)
 	case iohelpers.TOMLMimetype:
 		out, err = TOML(s)
 	case iohelpers.EnvMimetype:
 		out, err = DotEnv(s)
 	case iohelpers.TextMimetype:
 		out = s
+	case iohelpers.BinaryMimetype:
+		// binary data is returned as-is, so it can be base64-encoded or
+		// written to a file without being mangled
+		out = []byte(s)
 	case iohelpers.CUEMimetype:
 		out, err = CUE(s)
+	case iohelpers.XMLMimetype:
+		out, err = XML(s)
+	case iohelpers.INIMimetype:
+		out, err = INI(s)
+	case iohelpers.PropsMimetype:
+		out, err = Properties(s)
+	case iohelpers.MsgpackMimetype:
+		out, err = Msgpack(s)
+	case iohelpers.CBORMimetype:
+		out, err = CBOR(s)
+	case iohelpers.ProtobufMimetype:
+		out, err = protobufData(mimeType, s)
 	default:
 		return nil, fmt.Errorf("data of type %q not yet supported", mimeType)
 	}
 	return out, err
 }
+
+// csvData parses CSV (or TSV) data, using the optional "delimiter" and
+// "header" MIME type parameters. When header is "true" (or "present", as in
+// RFC 4180), the first row is used as column names and a slice of maps is
+// returned, otherwise a slice of rows is returned.
+func csvData(mimeType, s string) (any, error) {
+	mt, params, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MIME type %q: %w", mimeType, err)
+	}
+
+	delim := ","
+	if mt == iohelpers.TSVMimetype {
+		delim = "\t"
+	}
+
+	switch d := params["delimiter"]; d {
+	case "":
+	case "tab", `\t`:
+		delim = "\t"
+	default:
+		if len(d) != 1 {
+			return nil, fmt.Errorf("invalid CSV delimiter %q: must be a single character", d)
+		}
+
+		delim = d
+	}
+
+	header := false
+	if h := params["header"]; h != "" {
+		header = h == "present"
+		if h != "present" && h != "absent" {
+			header, err = strconv.ParseBool(h)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CSV header parameter %q: %w", h, err)
+			}
+		}
+	}
+
+	if header {
+		return CSVByRow(delim, s)
+	}
+
+	return CSV(delim, s)
+}
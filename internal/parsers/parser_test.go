@@ -0,0 +1,67 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDataCSV(t *testing.T) {
+	rows := [][]string{{"a", "b"}, {"1", "2"}}
+	maps := []map[string]string{{"a": "1", "b": "2"}}
+
+	testdata := []struct {
+		mimeType string
+		in       string
+		out      any
+	}{
+		{"text/csv", "a,b\n1,2\n", rows},
+		{"text/csv; charset=utf-8", "a,b\n1,2\n", rows},
+		{"text/csv; header=present", "a,b\n1,2\n", maps},
+		{"text/csv; header=true", "a,b\n1,2\n", maps},
+		{"text/csv; header=false", "a,b\n1,2\n", rows},
+		{"text/csv; header=absent", "a,b\n1,2\n", rows},
+		{`text/csv; delimiter=";"`, "a;b\n1;2\n", rows},
+		{"text/csv; delimiter=|; header=true", "a|b\n1|2\n", maps},
+		{"text/csv; delimiter=tab", "a\tb\n1\t2\n", rows},
+		{"text/tab-separated-values", "a\tb\n1\t2\n", rows},
+		{"text/tab-separated-values; header=true", "a\tb\n1\t2\n", maps},
+	}
+
+	for _, d := range testdata {
+		out, err := ParseData(d.mimeType, d.in)
+		require.NoError(t, err, d.mimeType)
+		assert.Equal(t, d.out, out, d.mimeType)
+	}
+
+	_, err := ParseData("text/csv; delimiter=abc", "a,b")
+	require.Error(t, err)
+
+	_, err = ParseData("text/csv; header=maybe", "a,b")
+	require.Error(t, err)
+}
+
+func TestParseDataMultiDocYAML(t *testing.T) {
+	out, err := ParseData("application/yaml", "foo: bar\n")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"foo": "bar"}, out)
+
+	out, err = ParseData("application/yaml", "---\nfoo: bar\n---\nbaz: qux\n")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"foo": "bar"},
+		map[string]interface{}{"baz": "qux"},
+	}, out)
+
+	// a single document with a leading separator is not treated as multi-doc
+	out, err = ParseData("application/yaml", "---\n- 1\n- 2\n")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2}, out)
+}
+
+func TestParseDataBinary(t *testing.T) {
+	out, err := ParseData("application/octet-stream", "\x00\x01\xfe\xff")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 1, 0xfe, 0xff}, out)
+}
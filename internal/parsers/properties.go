@@ -0,0 +1,230 @@
+package parsers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+)
+
+// Properties - Unmarshal a Java properties document into a flat map of
+// strings. Keys are not split on '.' characters.
+func Properties(in string) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+
+	lines := strings.Split(strings.ReplaceAll(in, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		// join continuation lines - a line ending with an odd number of
+		// backslashes continues on the next line
+		for endsWithContinuation(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+
+		if endsWithContinuation(line) {
+			line = line[:len(line)-1]
+		}
+
+		k, v := splitProperty(line)
+
+		key, err := unescapeProperty(k)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse properties: %w", err)
+		}
+
+		val, err := unescapeProperty(v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse properties: %w", err)
+		}
+
+		out[key] = val
+	}
+
+	return out, nil
+}
+
+func endsWithContinuation(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+
+	return n%2 == 1
+}
+
+// splitProperty splits a line into its (still escaped) key and value. The key
+// ends at the first unescaped '=', ':', or whitespace character.
+func splitProperty(line string) (string, string) {
+	end := len(line)
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if c == '\\' {
+			i++
+			continue
+		}
+
+		if c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f' {
+			end = i
+			break
+		}
+	}
+
+	key := line[:end]
+	rest := strings.TrimLeft(line[end:], " \t\f")
+
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+
+	return key, rest
+}
+
+func unescapeProperty(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+
+	sb := &strings.Builder{}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i == len(s)-1 {
+			sb.WriteByte(c)
+			continue
+		}
+
+		i++
+
+		switch s[i] {
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'u':
+			if i+5 > len(s) {
+				return "", fmt.Errorf("invalid unicode escape %q", s[i-1:])
+			}
+
+			r, err := parseUnicodeEscape(s[i+1 : i+5])
+			if err != nil {
+				return "", err
+			}
+
+			i += 4
+
+			// characters outside the BMP are escaped as UTF-16 surrogate pairs
+			if utf16.IsSurrogate(r) && i+7 <= len(s) && s[i+1] == '\\' && s[i+2] == 'u' {
+				r2, err := parseUnicodeEscape(s[i+3 : i+7])
+				if err != nil {
+					return "", err
+				}
+
+				r = utf16.DecodeRune(r, r2)
+				i += 6
+			}
+
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func parseUnicodeEscape(hex string) (rune, error) {
+	r, err := strconv.ParseUint(hex, 16, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid unicode escape %q", `\u`+hex)
+	}
+
+	return rune(r), nil
+}
+
+// ToProperties - Stringify a map as a Java properties document. Nested maps
+// are flattened, with keys joined by '.' characters. Keys are sorted.
+func ToProperties(in interface{}) (string, error) {
+	m, ok := toStringMap(in)
+	if !ok {
+		return "", fmt.Errorf("unable to marshal %T as properties: must be a map", in)
+	}
+
+	flat := map[string]interface{}{}
+	flattenProperties(flat, "", m)
+
+	sb := &strings.Builder{}
+	for _, k := range sortedKeys(flat) {
+		sb.WriteString(escapeProperty(k, true))
+		sb.WriteString("=")
+		sb.WriteString(escapeProperty(conv.ToString(flat[k]), false))
+		sb.WriteString("\n")
+	}
+
+	return sb.String(), nil
+}
+
+func flattenProperties(out map[string]interface{}, prefix string, m map[string]interface{}) {
+	for k, v := range m {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+
+		if sub, ok := toStringMap(v); ok {
+			flattenProperties(out, k, sub)
+			continue
+		}
+
+		out[k] = v
+	}
+}
+
+// escapeProperty escapes a key or value the same way as Java's
+// Properties.store, including escaping non-ASCII characters
+func escapeProperty(s string, isKey bool) string {
+	sb := &strings.Builder{}
+
+	for i, r := range s {
+		switch {
+		case r == '\\':
+			sb.WriteString(`\\`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r == '\f':
+			sb.WriteString(`\f`)
+		case r == '=' || r == ':' || r == '#' || r == '!':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r == ' ' && (isKey || i == 0):
+			sb.WriteString(`\ `)
+		case r < 0x20 || r > 0x7e:
+			if r > 0xffff {
+				// encode as a UTF-16 surrogate pair
+				r1, r2 := utf16.EncodeRune(r)
+				fmt.Fprintf(sb, `\u%04X\u%04X`, r1, r2)
+				continue
+			}
+
+			fmt.Fprintf(sb, `\u%04X`, r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
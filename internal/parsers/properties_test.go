@@ -0,0 +1,86 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProperties(t *testing.T) {
+	in := `# comment
+! also a comment
+key1=value1
+key2 : value2
+key3 value3
+  indented = yes
+multi = one, \
+        two, \
+        three
+path=c:\\dir\\file
+uni=caf\u00e9 \uD83D\uDE00
+esc\ key\=x = v\tw
+empty
+trailing\\
+next=1
+`
+	expected := map[string]interface{}{
+		"key1":      "value1",
+		"key2":      "value2",
+		"key3":      "value3",
+		"indented":  "yes",
+		"multi":     "one, two, three",
+		"path":      `c:\dir\file`,
+		"uni":       "café 😀",
+		"esc key=x": "v\tw",
+		"empty":     "",
+		`trailing\`: "",
+		"next":      "1",
+	}
+
+	out, err := Properties(in)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	_, err = Properties(`bad=\u12`)
+	require.Error(t, err)
+
+	_, err = Properties(`bad=\uzzzz`)
+	require.Error(t, err)
+}
+
+func TestToProperties(t *testing.T) {
+	in := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": "c",
+			"d": map[interface{}]interface{}{"e": 1},
+		},
+		"sp key": " lead=x",
+		"uni":    "café😀",
+		"nl":     "a\nb",
+	}
+	expected := `a.b=c
+a.d.e=1
+nl=a\nb
+sp\ key=\ lead\=x
+uni=caf\u00E9\uD83D\uDE00
+`
+
+	out, err := ToProperties(in)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	// round-trip
+	parsed, err := Properties(out)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"a.b":    "c",
+		"a.d.e":  "1",
+		"nl":     "a\nb",
+		"sp key": " lead=x",
+		"uni":    "café😀",
+	}, parsed)
+
+	_, err = ToProperties("foo")
+	require.Error(t, err)
+}
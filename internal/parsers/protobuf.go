@@ -0,0 +1,91 @@
+package parsers
+
+import (
+	"fmt"
+	"mime"
+	"os"
+
+	"github.com/hairyhenderson/yaml"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Protobuf - Unmarshal a binary Protocol Buffers message into a map. The
+// message type is looked up by its full name in the given serialized
+// FileDescriptorSet (as produced by 'protoc --descriptor_set_out' or
+// 'buf build -o'), which must include all imported files.
+//
+// Messages are mapped the same way as the canonical protobuf JSON encoding,
+// except that the original field names from the .proto file are used. Note
+// that this means 64-bit integers are represented as strings.
+func Protobuf(in string, descriptorSet []byte, message string) (map[string]interface{}, error) {
+	md, err := protobufMessageDescriptor(descriptorSet, message)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := dynamicpb.NewMessage(md)
+	if err := proto.Unmarshal([]byte(in), msg); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal protobuf message %q: %w", message, err)
+	}
+
+	b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert protobuf message %q: %w", message, err)
+	}
+
+	return unmarshalObj(map[string]interface{}{}, string(b), yaml.Unmarshal)
+}
+
+func protobufMessageDescriptor(descriptorSet []byte, message string) (protoreflect.MessageDescriptor, error) {
+	if message == "" {
+		return nil, fmt.Errorf("a protobuf message name is required")
+	}
+
+	fds := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(descriptorSet, fds); err != nil {
+		return nil, fmt.Errorf("unable to parse protobuf descriptor set: %w", err)
+	}
+
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return nil, fmt.Errorf("invalid protobuf descriptor set: %w", err)
+	}
+
+	d, err := files.FindDescriptorByName(protoreflect.FullName(message))
+	if err != nil {
+		return nil, fmt.Errorf("protobuf message %q not found in descriptor set: %w", message, err)
+	}
+
+	md, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("protobuf descriptor %q is not a message", message)
+	}
+
+	return md, nil
+}
+
+// protobufData parses a binary protobuf message, using the "descriptor" (the
+// path to a descriptor set file) and "message" MIME type parameters.
+func protobufData(mimeType, s string) (map[string]interface{}, error) {
+	_, params, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MIME type %q: %w", mimeType, err)
+	}
+
+	descPath := params["descriptor"]
+	if descPath == "" {
+		return nil, fmt.Errorf("protobuf data requires a descriptor set (use the 'descriptor' parameter)")
+	}
+
+	desc, err := os.ReadFile(descPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read protobuf descriptor set: %w", err)
+	}
+
+	return Protobuf(s, desc, params["message"])
+}
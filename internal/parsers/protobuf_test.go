@@ -0,0 +1,104 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// testProtobuf returns a serialized descriptor set for a simple message type,
+// along with an encoded message of that type
+func testProtobuf(t *testing.T) (desc, msg []byte) {
+	t.Helper()
+
+	field := func(name string, num int32, typ descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(num),
+			Type:     typ.Enum(),
+			Label:    label.Enum(),
+		}
+	}
+
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("config.proto"),
+		Package: proto.String("example.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Config"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("service_name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+				field("port", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+				field("tags", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_REPEATED),
+			},
+		}},
+	}
+
+	fd, err := protodesc.NewFile(fdp, nil)
+	require.NoError(t, err)
+
+	md := fd.Messages().ByName("Config")
+	m := dynamicpb.NewMessage(md)
+	m.Set(md.Fields().ByName("service_name"), protoreflect.ValueOfString("web"))
+	m.Set(md.Fields().ByName("port"), protoreflect.ValueOfInt32(8080))
+
+	tags := m.Mutable(md.Fields().ByName("tags")).List()
+	tags.Append(protoreflect.ValueOfString("a"))
+	tags.Append(protoreflect.ValueOfString("b"))
+
+	msg, err = proto.Marshal(m)
+	require.NoError(t, err)
+
+	desc, err = proto.Marshal(&descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{fdp},
+	})
+	require.NoError(t, err)
+
+	return desc, msg
+}
+
+func TestProtobuf(t *testing.T) {
+	desc, msg := testProtobuf(t)
+
+	expected := map[string]interface{}{
+		"service_name": "web",
+		"port":         8080,
+		"tags":         []interface{}{"a", "b"},
+	}
+
+	out, err := Protobuf(string(msg), desc, "example.v1.Config")
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	_, err = Protobuf(string(msg), desc, "example.v1.Missing")
+	require.Error(t, err)
+
+	_, err = Protobuf(string(msg), desc, "")
+	require.Error(t, err)
+
+	_, err = Protobuf(string(msg), []byte("not a descriptor set"), "example.v1.Config")
+	require.Error(t, err)
+
+	_, err = Protobuf("\xff\xff\xff", desc, "example.v1.Config")
+	require.Error(t, err)
+
+	// the descriptor set is read from the path in the MIME type parameters
+	descPath := filepath.Join(t.TempDir(), "config.binpb")
+	require.NoError(t, os.WriteFile(descPath, desc, 0o600))
+
+	out, err = ParseData(`application/x-protobuf; descriptor="`+filepath.ToSlash(descPath)+`"; message=example.v1.Config`, string(msg))
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	_, err = ParseData("application/x-protobuf; message=example.v1.Config", string(msg))
+	require.Error(t, err)
+}
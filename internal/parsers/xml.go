@@ -0,0 +1,127 @@
+package parsers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+)
+
+// parseXML parses the XML document with github.com/antchfx/xmlquery. An error
+// is returned when the document has no root element.
+func parseXML(in string) (*xmlquery.Node, error) {
+	doc, err := xmlquery.Parse(strings.NewReader(in))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse XML: %w", err)
+	}
+
+	if doc.SelectElement("*") == nil {
+		return nil, fmt.Errorf("unable to parse XML: no root element")
+	}
+
+	return doc, nil
+}
+
+// XML - Unmarshal an XML document into a map. Elements are keyed by name,
+// attributes are keyed by name with a "-" prefix, and text content of
+// elements with attributes or child elements is keyed as "#text". Repeated
+// elements are collected into arrays. All values are strings.
+func XML(in string) (map[string]interface{}, error) {
+	doc, err := parseXML(in)
+	if err != nil {
+		return nil, err
+	}
+
+	root := doc.SelectElement("*")
+
+	return map[string]interface{}{root.Data: xmlValue(root)}, nil
+}
+
+func xmlValue(n *xmlquery.Node) interface{} {
+	m := map[string]interface{}{}
+
+	for _, a := range n.Attr {
+		// namespace declarations aren't useful in the decoded output
+		if a.Name.Space == "xmlns" || a.Name.Local == "xmlns" {
+			continue
+		}
+
+		m["-"+a.Name.Local] = a.Value
+	}
+
+	buf := &strings.Builder{}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		switch c.Type {
+		case xmlquery.TextNode, xmlquery.CharDataNode:
+			buf.WriteString(c.Data)
+			continue
+		case xmlquery.ElementNode:
+		default:
+			continue
+		}
+
+		v := xmlValue(c)
+
+		switch existing := m[c.Data].(type) {
+		case nil:
+			m[c.Data] = v
+		case []interface{}:
+			m[c.Data] = append(existing, v)
+		default:
+			m[c.Data] = []interface{}{existing, v}
+		}
+	}
+
+	text := strings.TrimSpace(buf.String())
+	if len(m) == 0 {
+		return text
+	}
+
+	if text != "" {
+		m["#text"] = text
+	}
+
+	return m
+}
+
+// XPath - query an XML document with an XPath 1.0 expression, returning the
+// string values of all matching nodes, in document order. Expressions which
+// evaluate to a number, string, or boolean (like "count(//book)") return a
+// single value.
+//
+// Expressions are evaluated with github.com/antchfx/xpath. Names with a
+// namespace prefix (like "h:note") match elements with that prefix, while
+// unprefixed names match elements in any namespace.
+func XPath(expr, in string) ([]string, error) {
+	doc, err := parseXML(in)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := xpath.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid XPath %q: %w", expr, err)
+	}
+
+	switch v := e.Evaluate(xmlquery.CreateXPathNavigator(doc)).(type) {
+	case *xpath.NodeIterator:
+		out := []string{}
+		for v.MoveNext() {
+			nav := v.Current().(*xmlquery.NodeNavigator)
+			if nav.NodeType() == xpath.AttributeNode {
+				out = append(out, nav.Value())
+			} else {
+				out = append(out, nav.Current().InnerText())
+			}
+		}
+
+		return out, nil
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}, nil
+	default:
+		return []string{fmt.Sprint(v)}, nil
+	}
+}
@@ -0,0 +1,114 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testXML = `<?xml version="1.0"?>
+<catalog xmlns="urn:example" xmlns:h="urn:example:h">
+  <!-- a comment -->
+  <book id="bk101" lang="en">
+    <author>Gambardella, Matthew</author>
+    <title>XML Developer's Guide</title>
+    <price>44.95</price>
+  </book>
+  <book id="bk102">
+    <author>Ralls, Kim</author>
+    <title>Midnight Rain</title>
+    <price>5.95</price>
+    <h:note>cheap</h:note>
+  </book>
+  <mixed a="1">hello <b>world</b>!</mixed>
+</catalog>`
+
+func TestXML(t *testing.T) {
+	expected := map[string]interface{}{
+		"catalog": map[string]interface{}{
+			"book": []interface{}{
+				map[string]interface{}{
+					"-id":    "bk101",
+					"-lang":  "en",
+					"author": "Gambardella, Matthew",
+					"title":  "XML Developer's Guide",
+					"price":  "44.95",
+				},
+				map[string]interface{}{
+					"-id":    "bk102",
+					"author": "Ralls, Kim",
+					"title":  "Midnight Rain",
+					"price":  "5.95",
+					"note":   "cheap",
+				},
+			},
+			"mixed": map[string]interface{}{
+				"-a":    "1",
+				"b":     "world",
+				"#text": "hello !",
+			},
+		},
+	}
+
+	out, err := XML(testXML)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	out, err = XML(`<empty/>`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"empty": ""}, out)
+
+	_, err = XML("not xml")
+	require.Error(t, err)
+
+	_, err = XML("<a><b></a>")
+	require.Error(t, err)
+}
+
+func TestXPath(t *testing.T) {
+	testdata := []struct {
+		expr     string
+		expected []string
+	}{
+		{"/catalog/book/title", []string{"XML Developer's Guide", "Midnight Rain"}},
+		{"catalog/book/title", []string{"XML Developer's Guide", "Midnight Rain"}},
+		{"//title", []string{"XML Developer's Guide", "Midnight Rain"}},
+		{"//book[@id='bk102']/author", []string{"Ralls, Kim"}},
+		{`//book[@id="bk102"]/author`, []string{"Ralls, Kim"}},
+		{"//book[2]/@id", []string{"bk102"}},
+		{"//book/@*", []string{"bk101", "en", "bk102"}},
+		{"//book[last()]/title/text()", []string{"Midnight Rain"}},
+		{"//book[price='5.95']/title", []string{"Midnight Rain"}},
+		{"//book[@lang]/title", []string{"XML Developer's Guide"}},
+		{"//book[3]", []string{}},
+		{"//mixed", []string{"hello world!"}},
+		{"//mixed/text()", []string{"hello ", "!"}},
+		{"//h:note", []string{"cheap"}},
+		{"/catalog/*[3]/b", []string{"world"}},
+		{"//title[.='Midnight Rain']/../@id", []string{"bk102"}},
+		{"//book[@id='bk101']/./price", []string{"44.95"}},
+		{"//missing", []string{}},
+		{"//book[@id=bk101]", []string{}},
+		{"//book[contains(author, 'Kim')]/title", []string{"Midnight Rain"}},
+		{"//book[price > 10]/@id", []string{"bk101"}},
+		{"count(//book)", []string{"2"}},
+		{"//book[1]/price * 2", []string{"89.9"}},
+		{"string(//book[1]/@lang)", []string{"en"}},
+		{"boolean(//h:note)", []string{"true"}},
+	}
+
+	for _, d := range testdata {
+		out, err := XPath(d.expr, testXML)
+		require.NoError(t, err, d.expr)
+		assert.Equal(t, d.expected, out, d.expr)
+	}
+
+	for _, expr := range []string{"", "//book[", "//book/", "//book[@id='bk101'", "nosuchfunc(//book)"} {
+		_, err := XPath(expr, testXML)
+		require.Error(t, err, expr)
+	}
+
+	_, err := XPath("//book", "not xml")
+	require.Error(t, err)
+}
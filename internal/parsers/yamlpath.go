@@ -0,0 +1,29 @@
+package parsers
+
+import (
+	"fmt"
+
+	"github.com/hairyhenderson/gomplate/v4/coll"
+	"github.com/hairyhenderson/yaml"
+)
+
+// YAMLPath - look up values in a YAML document with a JSONPath expression,
+// like `.spec.template.spec.containers[0].image`. This is the same
+// kubectl-style JSONPath dialect as [coll.JSONPath], so dots in keys must be
+// escaped (as in `.metadata.labels.app\.kubernetes\.io/name`), and filters and
+// wildcards are supported.
+//
+// The input may be a YAML (or JSON) document as a string, or an already
+// parsed object.
+func YAMLPath(p string, in interface{}) (interface{}, error) {
+	if s, ok := in.(string); ok {
+		var doc interface{}
+		err := yaml.Unmarshal([]byte(s), &doc)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unmarshal YAML document: %w", err)
+		}
+		in = doc
+	}
+
+	return coll.JSONPath(p, in)
+}
@@ -0,0 +1,70 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYAMLPath(t *testing.T) {
+	doc := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    app.kubernetes.io/name: web
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+        - name: web
+          image: nginx:1.25
+        - name: sidecar
+          image: envoy:1.29
+`
+
+	testdata := []struct {
+		path     string
+		expected interface{}
+	}{
+		{".kind", "Deployment"},
+		{".spec.replicas", 3},
+		{"$.spec.replicas", 3},
+		{"{.spec.replicas}", 3},
+		{".spec.template.spec.containers[0].image", "nginx:1.25"},
+		{".spec.template.spec.containers[-1].name", "sidecar"},
+		{".spec.template.spec.containers[*].name", []interface{}{"web", "sidecar"}},
+		{".spec.template.spec.containers[?(@.name=='sidecar')].image", "envoy:1.29"},
+		{`.metadata.labels.app\.kubernetes\.io/name`, "web"},
+		{".metadata", map[string]interface{}{
+			"labels": map[string]interface{}{"app.kubernetes.io/name": "web"},
+		}},
+	}
+
+	for _, d := range testdata {
+		out, err := YAMLPath(d.path, doc)
+		require.NoError(t, err, d.path)
+		assert.Equal(t, d.expected, out, d.path)
+	}
+
+	// parsed objects work too
+	obj := map[string]interface{}{
+		"a": map[interface{}]interface{}{"b": []string{"c", "d"}},
+	}
+	out, err := YAMLPath(".a.b[1]", obj)
+	require.NoError(t, err)
+	assert.Equal(t, "d", out)
+
+	_, err = YAMLPath(".spec.missing", doc)
+	assert.Error(t, err)
+
+	_, err = YAMLPath(".spec.template.spec.containers[5]", doc)
+	assert.Error(t, err)
+
+	_, err = YAMLPath(".foo", "foo: [")
+	assert.Error(t, err)
+
+	_, err = YAMLPath(".foo[", doc)
+	assert.Error(t, err)
+}
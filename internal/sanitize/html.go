@@ -0,0 +1,31 @@
+// Package sanitize contains functions for making untrusted content safe to
+// embed in generated documents.
+package sanitize
+
+import (
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// policy is built once, as policies are safe for concurrent use once they're
+// configured
+var policy = htmlPolicy()
+
+// htmlPolicy returns bluemonday's policy for user-generated content, which
+// keeps a conservative set of formatting elements and attributes. Code
+// elements may also have 'language-' classes (as rendered by markdown.ToHTML),
+// for syntax highlighters.
+func htmlPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w-]+$`)).OnElements("code")
+
+	return p
+}
+
+// HTML sanitizes the given HTML fragment, so that it's safe to embed in other
+// HTML documents. Elements and attributes which aren't explicitly allowed are
+// removed, as are URLs with unsafe schemes (like "javascript:").
+func HTML(in string) string {
+	return policy.Sanitize(in)
+}
@@ -0,0 +1,36 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTML(t *testing.T) {
+	testdata := []struct{ in, out string }{
+		{"", ""},
+		{"plain & simple", "plain &amp; simple"},
+		{
+			`<p onclick="x()">hi <b>there</b><script>alert(1)</script></p>`,
+			`<p>hi <b>there</b></p>`,
+		},
+		{
+			`<a href="javascript:alert(1)" title="t">x</a><a href=" JaVa&#x09;script:x">y</a><a href="/rel">z</a>`,
+			`<a title="t">x</a>y<a href="/rel" rel="nofollow">z</a>`,
+		},
+		{
+			`<img src="https://e.com/a.png" onerror="x" alt="a"><br/><!-- c --><div style="x">d</div>`,
+			`<img src="https://e.com/a.png" alt="a"><br/><div>d</div>`,
+		},
+		{
+			`<code class="language-go">a &lt; b</code><code class="evil">c</code>`,
+			`<code class="language-go">a &lt; b</code><code>c</code>`,
+		},
+		{`<unknown>text</unknown> & <style>p{}</style>after`, "text &amp; after"},
+		{`<iframe src="https://e.com"><p>fallback</p></iframe>ok`, "ok"},
+	}
+
+	for _, d := range testdata {
+		assert.Equal(t, d.out, HTML(d.in), d.in)
+	}
+}
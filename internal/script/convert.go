@@ -0,0 +1,142 @@
+package script
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"go.starlark.net/starlark"
+)
+
+// ToValue converts a Go value to a Starlark value, through its JSON
+// representation. Objects become dicts, arrays become lists, and numbers become
+// ints when they're integral, or floats otherwise.
+func ToValue(in interface{}) (starlark.Value, error) {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v interface{}
+	err = dec.Decode(&v)
+	if err != nil {
+		return nil, err
+	}
+
+	return toValue(v)
+}
+
+func toValue(in interface{}) (starlark.Value, error) {
+	switch v := in.(type) {
+	case nil:
+		return starlark.None, nil
+	case bool:
+		return starlark.Bool(v), nil
+	case string:
+		return starlark.String(v), nil
+	case json.Number:
+		if i, ok := new(big.Int).SetString(v.String(), 10); ok {
+			return starlark.MakeBigInt(i), nil
+		}
+
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+
+		return starlark.Float(f), nil
+	case []interface{}:
+		elems := make([]starlark.Value, len(v))
+		for i, e := range v {
+			ev, err := toValue(e)
+			if err != nil {
+				return nil, err
+			}
+
+			elems[i] = ev
+		}
+
+		return starlark.NewList(elems), nil
+	case map[string]interface{}:
+		d := starlark.NewDict(len(v))
+		for k, e := range v {
+			ev, err := toValue(e)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := d.SetKey(starlark.String(k), ev); err != nil {
+				return nil, err
+			}
+		}
+
+		return d, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %T", in)
+	}
+}
+
+// FromValue converts a Starlark value to the equivalent Go value. Dicts must
+// have string keys, and become map[string]interface{}. Lists, tuples, sets, and
+// ranges become []interface{}.
+func FromValue(in starlark.Value) (interface{}, error) {
+	switch v := in.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case starlark.Bool:
+		return bool(v), nil
+	case starlark.String:
+		return string(v), nil
+	case starlark.Bytes:
+		return string(v), nil
+	case starlark.Int:
+		if i, ok := v.Int64(); ok {
+			return i, nil
+		}
+
+		return v.BigInt(), nil
+	case starlark.Float:
+		return float64(v), nil
+	case *starlark.Dict:
+		out := make(map[string]interface{}, v.Len())
+		for _, item := range v.Items() {
+			k, ok := item[0].(starlark.String)
+			if !ok {
+				return nil, fmt.Errorf("unsupported dict key %s: keys must be strings", item[0])
+			}
+
+			e, err := FromValue(item[1])
+			if err != nil {
+				return nil, err
+			}
+
+			out[string(k)] = e
+		}
+
+		return out, nil
+	case starlark.Sequence:
+		// lists, tuples, sets, and ranges
+		out := make([]interface{}, 0, v.Len())
+
+		iter := v.Iterate()
+		defer iter.Done()
+
+		var e starlark.Value
+		for iter.Next(&e) {
+			ev, err := FromValue(e)
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, ev)
+		}
+
+		return out, nil
+	}
+
+	return nil, fmt.Errorf("unsupported script result type %s", in.Type())
+}
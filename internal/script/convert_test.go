@@ -0,0 +1,76 @@
+package script
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.starlark.net/starlark"
+)
+
+func TestToValue(t *testing.T) {
+	testdata := []struct {
+		in       interface{}
+		expected string
+	}{
+		{nil, "None"},
+		{true, "True"},
+		{"hello", `"hello"`},
+		{42, "42"},
+		{uint64(18446744073709551615), "18446744073709551615"},
+		{1.5, "1.5"},
+		{[]string{"a", "b"}, `["a", "b"]`},
+		{map[string]interface{}{"a": []int{1}}, `{"a": [1]}`},
+		{struct {
+			Name string `json:"name"`
+		}{"x"}, `{"name": "x"}`},
+	}
+
+	for _, d := range testdata {
+		v, err := ToValue(d.in)
+		require.NoError(t, err)
+		assert.Equal(t, d.expected, v.String())
+	}
+
+	_, err := ToValue(func() {})
+	require.Error(t, err)
+}
+
+func TestFromValue(t *testing.T) {
+	big, _ := new(big.Int).SetString("100000000000000000000", 10)
+
+	d := starlark.NewDict(1)
+	require.NoError(t, d.SetKey(starlark.String("a"), starlark.MakeInt(1)))
+
+	testdata := []struct {
+		in       starlark.Value
+		expected interface{}
+	}{
+		{starlark.None, nil},
+		{starlark.True, true},
+		{starlark.String("hello"), "hello"},
+		{starlark.Bytes("hello"), "hello"},
+		{starlark.MakeInt(42), int64(42)},
+		{starlark.MakeBigInt(big), big},
+		{starlark.Float(1.5), 1.5},
+		{starlark.NewList([]starlark.Value{starlark.MakeInt(1)}), []interface{}{int64(1)}},
+		{starlark.Tuple{starlark.String("a")}, []interface{}{"a"}},
+		{d, map[string]interface{}{"a": int64(1)}},
+	}
+
+	for _, d := range testdata {
+		out, err := FromValue(d.in)
+		require.NoError(t, err)
+		assert.Equal(t, d.expected, out)
+	}
+
+	d = starlark.NewDict(1)
+	require.NoError(t, d.SetKey(starlark.MakeInt(1), starlark.None))
+
+	_, err := FromValue(d)
+	require.Error(t, err)
+
+	_, err = FromValue(starlark.Universe["len"])
+	require.Error(t, err)
+}
@@ -0,0 +1,157 @@
+// Package script evaluates Starlark (https://github.com/bazelbuild/starlark)
+// scripts, for transformations which are too complex for templates.
+//
+// Scripts can't access the filesystem or network, and can't loop forever -
+// 'while' loops and recursion aren't allowed, and the number of execution
+// steps is limited.
+package script
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.starlark.net/lib/json"
+	"go.starlark.net/lib/math"
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+// maxExecutionSteps limits the work a single script can do, so that a runaway
+// script fails instead of hanging the render
+const maxExecutionSteps = 100_000_000
+
+// resultVar is the global which holds the result of a script which isn't a
+// single expression
+const resultVar = "result"
+
+// inputVar is the global which holds the script's input
+const inputVar = "ctx"
+
+var fileOptions = &syntax.FileOptions{
+	Set:             true,
+	TopLevelControl: true,
+	GlobalReassign:  true,
+}
+
+// modules are available to all scripts
+var modules = starlark.StringDict{
+	"json": json.Module,
+	"math": math.Module,
+}
+
+type globalsCtxKey struct{}
+
+// ContextWithGlobals returns a context with the globals defined by preloaded
+// scripts, which are available to scripts run with the context
+func ContextWithGlobals(ctx context.Context, globals starlark.StringDict) context.Context {
+	return context.WithValue(ctx, globalsCtxKey{}, globals)
+}
+
+// GlobalsFromContext returns the globals defined by preloaded scripts, if any
+func GlobalsFromContext(ctx context.Context) starlark.StringDict {
+	globals, _ := ctx.Value(globalsCtxKey{}).(starlark.StringDict)
+	return globals
+}
+
+// Load executes a script, returning its globals (typically functions) merged
+// with the given globals from previously-loaded scripts. The result is frozen,
+// so it can be shared safely between later runs.
+func Load(ctx context.Context, name string, src []byte, globals starlark.StringDict) (starlark.StringDict, error) {
+	thread, stop := newThread(ctx, name)
+	defer stop()
+
+	out, err := starlark.ExecFileOptions(fileOptions, thread, name, src, predeclared(globals))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load script %s: %w", name, scriptError(err))
+	}
+
+	merged := make(starlark.StringDict, len(globals)+len(out))
+	for k, v := range globals {
+		merged[k] = v
+	}
+
+	for k, v := range out {
+		merged[k] = v
+	}
+
+	merged.Freeze()
+
+	return merged, nil
+}
+
+// Run evaluates the script, with the input available as the global 'ctx', and
+// any preloaded globals from the context. When the script is a single
+// expression, its value is returned. Otherwise, the script is executed, and the
+// value it assigns to the global 'result' (if any) is returned.
+//
+// The input is converted to Starlark values through its JSON representation,
+// and the result is converted back to the equivalent Go types.
+func Run(ctx context.Context, src string, in interface{}) (interface{}, error) {
+	input, err := ToValue(in)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert script input: %w", err)
+	}
+
+	env := predeclared(GlobalsFromContext(ctx))
+	env[inputVar] = input
+
+	thread, stop := newThread(ctx, "<script>")
+	defer stop()
+
+	var out starlark.Value
+	if _, perr := fileOptions.ParseExpr("<script>", src, 0); perr == nil {
+		out, err = starlark.EvalOptions(fileOptions, thread, "<script>", src, env)
+	} else {
+		var globals starlark.StringDict
+		globals, err = starlark.ExecFileOptions(fileOptions, thread, "<script>", src, env)
+		out = globals[resultVar]
+	}
+
+	if err != nil {
+		return nil, scriptError(err)
+	}
+
+	if out == nil {
+		return nil, nil
+	}
+
+	return FromValue(out)
+}
+
+func predeclared(globals starlark.StringDict) starlark.StringDict {
+	env := make(starlark.StringDict, len(modules)+len(globals)+1)
+	for k, v := range modules {
+		env[k] = v
+	}
+
+	for k, v := range globals {
+		env[k] = v
+	}
+
+	return env
+}
+
+// newThread returns a thread which is cancelled when the context is done. The
+// returned function must be called when the thread is finished with.
+func newThread(ctx context.Context, name string) (*starlark.Thread, func() bool) {
+	thread := &starlark.Thread{Name: name}
+	thread.SetMaxExecutionSteps(maxExecutionSteps)
+
+	stop := context.AfterFunc(ctx, func() {
+		thread.Cancel(ctx.Err().Error())
+	})
+
+	return thread, stop
+}
+
+// scriptError returns a more useful error - evaluation errors include the
+// Starlark backtrace
+func scriptError(err error) error {
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) {
+		return fmt.Errorf("%s", evalErr.Backtrace())
+	}
+
+	return err
+}
@@ -0,0 +1,127 @@
+package script
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	out, err := Run(ctx, `1 + 2`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), out)
+
+	out, err = Run(ctx, `[x * 2 for x in ctx]`, []int{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{int64(2), int64(4), int64(6)}, out)
+
+	out, err = Run(ctx, `{k.upper(): v for k, v in ctx.items()}`, map[string]string{"a": "b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"A": "b"}, out)
+
+	// multi-statement scripts return the value of 'result'
+	out, err = Run(ctx, `
+ports = {}
+for s in ctx["services"]:
+    if s.get("port"):
+        ports[s["name"]] = s["port"]
+result = ports
+`, map[string]interface{}{
+		"services": []interface{}{
+			map[string]interface{}{"name": "web", "port": 80},
+			map[string]interface{}{"name": "worker"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"web": int64(80)}, out)
+
+	out, err = Run(ctx, `x = 1`, nil)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+
+	// the json and math modules are available
+	out, err = Run(ctx, `json.encode({"a": math.floor(1.5)})`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, out)
+
+	_, err = Run(ctx, `1 +`, nil)
+	require.Error(t, err)
+
+	_, err = Run(ctx, `fail("oops")`, nil)
+	require.ErrorContains(t, err, "oops")
+
+	_, err = Run(ctx, `ctx["missing"]`, map[string]string{})
+	require.ErrorContains(t, err, "missing")
+
+	// functions can't be returned
+	_, err = Run(ctx, `len`, nil)
+	require.Error(t, err)
+}
+
+func TestRunLimits(t *testing.T) {
+	ctx := context.Background()
+
+	// while loops and recursion aren't allowed
+	_, err := Run(ctx, "while True:\n    pass\n", nil)
+	require.Error(t, err)
+
+	_, err = Run(ctx, "def f(n):\n    return f(n)\nresult = f(1)\n", nil)
+	require.Error(t, err)
+
+	_, err = Run(ctx, `[x for x in range(1000000000)]`, nil)
+	require.ErrorContains(t, err, "too many steps")
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, err = Run(ctx, `[x for x in range(1000000)]`, nil)
+	require.ErrorContains(t, err, "context canceled")
+}
+
+func TestLoad(t *testing.T) {
+	ctx := context.Background()
+
+	globals, err := Load(ctx, "a.star", []byte(`
+def double(x):
+    return x * 2
+
+factor = 3
+`), nil)
+	require.NoError(t, err)
+
+	// later scripts can use (and override) globals from earlier ones
+	globals, err = Load(ctx, "b.star", []byte(`
+def triple(x):
+    return double(x) * factor / 2
+
+factor = 4
+`), globals)
+	require.NoError(t, err)
+
+	ctx = ContextWithGlobals(ctx, globals)
+
+	out, err := Run(ctx, `triple(ctx)`, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 12.0, out)
+
+	out, err = Run(ctx, `factor`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), out)
+
+	// preloaded values are frozen
+	globals, err = Load(ctx, "c.star", []byte(`items = [1, 2]`), globals)
+	require.NoError(t, err)
+
+	_, err = Run(ContextWithGlobals(ctx, globals), `items.append(3)`, nil)
+	require.ErrorContains(t, err, "frozen")
+
+	_, err = Load(ctx, "bad.star", []byte(`def`), nil)
+	require.ErrorContains(t, err, "bad.star")
+
+	_, err = Load(ctx, "fail.star", []byte(`fail("oops")`), nil)
+	require.ErrorContains(t, err, "oops")
+}
@@ -3,7 +3,9 @@ package integration
 import (
 	"io/fs"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
 	"github.com/stretchr/testify/require"
@@ -291,3 +293,52 @@ func TestBasic_CreatesMissingDirectory(t *testing.T) {
 	assert.Equal(t, iohelpers.NormalizeFileMode(0o755|fs.ModeDir), info.Mode())
 	assert.Equal(t, true, info.IsDir())
 }
+
+func TestBasic_SkipsUnchangedOutput(t *testing.T) {
+	tmpDir := setupBasicTest(t)
+	out := tmpDir.Join("out")
+
+	// large enough to need several reads when comparing
+	tmpl := `{{ range seq 1000 }}line {{ . }}{{ "\n" }}{{ end }}`
+
+	o, e, err := cmd(t, "-i", tmpl, "-o", out).run()
+	assertSuccess(t, o, e, err, "")
+
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(out, past, past))
+
+	o, e, err = cmd(t, "-i", tmpl, "-o", out).run()
+	assertSuccess(t, o, e, err, "")
+
+	info, err := os.Stat(out)
+	require.NoError(t, err)
+	assert.Assert(t, info.ModTime().Equal(past))
+
+	// changed output is written as usual
+	o, e, err = cmd(t, "-i", "changed", "-o", out).run()
+	assertSuccess(t, o, e, err, "")
+
+	content, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "changed", string(content))
+}
+
+func TestBasic_KeepsOutputOnFailedRender(t *testing.T) {
+	tmpDir := setupBasicTest(t)
+	out := tmpDir.Join("out")
+	require.NoError(t, os.WriteFile(out, []byte("original"), 0o644))
+
+	o, e, err := cmd(t, "-i", `partial {{ fail "oops" }}`, "-o", out).run()
+	assertFailed(t, o, e, err, "oops")
+
+	content, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "original", string(content))
+
+	// no temporary files are left behind
+	files, err := os.ReadDir(tmpDir.Path())
+	require.NoError(t, err)
+	for _, f := range files {
+		assert.Assert(t, !strings.HasPrefix(f.Name(), ".out.tmp"), f.Name())
+	}
+}
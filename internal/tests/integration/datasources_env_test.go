@@ -32,4 +32,10 @@ func TestDatasources_Env(t *testing.T) {
 		withEnv("json_value", `{"value":"corge"}`).
 		run()
 	assertSuccess(t, o, e, err, "corge")
+
+	o, e, err = cmd(t, "-d", "e=env:///yaml_value?type=application/yaml",
+		"-i", `{{ range (ds "e").regions }}{{ . }} {{ end }}`).
+		withEnv("yaml_value", "replicas: 3\nregions: [us-east-1, eu-west-1]\n").
+		run()
+	assertSuccess(t, o, e, err, "us-east-1 eu-west-1 ")
 }
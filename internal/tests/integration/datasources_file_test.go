@@ -189,3 +189,76 @@ func TestDatasources_File_Directory(t *testing.T) {
 		withDir(tmpDir.Path()).run()
 	assertSuccess(t, o, e, err, "core.yaml root key: cloud")
 }
+
+func TestDatasources_File_ConfD(t *testing.T) {
+	tmpDir := fs.NewDir(t, "gomplate-inttests",
+		fs.WithDir("conf.d", fs.WithFiles(map[string]string{
+			"00-defaults.yaml": "port: 80\nhost: localhost\n",
+			"10-prod.yaml":     "host: example.com\n",
+			"README":           "not data",
+		}), fs.WithDir("sub", fs.WithFile("extra.json", `{"debug": true}`))),
+	)
+	t.Cleanup(tmpDir.Remove)
+
+	o, e, err := cmd(t,
+		"-d", "confd=conf.d/",
+		"-i", `{{ $conf := dict -}}
+{{ range (ds "confd") }}{{ if strings.HasSuffix ".yaml" . -}}
+{{ $conf = merge (ds "confd" .) $conf -}}
+{{ end }}{{ end -}}
+{{ $conf.host }}:{{ $conf.port }} {{ (ds "confd" "sub/extra.json").debug }}`).
+		withDir(tmpDir.Path()).run()
+	assertSuccess(t, o, e, err, "example.com:80 true")
+}
+
+func TestDatasources_File_TOML(t *testing.T) {
+	tmpDir := fs.NewDir(t, "gomplate-inttests",
+		fs.WithFile("Cargo.toml", `[package]
+name = "hello"
+version = "0.1.0"
+
+[dependencies]
+serde = "1.0"
+`))
+	t.Cleanup(tmpDir.Remove)
+
+	o, e, err := cmd(t, "-d", "cargo="+tmpDir.Join("Cargo.toml"),
+		"-i", `{{ $c := ds "cargo" }}{{ $c.package.name }}@{{ $c.package.version }}
+{{ $c.dependencies | toTOML }}`).run()
+	assertSuccess(t, o, e, err, "hello@0.1.0\nserde = \"1.0\"\n")
+}
+
+func TestDatasources_File_XML(t *testing.T) {
+	tmpDir := fs.NewDir(t, "gomplate-inttests",
+		fs.WithFile("config.xml", `<?xml version="1.0"?>
+<config>
+  <server port="8080">web1</server>
+  <server port="8081">web2</server>
+</config>
+`))
+	t.Cleanup(tmpDir.Remove)
+
+	o, e, err := cmd(t, "-d", "config="+tmpDir.Join("config.xml"),
+		"-i", `{{ range (ds "config").config.server }}{{ index . "#text" }}:{{ index . "-port" }} {{ end }}{{ include "config" | xpath "//server[@port='8081']" }}`).run()
+	assertSuccess(t, o, e, err, "web1:8080 web2:8081 [web2]")
+}
+
+func TestDatasources_File_INIAndProperties(t *testing.T) {
+	tmpDir := fs.NewDir(t, "gomplate-inttests",
+		fs.WithFile("my.ini", "[mysqld]\nport = 3306\nskip-name-resolve\n"),
+		fs.WithFile("app.properties", "db.url=jdbc:postgresql://db/app\ndb.user = app\n"),
+	)
+	t.Cleanup(tmpDir.Remove)
+
+	o, e, err := cmd(t, "-d", "my="+tmpDir.Join("my.ini"),
+		"-d", "app="+tmpDir.Join("app.properties"),
+		"-i", `{{ (ds "my").mysqld.port }} {{ index (ds "app") "db.user" }}
+{{ ds "my" | toINI }}{{ ds "app" | toProperties }}`).run()
+	assertSuccess(t, o, e, err, `3306 app
+[mysqld]
+port = 3306
+skip-name-resolve = 
+db.url=jdbc\:postgresql\://db/app
+db.user=app
+`)
+}
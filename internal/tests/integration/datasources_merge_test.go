@@ -65,4 +65,13 @@ func TestDatasources_Merge(t *testing.T) {
 {{ ds "merged" | toJSON }}`,
 	).run()
 	assertSuccess(t, o, e, err, `{"foo":"bar","isDefault":true,"isOverride":false,"other":true}`)
+
+	// nested values are deep-merged, and overrides can come from the environment
+	o, e, err = cmd(t,
+		"-d", "overrides=env:///APP_CONFIG?type=application/json",
+		"-d", "default="+tmpDir.Join("default.yml"),
+		"-d", "config=merge:overrides|default",
+		"-i", `{{ ds "config" | toJSON }}`,
+	).withEnv("APP_CONFIG", `{"foo": {"baz": "qux"}, "isOverride": true}`).run()
+	assertSuccess(t, o, e, err, `{"foo":{"bar":"qux","baz":"qux"},"isDefault":true,"isOverride":true,"other":true}`)
 }
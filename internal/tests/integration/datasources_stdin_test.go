@@ -0,0 +1,29 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gotest.tools/v3/fs"
+)
+
+func TestDatasources_Stdin(t *testing.T) {
+	tmpDir := fs.NewDir(t, "gomplate-inttests",
+		fs.WithFile("in.tmpl", `{{ (ds "config").foo.bar }}`),
+	)
+	t.Cleanup(tmpDir.Remove)
+
+	o, e, err := cmd(t, "-d", "config=stdin:///?type=application/json",
+		"-f", tmpDir.Join("in.tmpl")).
+		withStdin(`{"foo": {"bar": "baz"}}`).run()
+	assertSuccess(t, o, e, err, "baz")
+
+	o, e, err = cmd(t, "-c", "config=stdin:///config.yaml",
+		"-i", `{{ .config.foo }}`).
+		withStdin(`foo: bar`).run()
+	assertSuccess(t, o, e, err, "bar")
+
+	_, _, err = cmd(t, "-d", "config=stdin:///?type=application/json").
+		withStdin(`{{ (ds "config").foo }}`).run()
+	assert.ErrorContains(t, err, "standard input can not be used for both")
+}
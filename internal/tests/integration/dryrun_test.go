@@ -0,0 +1,55 @@
+package integration
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gotest.tools/v3/fs"
+)
+
+func TestDryRun(t *testing.T) {
+	tmpDir := fs.NewDir(t, "gomplate-inttests",
+		fs.WithFiles(map[string]string{
+			"in.tmpl": "port: {{ .port }}\nhost: localhost\n",
+			"out.txt": "port: 80\nhost: localhost\n",
+		}),
+	)
+	t.Cleanup(tmpDir.Remove)
+
+	o, e, err := cmd(t, "--diff", "--set", "port=8080",
+		"-f", "in.tmpl", "-o", "out.txt",
+		"-f", "in.tmpl", "-o", "new.txt").
+		withDir(tmpDir.Path()).run()
+	assertSuccess(t, o, e, err, `--- out.txt
++++ out.txt
+@@ -1,2 +1,2 @@
+-port: 80
++port: 8080
+ host: localhost
+--- /dev/null
++++ new.txt
+@@ -0,0 +1,2 @@
++port: 8080
++host: localhost
+`)
+
+	o, e, err = cmd(t, "--dry-run", "--set", "port=8080",
+		"-f", "in.tmpl", "-o", "out.txt").
+		withDir(tmpDir.Path()).run()
+	assertSuccess(t, o, e, err, "would update out.txt\n")
+
+	// nothing was written
+	b, err := os.ReadFile(tmpDir.Join("out.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "port: 80\nhost: localhost\n", string(b))
+
+	_, err = os.Stat(tmpDir.Join("new.txt"))
+	assert.True(t, os.IsNotExist(err))
+
+	o, e, err = cmd(t, "--dry-run", "-f", "in.tmpl", "-o", "out.txt",
+		"--", "echo", "hello").
+		withDir(tmpDir.Path()).run()
+	assertFailed(t, o, e, err, "'dryRun' and 'diff' can not be used with a postExec command")
+}
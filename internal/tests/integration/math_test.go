@@ -17,4 +17,6 @@ func TestMath(t *testing.T) {
 		`1, 0, 4`)
 	inOutTest(t, `{{ math.Max -0 "+Inf" "NaN" }}, {{ math.Max 3.4 3.401 3.399 }}`,
 		`+Inf, 3.401`)
+	inOutTest(t, `{{ math.Pow 2 64 }} {{ add "9223372036854775807" 1 }}`,
+		`18446744073709551616 9223372036854775808`)
 }
@@ -0,0 +1,39 @@
+package integration
+
+import (
+	"testing"
+
+	"gotest.tools/v3/fs"
+)
+
+func TestSet(t *testing.T) {
+	tmpDir := fs.NewDir(t, "gomplate-inttests",
+		fs.WithFiles(map[string]string{
+			"config.yaml": "server:\n  host: localhost\n  port: 80\n",
+			"motd.txt":    "Welcome!",
+		}),
+	)
+	t.Cleanup(tmpDir.Remove)
+
+	o, e, err := cmd(t, "-c", ".=config.yaml",
+		"--set", "server.port=8080,debug=true",
+		"-i", `{{ .server.host }}:{{ add .server.port 1 }} {{ .debug }}`).
+		withDir(tmpDir.Path()).run()
+	assertSuccess(t, o, e, err, "localhost:8081 true")
+
+	o, e, err = cmd(t, "-c", "cfg=config.yaml",
+		"--set", "cfg.server.port=8080", "--set", "cfg.server.port=9090",
+		"-i", `{{ .cfg.server.port }}`).
+		withDir(tmpDir.Path()).run()
+	assertSuccess(t, o, e, err, "9090")
+
+	o, e, err = cmd(t, "--set", "servers={web1,web2}",
+		"--set-string", "version=1.10",
+		"--set-file", "motd=motd.txt",
+		"-i", `{{ range .servers }}{{ . }} {{ end }}{{ .version }}: {{ .motd }}`).
+		withDir(tmpDir.Path()).run()
+	assertSuccess(t, o, e, err, "web1 web2 1.10: Welcome!")
+
+	o, e, err = cmd(t, "--set", "novalue", "-i", "{{ .novalue }}").run()
+	assertFailed(t, o, e, err, `invalid set value "novalue": must be in path=value form`)
+}
@@ -2,6 +2,7 @@
 package net
 
 import (
+	"fmt"
 	"net"
 )
 
@@ -63,6 +64,9 @@ func LookupSRV(name string) (*net.SRV, error) {
 	if err != nil {
 		return nil, err
 	}
+	if len(srvs) == 0 {
+		return nil, fmt.Errorf("no SRV records found for %q", name)
+	}
 	return srvs[0], nil
 }
 
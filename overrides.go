@@ -0,0 +1,267 @@
+package gomplate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+)
+
+// ContextOverride - a value to set in the template's context, after the
+// context datasources are read. Path is a dot-separated path, like
+// "path.to.key", and may include array indexes, like "list[0]". Literal dots
+// in keys can be escaped with a backslash.
+//
+// Experimental: subject to breaking changes before the next major release
+type ContextOverride struct {
+	Value interface{}
+	Path  string
+}
+
+// contextOverrides - build the list of overrides from the config's set,
+// setString, and setFile options, in that order (later overrides win)
+func contextOverrides(ctx context.Context, cfg *config.Config) ([]ContextOverride, error) {
+	overrides := []ContextOverride{}
+
+	add := func(opt string, values []string, conv func(string) (interface{}, error)) error {
+		for _, s := range values {
+			for _, assignment := range splitAssignments(s) {
+				p, v, ok := strings.Cut(assignment, "=")
+				if !ok {
+					return fmt.Errorf("invalid %s value %q: must be in path=value form", opt, assignment)
+				}
+				if _, err := parseOverridePath(p); err != nil {
+					return fmt.Errorf("invalid %s value %q: %w", opt, assignment, err)
+				}
+
+				val, err := conv(v)
+				if err != nil {
+					return fmt.Errorf("invalid %s value %q: %w", opt, assignment, err)
+				}
+
+				overrides = append(overrides, ContextOverride{Path: p, Value: val})
+			}
+		}
+		return nil
+	}
+
+	err := add("set", cfg.Set, func(s string) (interface{}, error) {
+		return overrideValue(s), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = add("setString", cfg.SetString, func(s string) (interface{}, error) {
+		return unescapeCommas(s), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = add("setFile", cfg.SetFile, func(s string) (interface{}, error) {
+		return readOverrideFile(ctx, unescapeCommas(s))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return overrides, nil
+}
+
+func readOverrideFile(ctx context.Context, name string) (interface{}, error) {
+	if datafs.FSProviderFromContext(ctx) == nil {
+		ctx = datafs.ContextWithFSProvider(ctx, DefaultFSProvider())
+	}
+
+	fsys, err := datafs.FSysForPath(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("fsys for path %v: %w", name, err)
+	}
+
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	return string(b), nil
+}
+
+// splitAssignments splits a Helm-style list of assignments, like
+// "a=1,b=2", on commas. Escaped commas (`\,`) and commas inside list values
+// (like "a={1,2}") don't split.
+func splitAssignments(s string) []string {
+	out := []string{}
+	depth := 0
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				out = append(out, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(out, s[start:])
+}
+
+func unescapeCommas(s string) string {
+	return strings.ReplaceAll(s, `\,`, ",")
+}
+
+// overrideValue converts a --set value to a typed value - "true" and "false"
+// become booleans, "null" becomes nil, integers become int64s, and lists in
+// the form "{a,b,c}" become arrays. Everything else is a string.
+func overrideValue(s string) interface{} {
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+
+	if len(s) >= 2 && s[0] == '{' && s[len(s)-1] == '}' {
+		out := []interface{}{}
+		inner := s[1 : len(s)-1]
+		if inner == "" {
+			return out
+		}
+		for _, item := range splitAssignments(inner) {
+			out = append(out, overrideValue(item))
+		}
+		return out
+	}
+
+	return unescapeCommas(s)
+}
+
+// parseOverridePath splits a path like `a.b[0].c\.d` into segments - strings
+// for map keys and ints for array indexes
+func parseOverridePath(p string) ([]interface{}, error) {
+	segments := []interface{}{}
+	key := &strings.Builder{}
+	// whether the current key must be non-empty (i.e. it follows a '.', or
+	// it's the first segment)
+	needKey := true
+
+	flush := func() error {
+		if key.Len() == 0 {
+			if needKey {
+				return fmt.Errorf("empty key in path %q", p)
+			}
+			return nil
+		}
+		segments = append(segments, key.String())
+		key.Reset()
+		return nil
+	}
+
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; c {
+		case '\\':
+			if i+1 < len(p) {
+				i++
+				key.WriteByte(p[i])
+			}
+		case '.':
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			needKey = true
+		case '[':
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			end := strings.IndexByte(p[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("missing ']' in path %q", p)
+			}
+			idx, err := strconv.Atoi(p[i+1 : i+end])
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("invalid index %q in path %q", p[i+1:i+end], p)
+			}
+			segments = append(segments, idx)
+			i += end
+			needKey = false
+		default:
+			key.WriteByte(c)
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return segments, nil
+}
+
+// setPath returns a copy of in, with v set at the given path. Maps and
+// arrays along the path are copied (so values from datasources aren't
+// modified), and are created when missing. Values that are in the way (like
+// a string where a map is needed) are replaced.
+func setPath(in interface{}, path []interface{}, v interface{}) interface{} {
+	if len(path) == 0 {
+		return v
+	}
+
+	switch seg := path[0].(type) {
+	case int:
+		orig, _ := in.([]interface{})
+		l := append([]interface{}{}, orig...)
+		for len(l) <= seg {
+			l = append(l, nil)
+		}
+		l[seg] = setPath(l[seg], path[1:], v)
+		return l
+	default:
+		key := seg.(string)
+		m := map[string]interface{}{}
+		if orig, ok := in.(map[string]interface{}); ok {
+			for k, val := range orig {
+				m[k] = val
+			}
+		}
+		m[key] = setPath(m[key], path[1:], v)
+		return m
+	}
+}
+
+// applyOverrides sets the override values in the template context
+func applyOverrides(tctx interface{}, overrides []ContextOverride) (interface{}, error) {
+	for _, o := range overrides {
+		path, err := parseOverridePath(o.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		// paths always start with a key, so the result is a map
+		if c, ok := tctx.(*tmplctx); ok {
+			m := setPath(map[string]interface{}(*c), path, o.Value).(map[string]interface{})
+			nc := tmplctx(m)
+			tctx = &nc
+			continue
+		}
+
+		tctx = setPath(tctx, path, o.Value)
+	}
+
+	return tctx, nil
+}
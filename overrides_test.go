@@ -0,0 +1,150 @@
+package gomplate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitAssignments(t *testing.T) {
+	assert.Equal(t, []string{"a=1"}, splitAssignments("a=1"))
+	assert.Equal(t, []string{"a=1", "b.c=2"}, splitAssignments("a=1,b.c=2"))
+	assert.Equal(t, []string{`a=1\,2`, "b=3"}, splitAssignments(`a=1\,2,b=3`))
+	assert.Equal(t, []string{"a={1,2}", "b=3"}, splitAssignments("a={1,2},b=3"))
+}
+
+func TestOverrideValue(t *testing.T) {
+	assert.Equal(t, true, overrideValue("true"))
+	assert.Equal(t, false, overrideValue("false"))
+	assert.Nil(t, overrideValue("null"))
+	assert.Equal(t, int64(8080), overrideValue("8080"))
+	assert.Equal(t, int64(-1), overrideValue("-1"))
+	assert.Equal(t, "1.5", overrideValue("1.5"))
+	assert.Equal(t, "True", overrideValue("True"))
+	assert.Equal(t, "a,b", overrideValue(`a\,b`))
+	assert.Equal(t, "", overrideValue(""))
+	assert.Equal(t, []interface{}{}, overrideValue("{}"))
+	assert.Equal(t, []interface{}{"a", int64(2), true}, overrideValue("{a,2,true}"))
+}
+
+func TestParseOverridePath(t *testing.T) {
+	testdata := []struct {
+		in       string
+		expected []interface{}
+	}{
+		{"a", []interface{}{"a"}},
+		{"a.b.c", []interface{}{"a", "b", "c"}},
+		{"a[0]", []interface{}{"a", 0}},
+		{"a[1].b", []interface{}{"a", 1, "b"}},
+		{"a[0][2]", []interface{}{"a", 0, 2}},
+		{`a\.b.c`, []interface{}{"a.b", "c"}},
+		{"labels.app-name", []interface{}{"labels", "app-name"}},
+	}
+	for _, d := range testdata {
+		out, err := parseOverridePath(d.in)
+		require.NoError(t, err, d.in)
+		assert.Equal(t, d.expected, out, d.in)
+	}
+
+	for _, in := range []string{"", ".a", "a.", "a..b", "[0]", "a[", "a[x]", "a[-1]"} {
+		_, err := parseOverridePath(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestSetPath(t *testing.T) {
+	orig := map[string]interface{}{
+		"a": map[string]interface{}{"b": 1, "c": 2},
+		"l": []interface{}{"x", "y"},
+	}
+
+	out := setPath(orig, []interface{}{"a", "b"}, 42)
+	assert.Equal(t, map[string]interface{}{
+		"a": map[string]interface{}{"b": 42, "c": 2},
+		"l": []interface{}{"x", "y"},
+	}, out)
+
+	// the original isn't modified
+	assert.Equal(t, 1, orig["a"].(map[string]interface{})["b"])
+
+	out = setPath(orig, []interface{}{"l", 3}, "z")
+	assert.Equal(t, []interface{}{"x", "y", nil, "z"}, out.(map[string]interface{})["l"])
+	assert.Len(t, orig["l"], 2)
+
+	out = setPath(orig, []interface{}{"new", "deep", 0, "key"}, true)
+	assert.Equal(t, []interface{}{map[string]interface{}{"key": true}},
+		out.(map[string]interface{})["new"].(map[string]interface{})["deep"])
+
+	// values in the way are replaced
+	out = setPath(map[string]interface{}{"a": "str"}, []interface{}{"a", "b"}, 1)
+	assert.Equal(t, map[string]interface{}{"a": map[string]interface{}{"b": 1}}, out)
+}
+
+func TestApplyOverrides(t *testing.T) {
+	tctx := &tmplctx{"config": map[string]interface{}{"port": 80, "host": "localhost"}}
+
+	out, err := applyOverrides(tctx, []ContextOverride{
+		{Path: "config.port", Value: int64(8080)},
+		{Path: "env", Value: "prod"},
+		{Path: "env", Value: "staging"},
+	})
+	require.NoError(t, err)
+	require.IsType(t, &tmplctx{}, out)
+	assert.Equal(t, tmplctx{
+		"config": map[string]interface{}{"port": int64(8080), "host": "localhost"},
+		"env":    "staging",
+	}, *(out.(*tmplctx)))
+
+	// the root context may be set to a datasource value
+	out, err = applyOverrides(map[string]interface{}{"port": 80}, []ContextOverride{
+		{Path: "port", Value: int64(8080)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"port": int64(8080)}, out)
+
+	out, err = applyOverrides(tctx, nil)
+	require.NoError(t, err)
+	assert.Same(t, tctx, out)
+
+	_, err = applyOverrides(tctx, []ContextOverride{{Path: "a..b"}})
+	assert.Error(t, err)
+}
+
+func TestContextOverrides(t *testing.T) {
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	fname := filepath.Join(dir, "cert.pem")
+	require.NoError(t, os.WriteFile(fname, []byte("-----BEGIN CERTIFICATE-----\n"), 0o600))
+
+	cfg := &config.Config{
+		Set:       []string{"a.b=1,c={x,y}", "d=true"},
+		SetString: []string{"a.b=1", `e=x\,y`},
+		SetFile:   []string{"tls.cert=" + fname},
+	}
+
+	out, err := contextOverrides(ctx, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []ContextOverride{
+		{Path: "a.b", Value: int64(1)},
+		{Path: "c", Value: []interface{}{"x", "y"}},
+		{Path: "d", Value: true},
+		{Path: "a.b", Value: "1"},
+		{Path: "e", Value: "x,y"},
+		{Path: "tls.cert", Value: "-----BEGIN CERTIFICATE-----\n"},
+	}, out)
+
+	_, err = contextOverrides(ctx, &config.Config{Set: []string{"novalue"}})
+	assert.ErrorContains(t, err, "path=value")
+
+	_, err = contextOverrides(ctx, &config.Config{Set: []string{"a..b=1"}})
+	assert.Error(t, err)
+
+	_, err = contextOverrides(ctx, &config.Config{SetFile: []string{"a=" + filepath.Join(dir, "missing")}})
+	assert.Error(t, err)
+}
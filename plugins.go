@@ -128,8 +128,11 @@ func findPowershell() string {
 }
 
 func (p *plugin) run(args ...interface{}) (interface{}, error) {
-	a := conv.ToStrings(args...)
-	a = append(p.args, a...)
+	// copy the configured args, so concurrent or repeated calls can't clobber
+	// each other through a shared backing array
+	a := make([]string, 0, len(p.args)+len(args))
+	a = append(a, p.args...)
+	a = append(a, conv.ToStrings(args...)...)
 
 	name, a := p.buildCommand(a)
 
@@ -160,6 +163,9 @@ func (p *plugin) run(args ...interface{}) (interface{}, error) {
 	// make sure all signals are propagated
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs)
+	// stop relaying once the plugin is done, otherwise signals (like SIGINT)
+	// would no longer be handled by gomplate itself
+	defer signal.Stop(sigs)
 	go func() {
 		select {
 		case sig := <-sigs:
@@ -97,6 +97,25 @@ func TestRun(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "", stderr.String())
 	assert.Equal(t, "foo bar baz qux", strings.TrimSpace(out.(string)))
+
+	// configured args with spare capacity must not be modified by calls
+	args := make([]string, 1, 4)
+	args[0] = "foo"
+	p = &plugin{
+		ctx:     ctx,
+		timeout: 500 * time.Millisecond,
+		stderr:  stderr,
+		path:    "echo",
+		args:    args,
+	}
+	out, err = p.run("bar")
+	require.NoError(t, err)
+	assert.Equal(t, "foo bar", strings.TrimSpace(out.(string)))
+	assert.Equal(t, []string{"foo", ""}, args[:2])
+
+	out, err = p.run("baz")
+	require.NoError(t, err)
+	assert.Equal(t, "foo baz", strings.TrimSpace(out.(string)))
 }
 
 func ExamplePluginFunc() {
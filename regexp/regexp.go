@@ -21,6 +21,66 @@ func FindAll(expression string, n int, input string) ([]string, error) {
 	return re.FindAllString(input, n), nil
 }
 
+// FindSubmatch - return the leftmost match, followed by the text of each of
+// its submatches (capturing groups). Returns nil if there's no match.
+func FindSubmatch(expression, input string) ([]string, error) {
+	re, err := stdre.Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	return re.FindStringSubmatch(input), nil
+}
+
+// FindAllSubmatch - like FindSubmatch, but for all successive matches (up to
+// n, if n >= 0).
+func FindAllSubmatch(expression string, n int, input string) ([][]string, error) {
+	re, err := stdre.Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	return re.FindAllStringSubmatch(input, n), nil
+}
+
+// FindNamed - return a map of the named capturing groups in the expression to
+// the text they matched in the leftmost match. Returns an empty map if there's
+// no match.
+func FindNamed(expression, input string) (map[string]interface{}, error) {
+	re, err := stdre.Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	m := re.FindStringSubmatch(input)
+	if m == nil {
+		return map[string]interface{}{}, nil
+	}
+	return namedGroups(re, m), nil
+}
+
+// FindAllNamed - like FindNamed, but for all successive matches (up to n, if
+// n >= 0).
+func FindAllNamed(expression string, n int, input string) ([]map[string]interface{}, error) {
+	re, err := stdre.Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	matches := re.FindAllStringSubmatch(input, n)
+	out := make([]map[string]interface{}, len(matches))
+	for i, m := range matches {
+		out[i] = namedGroups(re, m)
+	}
+	return out, nil
+}
+
+func namedGroups(re *stdre.Regexp, match []string) map[string]interface{} {
+	out := map[string]interface{}{}
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			out[name] = match[i]
+		}
+	}
+	return out
+}
+
 // Match -
 func Match(expression, input string) bool {
 	re := stdre.MustCompile(expression)
@@ -113,3 +113,58 @@ func TestSplit(t *testing.T) {
 func TestQuoteMeta(t *testing.T) {
 	assert.Equal(t, `foo\{\(\\`, QuoteMeta(`foo{(\`))
 }
+
+func TestFindSubmatch(t *testing.T) {
+	_, err := FindSubmatch(`[a-`, "")
+	assert.Error(t, err)
+
+	m, err := FindSubmatch(`(\w+)@(\w+)\.com`, "contact: bob@example.com, alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bob@example.com", "bob", "example"}, m)
+
+	m, err = FindSubmatch(`(\d+)`, "no numbers")
+	require.NoError(t, err)
+	assert.Nil(t, m)
+}
+
+func TestFindAllSubmatch(t *testing.T) {
+	_, err := FindAllSubmatch(`[a-`, -1, "")
+	assert.Error(t, err)
+
+	m, err := FindAllSubmatch(`(\w+)=(\w*)`, -1, "a=1 b= c=3")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"a=1", "a", "1"}, {"b=", "b", ""}, {"c=3", "c", "3"}}, m)
+
+	m, err = FindAllSubmatch(`(\w+)=(\w*)`, 1, "a=1 b= c=3")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"a=1", "a", "1"}}, m)
+}
+
+func TestFindNamed(t *testing.T) {
+	_, err := FindNamed(`[a-`, "")
+	assert.Error(t, err)
+
+	m, err := FindNamed(`(?P<major>\d+)\.(?P<minor>\d+)(?:\.(?P<patch>\d+))?`, "version 1.22")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"major": "1", "minor": "22", "patch": ""}, m)
+
+	m, err = FindNamed(`(?P<major>\d+)`, "no numbers")
+	require.NoError(t, err)
+	assert.Empty(t, m)
+}
+
+func TestFindAllNamed(t *testing.T) {
+	_, err := FindAllNamed(`[a-`, -1, "")
+	assert.Error(t, err)
+
+	m, err := FindAllNamed(`(?P<key>\w+)=(?P<value>\w*)`, -1, "a=1 b=")
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{
+		{"key": "a", "value": "1"},
+		{"key": "b", "value": ""},
+	}, m)
+
+	m, err = FindAllNamed(`(?P<key>\w+)=`, -1, "nothing here")
+	require.NoError(t, err)
+	assert.Empty(t, m)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/hairyhenderson/gomplate/v4/data"
 	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
 )
 
 // Options for template rendering.
@@ -35,10 +36,31 @@ type Options struct {
 	// Templates - map of templates that can be referenced as nested templates
 	Templates map[string]Datasource
 
+	// Overrides - values to set in the template's context, after the Context
+	// datasources are read. Later overrides take precedence.
+	Overrides []ContextOverride
+
 	// Extra HTTP headers not attached to pre-defined datsources. Potentially
 	// used by datasources defined in the template.
 	ExtraHeaders map[string]http.Header
 
+	// DatasourceCacheTTL - how long datasource content is cached for. Defaults
+	// to caching for the lifetime of the Renderer.
+	DatasourceCacheTTL time.Duration
+	// DatasourceCacheDir - optional directory to persist cached datasource
+	// content in, between runs
+	DatasourceCacheDir string
+	// DatasourcePrefetch - when greater than zero, all datasources are read
+	// concurrently (using this many workers) before rendering, instead of
+	// on demand
+	DatasourcePrefetch int
+	// DatasourceTimeout - the default timeout for each attempt to read a
+	// datasource. Defaults to no timeout.
+	DatasourceTimeout time.Duration
+	// DatasourceRetries - the default number of times to retry failed
+	// datasource reads
+	DatasourceRetries int
+
 	// Funcs - map of functions to be added to the default template functions.
 	// Duplicate functions will be overwritten by entries in this map.
 	Funcs template.FuncMap
@@ -65,6 +87,7 @@ func optionsFromConfig(cfg *config.Config) Options {
 		ds[k] = Datasource{
 			URL:    v.URL,
 			Header: v.Header,
+			Schema: v.Schema,
 		}
 	}
 	cs := make(map[string]Datasource, len(cfg.Context))
@@ -72,6 +95,7 @@ func optionsFromConfig(cfg *config.Config) Options {
 		cs[k] = Datasource{
 			URL:    v.URL,
 			Header: v.Header,
+			Schema: v.Schema,
 		}
 	}
 	ts := make(map[string]Datasource, len(cfg.Templates))
@@ -83,14 +107,19 @@ func optionsFromConfig(cfg *config.Config) Options {
 	}
 
 	opts := Options{
-		Datasources:  ds,
-		Context:      cs,
-		Templates:    ts,
-		ExtraHeaders: cfg.ExtraHeaders,
-		LDelim:       cfg.LDelim,
-		RDelim:       cfg.RDelim,
-		MissingKey:   cfg.MissingKey,
-		Experimental: cfg.Experimental,
+		Datasources:        ds,
+		Context:            cs,
+		Templates:          ts,
+		ExtraHeaders:       cfg.ExtraHeaders,
+		DatasourceCacheTTL: cfg.DatasourceCacheTTL,
+		DatasourceCacheDir: cfg.DatasourceCacheDir,
+		DatasourcePrefetch: cfg.DatasourcePrefetch,
+		DatasourceTimeout:  cfg.DatasourceTimeout,
+		DatasourceRetries:  cfg.DatasourceRetries,
+		LDelim:             cfg.LDelim,
+		RDelim:             cfg.RDelim,
+		MissingKey:         cfg.MissingKey,
+		Experimental:       cfg.Experimental,
 	}
 
 	return opts
@@ -102,6 +131,10 @@ func optionsFromConfig(cfg *config.Config) Options {
 type Datasource struct {
 	URL    *url.URL
 	Header http.Header
+	// Schema - optional URL of a JSON Schema to validate the datasource's
+	// contents against, when it's read with the 'datasource'/'ds' function or
+	// added to the context
+	Schema *url.URL
 }
 
 // Renderer provides gomplate's core template rendering functionality.
@@ -118,6 +151,8 @@ type Renderer struct {
 	rDelim      string
 	missingKey  string
 	tctxAliases []string
+	overrides   []ContextOverride
+	prefetch    int
 }
 
 // NewRenderer creates a new template renderer with the specified options.
@@ -138,12 +173,14 @@ func NewRenderer(opts Options) *Renderer {
 		sources[alias] = config.DataSource{
 			URL:    ds.URL,
 			Header: ds.Header,
+			Schema: ds.Schema,
 		}
 	}
 	for alias, ds := range opts.Datasources {
 		sources[alias] = config.DataSource{
 			URL:    ds.URL,
 			Header: ds.Header,
+			Schema: ds.Schema,
 		}
 	}
 
@@ -161,6 +198,10 @@ func NewRenderer(opts Options) *Renderer {
 	d := &data.Data{
 		ExtraHeaders: opts.ExtraHeaders,
 		Sources:      sources,
+		CacheTTL:     opts.DatasourceCacheTTL,
+		CacheDir:     opts.DatasourceCacheDir,
+		Timeout:      opts.DatasourceTimeout,
+		Retries:      opts.DatasourceRetries,
 	}
 
 	if opts.Funcs == nil {
@@ -181,10 +222,12 @@ func NewRenderer(opts Options) *Renderer {
 		data:        d,
 		funcs:       opts.Funcs,
 		tctxAliases: tctxAliases,
+		overrides:   opts.Overrides,
 		lDelim:      opts.LDelim,
 		rDelim:      opts.RDelim,
 		missingKey:  missingKey,
 		fsp:         opts.FSProvider,
+		prefetch:    opts.DatasourcePrefetch,
 	}
 }
 
@@ -212,9 +255,13 @@ func (t *Renderer) RenderTemplates(ctx context.Context, templates []Template) er
 		ctx = datafs.ContextWithFSProvider(ctx, t.fsp)
 	}
 
+	if t.prefetch > 0 {
+		t.data.Prefetch(ctx, t.prefetch)
+	}
+
 	// configure the template context with the refreshed Data value
 	// only done here because the data context may have changed
-	tmplctx, err := createTmplContext(ctx, t.tctxAliases, t.data)
+	tmplctx, err := t.tmplContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -222,6 +269,21 @@ func (t *Renderer) RenderTemplates(ctx context.Context, templates []Template) er
 	return t.renderTemplatesWithData(ctx, templates, tmplctx)
 }
 
+// tmplContext reads the context datasources, and applies any overrides
+func (t *Renderer) tmplContext(ctx context.Context) (interface{}, error) {
+	tmplctx, err := createTmplContext(ctx, t.tctxAliases, t.data)
+	if err != nil {
+		return nil, err
+	}
+
+	tmplctx, err = applyOverrides(tmplctx, t.overrides)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply context overrides: %w", err)
+	}
+
+	return tmplctx, nil
+}
+
 func (t *Renderer) renderTemplatesWithData(ctx context.Context, templates []Template, tmplctx interface{}) error {
 	// update funcs with the current context
 	// only done here to ensure the context is properly set in func namespaces
@@ -242,11 +304,24 @@ func (t *Renderer) renderTemplatesWithData(ctx context.Context, templates []Temp
 	return nil
 }
 
-func (t *Renderer) renderTemplate(ctx context.Context, template Template, f template.FuncMap, tmplctx interface{}) error {
+func (t *Renderer) renderTemplate(ctx context.Context, template Template, f template.FuncMap, tmplctx interface{}) (err error) {
 	if template.Writer != nil {
 		wr, ok := template.Writer.(io.Closer)
 		if ok && wr != os.Stdout {
-			defer wr.Close()
+			defer func() {
+				// discard partial output, so a failed render doesn't replace
+				// an existing output file
+				if err != nil {
+					_ = iohelpers.Abort(template.Writer)
+					return
+				}
+
+				err = wr.Close()
+				if err != nil {
+					Metrics.Errors++
+					err = fmt.Errorf("failed to write output for template %s: %w", template.Name, err)
+				}
+			}()
 		}
 	}
 
@@ -290,10 +365,23 @@ var DefaultFSProvider = sync.OnceValue[fsimpl.FSProvider](
 		// override go-fsimpl's filefs with wdfs to handle working directories
 		fsp.Add(datafs.WdFS)
 
+		// override go-fsimpl's awssmfs to support reading secret versions
+		fsp.Add(datafs.AWSSMFS)
+
+		// override go-fsimpl's vaultfs to support reading lease metadata
+		fsp.Add(datafs.VaultFS)
+
 		// gomplate-only filesystem
 		fsp.Add(datafs.EnvFS)
 		fsp.Add(datafs.StdinFS)
 		fsp.Add(datafs.MergeFS)
+		fsp.Add(datafs.GCPSMFS)
+		fsp.Add(datafs.SQLFS)
+		fsp.Add(datafs.ExecFS)
+		fsp.Add(datafs.OCIFS)
+		fsp.Add(datafs.SFTPFS)
+		fsp.Add(datafs.FTPFS)
+		fsp.Add(datafs.WebDAVFS)
 
 		return fsp
 	})()
@@ -80,6 +80,25 @@ func TestRenderTemplate(t *testing.T) {
 	assert.ErrorContains(t, err, "template: foo:")
 }
 
+func TestRenderTemplateSprig(t *testing.T) {
+	ctx := SetSprig(context.Background())
+
+	tr := NewRenderer(Options{})
+	out := &bytes.Buffer{}
+	err := tr.Render(ctx, "test", `{{- define "labels" }}app: {{ .name }}
+tier: {{ .tier | default "web" }}{{ end -}}
+labels:{{ include "labels" (dict "name" "foo" "tier" "") | nindent 2 }}
+{{ list "a" "b" | join "," }} {{ contains "ell" "hello" }}`, out)
+	require.NoError(t, err)
+	assert.Equal(t, "labels:\n  app: foo\n  tier: web\na,b true", out.String())
+
+	// without Sprig mode, the gomplate variants are used
+	out = &bytes.Buffer{}
+	err = tr.Render(context.Background(), "test", `{{ join (slice "a" "b") "," }} {{ contains "hello" "ell" }}`, out)
+	require.NoError(t, err)
+	assert.Equal(t, "a,b true", out.String())
+}
+
 //// examples
 
 func ExampleRenderer() {
@@ -0,0 +1,42 @@
+package gomplate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/hairyhenderson/gomplate/v4/internal/script"
+)
+
+// loadScripts - read and execute the given Starlark scripts, in order, and
+// return a context with the globals they define, for use by script.Run
+func loadScripts(ctx context.Context, names []string) (context.Context, error) {
+	// the filesystem provider is only needed for reading the scripts, so the
+	// renderer's own provider isn't overridden
+	fctx := ctx
+	if datafs.FSProviderFromContext(fctx) == nil {
+		fctx = datafs.ContextWithFSProvider(fctx, DefaultFSProvider())
+	}
+
+	globals := script.GlobalsFromContext(ctx)
+
+	for _, name := range names {
+		fsys, err := datafs.FSysForPath(fctx, name)
+		if err != nil {
+			return ctx, fmt.Errorf("fsys for path %v: %w", name, err)
+		}
+
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return ctx, fmt.Errorf("failed to read script %s: %w", name, err)
+		}
+
+		globals, err = script.Load(ctx, name, b, globals)
+		if err != nil {
+			return ctx, err
+		}
+	}
+
+	return script.ContextWithGlobals(ctx, globals), nil
+}
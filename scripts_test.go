@@ -0,0 +1,43 @@
+package gomplate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/hairyhenderson/gomplate/v4/internal/script"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadScripts(t *testing.T) {
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	lib := filepath.Join(dir, "lib.star")
+	require.NoError(t, os.WriteFile(lib, []byte("def double(x):\n    return x * 2\n"), 0o600))
+
+	more := filepath.Join(dir, "more.star")
+	require.NoError(t, os.WriteFile(more, []byte("def quadruple(x):\n    return double(double(x))\n"), 0o600))
+
+	sctx, err := loadScripts(ctx, []string{lib, more})
+	require.NoError(t, err)
+
+	// the default filesystem provider is only used for reading the scripts
+	assert.Nil(t, datafs.FSProviderFromContext(sctx))
+
+	out, err := script.Run(sctx, "quadruple(ctx)", 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), out)
+
+	_, err = loadScripts(ctx, []string{filepath.Join(dir, "missing.star")})
+	require.Error(t, err)
+
+	bad := filepath.Join(dir, "bad.star")
+	require.NoError(t, os.WriteFile(bad, []byte("def"), 0o600))
+
+	_, err = loadScripts(ctx, []string{bad})
+	require.ErrorContains(t, err, "bad.star")
+}
@@ -0,0 +1,226 @@
+package gomplate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/rs/zerolog"
+)
+
+// Serve serves the templates in cfg.InputDir over HTTP at the given address,
+// rendering them on each request, until the context is cancelled.
+//
+// Request paths are mapped to the templates' output paths, relative to the
+// output directory (so cfg.OutputMap can be used to strip extensions, for
+// example). Directory requests are mapped to index.html. Datasources are read
+// for each request, and the request is available in the template context as
+// .request, with the query parameters in .request.query.
+//
+// Experimental: subject to breaking changes before the next major release
+func Serve(ctx context.Context, cfg *config.Config, addr string) error {
+	log := zerolog.Ctx(ctx)
+
+	if cfg.InputDir == "" {
+		return fmt.Errorf("an input directory must be set to serve templates")
+	}
+
+	if datafs.FSProviderFromContext(ctx) == nil {
+		ctx = datafs.ContextWithFSProvider(ctx, DefaultFSProvider())
+	}
+
+	ctx, opts, err := prepareRun(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	srv := &http.Server{
+		Handler:           &server{ctx: ctx, cfg: cfg, opts: opts},
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	log.Info().Str("addr", ln.Addr().String()).Str("inputDir", cfg.InputDir).Msg("serving templates")
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(ln)
+	}()
+
+	select {
+	case err = <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return srv.Shutdown(shutdownCtx)
+}
+
+// server renders templates on request
+type server struct {
+	ctx  context.Context
+	cfg  *config.Config
+	opts Options
+
+	// rendering isn't safe for concurrent use, so requests are rendered one
+	// at a time
+	mu sync.Mutex
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name == "" || strings.HasSuffix(r.URL.Path, "/") {
+		name = path.Join(name, "index.html")
+	}
+
+	s.mu.Lock()
+	b, err := s.render(r, name)
+	s.mu.Unlock()
+
+	if errors.Is(err, fs.ErrNotExist) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		// the error is only logged, as it may reveal details (like file paths
+		// or datasource content) which clients shouldn't see
+		zerolog.Ctx(s.ctx).Error().Err(err).Str("path", r.URL.Path).Msg("rendering failed")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	ctype := mime.TypeByExtension(path.Ext(name))
+	if ctype == "" {
+		ctype = http.DetectContentType(b)
+	}
+	w.Header().Set("Content-Type", ctype)
+	w.Header().Set("Content-Length", strconv.Itoa(len(b)))
+
+	_, _ = w.Write(b)
+}
+
+// render renders the template with the given output name, returning an
+// fs.ErrNotExist error if there isn't one
+func (s *server) render(r *http.Request, name string) ([]byte, error) {
+	ctx := s.ctx
+
+	// a new renderer is used for each request so that datasources are re-read
+	tr := NewRenderer(s.opts)
+
+	namer := simpleNamer("")
+	if s.cfg.OutputMap != "" {
+		namer = mappingNamer(s.cfg.OutputMap, tr)
+	}
+
+	inPath, passthrough, err := s.lookup(ctx, namer, name)
+	if err != nil {
+		return nil, err
+	}
+
+	text, _, err := readInFile(ctx, s.cfg, inPath, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if passthrough {
+		return []byte(text), nil
+	}
+
+	if tr.prefetch > 0 {
+		tr.data.Prefetch(ctx, tr.prefetch)
+	}
+
+	tctx, err := tr.tmplContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, err = applyOverrides(tctx, []ContextOverride{
+		{Path: "request", Value: requestContext(r)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &bytes.Buffer{}
+	err = tr.renderTemplatesWithData(ctx, []Template{{Name: inPath, Text: text, Writer: out}}, tctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// lookup finds the input file for the given output name, and whether it
+// should be served without processing
+func (s *server) lookup(ctx context.Context, namer func(context.Context, string) (string, error), name string) (string, bool, error) {
+	dir := filepath.ToSlash(filepath.Clean(s.cfg.InputDir))
+
+	files, passthrough, _, err := matchInputDir(ctx, dir, s.cfg.ExcludeGlob, s.cfg.ExcludeProcessingGlob)
+	if err != nil {
+		return "", false, err
+	}
+
+	for _, file := range files {
+		out, err := namer(ctx, file)
+		if err != nil {
+			return "", false, fmt.Errorf("outFileNamer: %w", err)
+		}
+
+		if strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(out)), "/") == name {
+			inPath := filepath.ToSlash(filepath.Join(dir, file))
+			return inPath, passthrough[file], nil
+		}
+	}
+
+	return "", false, fmt.Errorf("no template for %q: %w", name, fs.ErrNotExist)
+}
+
+// requestContext - the request details available to templates as .request
+func requestContext(r *http.Request) map[string]interface{} {
+	query := map[string]interface{}{}
+	for k, v := range r.URL.Query() {
+		query[k] = v[0]
+	}
+
+	header := map[string]interface{}{}
+	for k, v := range r.Header {
+		header[k] = strings.Join(v, ", ")
+	}
+
+	return map[string]interface{}{
+		"method":   r.Method,
+		"path":     r.URL.Path,
+		"query":    query,
+		"rawQuery": r.URL.RawQuery,
+		"header":   header,
+		"host":     r.Host,
+	}
+}
@@ -0,0 +1,34 @@
+package gomplate
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeRequiresInputDir(t *testing.T) {
+	err := Serve(context.Background(), &config.Config{Input: "hello"}, "127.0.0.1:0")
+	assert.Error(t, err)
+}
+
+func TestRequestContext(t *testing.T) {
+	r := httptest.NewRequest("GET", "http://example.com/foo/bar?a=1&b=2&b=3", nil)
+	r.Header.Set("Accept", "text/plain")
+	r.Header.Add("X-Foo", "one")
+	r.Header.Add("X-Foo", "two")
+
+	assert.Equal(t, map[string]interface{}{
+		"method":   "GET",
+		"path":     "/foo/bar",
+		"query":    map[string]interface{}{"a": "1", "b": "2"},
+		"rawQuery": "a=1&b=2&b=3",
+		"header": map[string]interface{}{
+			"Accept": "text/plain",
+			"X-Foo":  "one, two",
+		},
+		"host": "example.com",
+	}, requestContext(r))
+}
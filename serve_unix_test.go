@@ -0,0 +1,120 @@
+//go:build !windows
+
+package gomplate
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hack-pad/hackpadfs"
+	"github.com/hack-pad/hackpadfs/mem"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/rs/zerolog"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_UNIX(t *testing.T) {
+	memfs, _ := mem.NewFS()
+	fsys := datafs.WrapWdFS(memfs)
+
+	logs := &bytes.Buffer{}
+	ctx := zerolog.New(logs).WithContext(context.Background())
+	ctx = datafs.ContextWithFSProvider(ctx, datafs.WrappedFSProvider(fsys, "file"))
+
+	require.NoError(t, hackpadfs.MkdirAll(fsys, "/indir/sub", 0o777))
+	files := map[string]string{
+		"/data.json":            `{"foo": "bar"}`,
+		"/indir/hello.txt":      "Hello, {{ .request.query.name }}!",
+		"/indir/data.txt":       "{{ .data.foo }}",
+		"/indir/sub/index.html": "<p>{{ .request.path }}</p>",
+		"/indir/static.css":     "{{ not a template }}",
+		"/indir/ignored.txt":    "ignored",
+		"/indir/broken.txt":     "{{ .bogus.thing }}",
+		"/indir/page.html.tmpl": "<h1>{{ .request.method }}</h1>",
+	}
+	for name, content := range files {
+		require.NoError(t, hackpadfs.WriteFullFile(fsys, name, []byte(content), 0o644))
+	}
+
+	dataURL, _ := url.Parse("file:///data.json")
+	cfg := &config.Config{
+		InputDir:              "/indir",
+		ExcludeGlob:           []string{"ignored.txt"},
+		ExcludeProcessingGlob: []string{"*.css"},
+		Context: map[string]config.DataSource{
+			"data": {URL: dataURL},
+		},
+	}
+
+	ctx, opts, err := prepareRun(ctx, cfg)
+	require.NoError(t, err)
+
+	s := &server{ctx: ctx, cfg: cfg, opts: opts}
+
+	get := func(method, target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+		return rec
+	}
+
+	rec := get(http.MethodGet, "/hello.txt?name=World")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "Hello, World!", rec.Body.String())
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+
+	rec = get(http.MethodGet, "/data.txt")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "bar", rec.Body.String())
+
+	// directories are mapped to index.html
+	rec = get(http.MethodGet, "/sub/")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "<p>/sub/</p>", rec.Body.String())
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+
+	// excluded from processing
+	rec = get(http.MethodGet, "/static.css")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{{ not a template }}", rec.Body.String())
+
+	rec = get(http.MethodGet, "/ignored.txt")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = get(http.MethodGet, "/missing.txt")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = get(http.MethodGet, "/../../data.json")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// rendering errors are logged, but not sent to clients
+	rec = get(http.MethodGet, "/broken.txt")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "Internal Server Error\n", rec.Body.String())
+	assert.Contains(t, logs.String(), "rendering failed")
+	assert.Contains(t, logs.String(), "bogus")
+
+	rec = get(http.MethodPost, "/hello.txt")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET, HEAD", rec.Header().Get("Allow"))
+
+	// request paths are mapped to output paths
+	cfg.OutputMap = `{{ .in | strings.TrimSuffix ".tmpl" }}`
+	ctx, opts, err = prepareRun(ctx, cfg)
+	require.NoError(t, err)
+
+	s = &server{ctx: ctx, cfg: cfg, opts: opts}
+
+	rec = get(http.MethodGet, "/page.html")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "<h1>GET</h1>", rec.Body.String())
+
+	rec = get(http.MethodGet, "/page.html.tmpl")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
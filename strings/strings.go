@@ -3,21 +3,17 @@ package strings
 
 import (
 	"fmt"
-	"regexp"
 	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/Masterminds/goutils"
-	"github.com/hairyhenderson/gomplate/v4/conv"
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
 )
 
 // Indent - indent each line of the string with the given indent string.
-// Any indent characters are permitted, except for '\n'.
-//
-// TODO: return an error if the indent string contains '\n' instead of
-// succeeding
+// Any indent characters are permitted, except for '\n'. Empty lines are not
+// indented, so that no trailing whitespace is added.
 func Indent(width int, indent, s string) string {
 	if width <= 0 || strings.Contains(indent, "\n") {
 		return s
@@ -47,15 +43,35 @@ func ShellQuote(s string) string {
 	return "'" + strings.ReplaceAll(s, "'", "'\"'\"'") + "'"
 }
 
-// Trunc - truncate a string to the given length
+// Trunc - truncate a string to the given length (in characters)
 func Trunc(length int, s string) string {
 	if length < 0 {
 		return s
 	}
-	if len(s) <= length {
+	r := []rune(s)
+	if len(r) <= length {
+		return s
+	}
+	return string(r[0:length])
+}
+
+// Truncate - truncate a string to the given length (in characters), ending it
+// with the given ellipsis when it's truncated. The result, including the
+// ellipsis, is never longer than length. Any whitespace before the ellipsis is
+// removed.
+func Truncate(length int, ellipsis, s string) string {
+	if length < 0 {
+		return s
+	}
+	r := []rune(s)
+	if len(r) <= length {
 		return s
 	}
-	return s[0:length]
+	e := []rune(ellipsis)
+	if len(e) >= length {
+		return string(r[0:length])
+	}
+	return strings.TrimRightFunc(string(r[0:length-len(e)]), unicode.IsSpace) + ellipsis
 }
 
 // Sort - return an alphanumerically-sorted list of strings
@@ -67,40 +83,81 @@ func Sort(list []string) []string {
 	return sorted
 }
 
-var (
-	spaces      = regexp.MustCompile(`\s+`)
-	nonAlphaNum = regexp.MustCompile(`[^\pL\pN]+`)
-)
-
-// SnakeCase -
+// SnakeCase - convert the input to snake_case. Words are lower-cased, except
+// for the first letter, which keeps its original case.
 func SnakeCase(in string) string {
-	s := casePrepare(in)
-	return spaces.ReplaceAllString(s, "_")
+	return caseJoin(in, "_", false)
 }
 
-// KebabCase -
+// KebabCase - convert the input to kebab-case. Words are lower-cased, except
+// for the first letter, which keeps its original case.
 func KebabCase(in string) string {
-	s := casePrepare(in)
-	return spaces.ReplaceAllString(s, "-")
+	return caseJoin(in, "-", false)
 }
 
-func casePrepare(in string) string {
-	in = strings.TrimSpace(in)
-	s := strings.ToLower(in)
-	// make sure the first letter remains lower- or upper-cased
-	s = strings.Replace(s, string(s[0]), string(in[0]), 1)
-	s = nonAlphaNum.ReplaceAllString(s, " ")
-	return strings.TrimSpace(s)
+// CamelCase - convert the input to CamelCase. Every word is title-cased,
+// except for the first letter, which keeps its original case.
+func CamelCase(in string) string {
+	return caseJoin(in, "", true)
 }
 
-// CamelCase -
-func CamelCase(in string) string {
-	in = strings.TrimSpace(in)
-	tag := language.Und
-	s := cases.Title(tag).String(conv.ToString(in))
-	// make sure the first letter remains lower- or upper-cased
-	s = strings.Replace(s, string(s[0]), string(in[0]), 1)
-	return nonAlphaNum.ReplaceAllString(s, "")
+// caseJoin splits the input into words, and joins them with sep after
+// lower-casing (or title-casing) them. The first letter keeps its case.
+func caseJoin(in, sep string, title bool) string {
+	words := splitWords(in)
+	if len(words) == 0 {
+		return ""
+	}
+
+	first, _ := utf8.DecodeRuneInString(words[0])
+	for i, w := range words {
+		r := []rune(strings.ToLower(w))
+		if i == 0 {
+			r[0] = first
+		} else if title {
+			r[0] = unicode.ToTitle(r[0])
+		}
+		words[i] = string(r)
+	}
+
+	return strings.Join(words, sep)
+}
+
+// splitWords splits the input into words, on any non-alphanumeric characters
+// and at case changes (so "fooBar", "FooBar", and "foo_bar" all result in
+// "foo" and "bar", with the original case kept). Runs of capitals are treated
+// as a single word, so "HTTPServer" results in "HTTP" and "Server".
+func splitWords(in string) []string {
+	words := []string{}
+	r := []rune(in)
+	start := -1
+	for i, c := range r {
+		if !unicode.IsLetter(c) && !unicode.IsNumber(c) {
+			if start >= 0 {
+				words = append(words, string(r[start:i]))
+				start = -1
+			}
+			continue
+		}
+
+		if start >= 0 && unicode.IsUpper(c) {
+			prev := r[i-1]
+			if unicode.IsLower(prev) || unicode.IsNumber(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(r) && unicode.IsLower(r[i+1])) {
+				words = append(words, string(r[start:i]))
+				start = i
+			}
+		}
+
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(r[start:]))
+	}
+
+	return words
 }
 
 // WordWrapOpts defines the options to apply to the WordWrap function
@@ -52,6 +52,29 @@ func TestTrunc(t *testing.T) {
 	assert.Equal(t, "hello, world", Trunc(12, "hello, world"))
 	assert.Equal(t, "hello, world", Trunc(42, "hello, world"))
 	assert.Equal(t, "hello, world", Trunc(-1, "hello, world"))
+	assert.Equal(t, "grü", Trunc(3, "grüne"))
+}
+
+func TestTruncate(t *testing.T) {
+	testdata := []struct {
+		length   int
+		ellipsis string
+		in, out  string
+	}{
+		{5, "...", "", ""},
+		{12, "...", "hello, world", "hello, world"},
+		{-1, "...", "hello, world", "hello, world"},
+		{10, "...", "hello, world", "hello,..."},
+		{8, "...", "hello, world", "hello..."},
+		{7, "…", "hello, world", "hello,…"},
+		{4, "…", "grüne Straße", "grü…"},
+		{9, "", "hello, world", "hello, wo"},
+		{2, "...", "hello, world", "he"},
+	}
+
+	for _, d := range testdata {
+		assert.Equal(t, d.out, Truncate(d.length, d.ellipsis, d.in), d)
+	}
 }
 
 func TestShellQuote(t *testing.T) {
@@ -82,6 +105,16 @@ func TestCaseFuncs(t *testing.T) {
 		{" baz\tqux  ", "baz_qux", "baz-qux", "bazQux"},
 		{"Hello, World!", "Hello_world", "Hello-world", "HelloWorld"},
 		{"grüne | Straße", "grüne_straße", "grüne-straße", "grüneStraße"},
+		{"", "", "", ""},
+		{" !! ", "", "", ""},
+		{"fooBar", "foo_bar", "foo-bar", "fooBar"},
+		{"FooBar", "Foo_bar", "Foo-bar", "FooBar"},
+		{"foo_bar-baz", "foo_bar_baz", "foo-bar-baz", "fooBarBaz"},
+		{"SCREAMING_SNAKE", "Screaming_snake", "Screaming-snake", "ScreamingSnake"},
+		{"myHTTPServer2Go", "my_http_server2_go", "my-http-server2-go", "myHttpServer2Go"},
+		{"ipv4Address", "ipv4_address", "ipv4-address", "ipv4Address"},
+		{"Élan vital", "Élan_vital", "Élan-vital", "ÉlanVital"},
+		{"ǉoo ǆar", "ǉoo_ǆar", "ǉoo-ǆar", "ǉooǅar"},
 	}
 	for _, d := range testdata {
 		assert.Equal(t, d.s, SnakeCase(d.in))
@@ -15,6 +15,7 @@ import (
 
 	"github.com/hack-pad/hackpadfs"
 	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/hairyhenderson/gomplate/v4/conv"
 	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
@@ -27,11 +28,23 @@ import (
 // ignorefile name, like .gitignore
 const gomplateignore = ".gomplateignore"
 
-func addTmplFuncs(f template.FuncMap, root *template.Template, tctx interface{}, path string) {
+func addTmplFuncs(ctx context.Context, f template.FuncMap, root *template.Template, tctx interface{}, path string) {
 	t := tmpl.New(root, tctx, path)
 	tns := func() *tmpl.Template { return t }
 	f["tmpl"] = tns
 	f["tpl"] = t.Inline
+
+	// in Sprig compatibility mode, 'include' renders a named template (like
+	// Helm's), falling back to gomplate's datasource 'include'
+	if config.SprigEnabled(ctx) {
+		dsInclude, _ := f["include"].(func(string, ...string) (string, error))
+		f["include"] = func(name string, args ...interface{}) (string, error) {
+			if root.Lookup(name) != nil || dsInclude == nil {
+				return t.Exec(name, args...)
+			}
+			return dsInclude(name, conv.ToStrings(args...)...)
+		}
+	}
 }
 
 // copyFuncMap - copies the template.FuncMap into a new map so we can modify it
@@ -65,7 +78,7 @@ func parseTemplate(ctx context.Context, name, text string, funcs template.FuncMa
 	funcMap := copyFuncMap(funcs)
 
 	// the "tmpl" funcs get added here because they need access to the root template and context
-	addTmplFuncs(funcMap, tmpl, tmplctx, name)
+	addTmplFuncs(ctx, funcMap, tmpl, tmplctx, name)
 	tmpl.Funcs(funcMap)
 	tmpl.Delims(leftDelim, rightDelim)
 	_, err = tmpl.Parse(text)
@@ -147,15 +160,18 @@ func parseNestedTemplateDir(ctx context.Context, fsys fs.FS, alias, fname string
 	}
 
 	for _, f := range files {
-		if !f.IsDir() {
-			err = parseNestedTemplate(ctx, fsys,
-				path.Join(alias, f.Name()),
-				path.Join(fname, f.Name()),
-				tmpl,
-			)
-			if err != nil {
-				return err
-			}
+		childAlias := path.Join(alias, f.Name())
+		childName := path.Join(fname, f.Name())
+
+		// subdirectories are included too, so partials can be organized in a tree
+		if f.IsDir() {
+			err = parseNestedTemplateDir(ctx, fsys, childAlias, childName, tmpl)
+		} else {
+			err = parseNestedTemplate(ctx, fsys, childAlias, childName, tmpl)
+		}
+
+		if err != nil {
+			return err
 		}
 	}
 
@@ -192,9 +208,9 @@ func gatherTemplates(ctx context.Context, cfg *config.Config, outFileNamer func(
 	case cfg.Input != "":
 		// open the output file - no need to close it, as it will be closed by the
 		// caller later
-		target, oerr := openOutFile(ctx, cfg.OutputFiles[0], 0o755, mode, modeOverride, cfg.Stdout, cfg.SuppressEmpty)
+		target, oerr := getOutfileHandler(ctx, cfg, cfg.OutputFiles[0], mode, modeOverride)
 		if oerr != nil {
-			return nil, fmt.Errorf("openOutFile: %w", oerr)
+			return nil, oerr
 		}
 
 		templates = []Template{{
@@ -227,33 +243,89 @@ func gatherTemplates(ctx context.Context, cfg *config.Config, outFileNamer func(
 func walkDir(ctx context.Context, cfg *config.Config, dir string, outFileNamer func(context.Context, string) (string, error), excludeGlob []string, excludeProcessingGlob []string, mode os.FileMode, modeOverride bool) ([]Template, error) {
 	dir = filepath.ToSlash(filepath.Clean(dir))
 
+	files, passthroughFiles, dirMode, err := matchInputDir(ctx, dir, excludeGlob, excludeProcessingGlob)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]Template, 0)
+
+	for _, file := range files {
+		// we want to pass an absolute (as much as possible) path to fileToTemplate
+		inPath := filepath.Join(dir, file)
+		inPath = filepath.ToSlash(inPath)
+
+		// but outFileNamer expects only the filename itself
+		outFile, err := outFileNamer(ctx, file)
+		if err != nil {
+			return nil, fmt.Errorf("outFileNamer: %w", err)
+		}
+
+		_, ok := passthroughFiles[file]
+		if ok {
+			err = copyFileToOutDir(ctx, cfg, inPath, outFile, mode, modeOverride)
+			if err != nil {
+				return nil, fmt.Errorf("copyFileToOutDir: %w", err)
+			}
+
+			continue
+		}
+
+		tpl, err := fileToTemplate(ctx, cfg, inPath, outFile, mode, modeOverride)
+		if err != nil {
+			return nil, fmt.Errorf("fileToTemplate: %w", err)
+		}
+
+		if cfg.DryRun {
+			templates = append(templates, tpl)
+			continue
+		}
+
+		// Ensure file parent dirs - use separate fsys for output file
+		outfsys, err := datafs.FSysForPath(ctx, outFile)
+		if err != nil {
+			return nil, fmt.Errorf("fsysForPath: %w", err)
+		}
+		if err = hackpadfs.MkdirAll(outfsys, filepath.Dir(outFile), dirMode); err != nil {
+			return nil, fmt.Errorf("mkdirAll %q: %w", outFile, err)
+		}
+
+		templates = append(templates, tpl)
+	}
+
+	return templates, nil
+}
+
+// matchInputDir - list the files in the input dir which aren't excluded (by
+// the exclude globs or .gomplateignore files), relative to the dir. Files that
+// should be copied without processing are also returned, along with the dir's
+// mode.
+func matchInputDir(ctx context.Context, dir string, excludeGlob, excludeProcessingGlob []string) ([]string, map[string]bool, os.FileMode, error) {
 	// get a filesystem rooted in the same volume as dir (or / on non-Windows)
 	fsys, err := datafs.FSysForPath(ctx, dir)
 	if err != nil {
-		return nil, err
+		return nil, nil, 0, err
 	}
 
 	// we need dir to be relative to the root of fsys
 	// TODO: maybe need to do something with root here?
 	_, resolvedDir, err := datafs.ResolveLocalPath(fsys, dir)
 	if err != nil {
-		return nil, fmt.Errorf("resolveLocalPath: %w", err)
+		return nil, nil, 0, fmt.Errorf("resolveLocalPath: %w", err)
 	}
 
 	// we need to sub the filesystem to the dir
 	subfsys, err := fs.Sub(fsys, resolvedDir)
 	if err != nil {
-		return nil, fmt.Errorf("sub: %w", err)
+		return nil, nil, 0, fmt.Errorf("sub: %w", err)
 	}
 
 	// just check . because fsys is subbed to dir already
 	dirStat, err := fs.Stat(subfsys, ".")
 	if err != nil {
-		return nil, fmt.Errorf("stat %q (%q): %w", dir, resolvedDir, err)
+		return nil, nil, 0, fmt.Errorf("stat %q (%q): %w", dir, resolvedDir, err)
 	}
-	dirMode := dirStat.Mode()
 
-	templates := make([]Template, 0)
 	matcher := xignore.NewMatcher(subfsys)
 
 	excludeMatches, err := matcher.Matches(".", &xignore.MatchesOptions{
@@ -262,7 +334,7 @@ func walkDir(ctx context.Context, cfg *config.Config, dir string, outFileNamer f
 		AfterPatterns: excludeGlob,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("ignore matching failed for %s: %w", dir, err)
+		return nil, nil, 0, fmt.Errorf("ignore matching failed for %s: %w", dir, err)
 	}
 
 	excludeProcessingMatches, err := matcher.Matches(".", &xignore.MatchesOptions{
@@ -272,7 +344,7 @@ func walkDir(ctx context.Context, cfg *config.Config, dir string, outFileNamer f
 		AfterPatterns: excludeProcessingGlob,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("passthough matching failed for %s: %w", dir, err)
+		return nil, nil, 0, fmt.Errorf("passthough matching failed for %s: %w", dir, err)
 	}
 
 	passthroughFiles := make(map[string]bool)
@@ -283,45 +355,7 @@ func walkDir(ctx context.Context, cfg *config.Config, dir string, outFileNamer f
 	}
 
 	// Unmatched ignorefile rules's files
-	for _, file := range excludeMatches.UnmatchedFiles {
-		// we want to pass an absolute (as much as possible) path to fileToTemplate
-		inPath := filepath.Join(dir, file)
-		inPath = filepath.ToSlash(inPath)
-
-		// but outFileNamer expects only the filename itself
-		outFile, err := outFileNamer(ctx, file)
-		if err != nil {
-			return nil, fmt.Errorf("outFileNamer: %w", err)
-		}
-
-		_, ok := passthroughFiles[file]
-		if ok {
-			err = copyFileToOutDir(ctx, cfg, inPath, outFile, mode, modeOverride)
-			if err != nil {
-				return nil, fmt.Errorf("copyFileToOutDir: %w", err)
-			}
-
-			continue
-		}
-
-		tpl, err := fileToTemplate(ctx, cfg, inPath, outFile, mode, modeOverride)
-		if err != nil {
-			return nil, fmt.Errorf("fileToTemplate: %w", err)
-		}
-
-		// Ensure file parent dirs - use separate fsys for output file
-		outfsys, err := datafs.FSysForPath(ctx, outFile)
-		if err != nil {
-			return nil, fmt.Errorf("fsysForPath: %w", err)
-		}
-		if err = hackpadfs.MkdirAll(outfsys, filepath.Dir(outFile), dirMode); err != nil {
-			return nil, fmt.Errorf("mkdirAll %q: %w", outFile, err)
-		}
-
-		templates = append(templates, tpl)
-	}
-
-	return templates, nil
+	return excludeMatches.UnmatchedFiles, passthroughFiles, dirStat.Mode(), nil
 }
 
 func readInFile(ctx context.Context, cfg *config.Config, inFile string, mode os.FileMode) (source string, newmode os.FileMode, err error) {
@@ -365,9 +399,14 @@ func readInFile(ctx context.Context, cfg *config.Config, inFile string, mode os.
 }
 
 func getOutfileHandler(ctx context.Context, cfg *config.Config, outFile string, mode os.FileMode, modeOverride bool) (io.Writer, error) {
+	// nothing is written to output files in dry-run mode
+	if cfg.DryRun && outFile != "-" {
+		return newDryRunWriter(ctx, cfg, outFile), nil
+	}
+
 	// open the output file - no need to close it, as it will be closed by the
 	// caller later
-	target, err := openOutFile(ctx, outFile, 0o755, mode, modeOverride, cfg.Stdout, cfg.SuppressEmpty)
+	target, err := openOutFile(ctx, outFile, 0o755, mode, modeOverride, cfg.BackupSuffix, cfg.Stdout, cfg.SuppressEmpty)
 	if err != nil {
 		return nil, fmt.Errorf("openOutFile: %w", err)
 	}
@@ -387,12 +426,18 @@ func copyFileToOutDir(ctx context.Context, cfg *config.Config, inFile, outFile s
 	}
 
 	wr, ok := outFH.(io.Closer)
-	if ok && wr != os.Stdout {
-		defer wr.Close()
+	if !ok || wr == os.Stdout {
+		_, err = outFH.Write([]byte(sourceStr))
+		return err
 	}
 
 	_, err = outFH.Write([]byte(sourceStr))
-	return err
+	if err != nil {
+		_ = iohelpers.Abort(outFH)
+		return err
+	}
+
+	return wr.Close()
 }
 
 func fileToTemplate(ctx context.Context, cfg *config.Config, inFile, outFile string, mode os.FileMode, modeOverride bool) (Template, error) {
@@ -425,13 +470,13 @@ func fileToTemplate(ctx context.Context, cfg *config.Config, inFile, outFile str
 // major release (v4.x).
 //
 //nolint:unparam // TODO: dirMode is always called with 0o755 - should either remove or make it configurable
-func openOutFile(ctx context.Context, filename string, dirMode, mode os.FileMode, modeOverride bool, stdout io.Writer, suppressEmpty bool) (out io.Writer, err error) {
+func openOutFile(ctx context.Context, filename string, dirMode, mode os.FileMode, modeOverride bool, backupSuffix string, stdout io.Writer, suppressEmpty bool) (out io.Writer, err error) {
 	if suppressEmpty {
 		out = iohelpers.NewEmptySkipper(func() (io.Writer, error) {
 			if filename == "-" {
 				return stdout, nil
 			}
-			return createOutFile(ctx, filename, dirMode, mode, modeOverride)
+			return createOutFile(ctx, filename, dirMode, mode, modeOverride, backupSuffix)
 		})
 		return out, nil
 	}
@@ -439,10 +484,10 @@ func openOutFile(ctx context.Context, filename string, dirMode, mode os.FileMode
 	if filename == "-" {
 		return stdout, nil
 	}
-	return createOutFile(ctx, filename, dirMode, mode, modeOverride)
+	return createOutFile(ctx, filename, dirMode, mode, modeOverride, backupSuffix)
 }
 
-func createOutFile(ctx context.Context, filename string, dirMode, mode os.FileMode, modeOverride bool) (out io.WriteCloser, err error) {
+func createOutFile(ctx context.Context, filename string, dirMode, mode os.FileMode, modeOverride bool, backupSuffix string) (out io.WriteCloser, err error) {
 	// we only support writing out to local files for now
 	fsys, err := datafs.FSysForPath(ctx, filename)
 	if err != nil {
@@ -457,12 +502,41 @@ func createOutFile(ctx context.Context, filename string, dirMode, mode os.FileMo
 		}
 	}
 
+	// regular files are written atomically, keeping the existing file's mode
+	// unless it's overridden. Symlinks and special files (like /dev/stdout)
+	// can't be replaced, so they're written in place.
+	atomic := true
+	atomicMode := mode
+	if fi, lerr := hackpadfs.LstatOrStat(fsys, filename); lerr == nil {
+		atomic = fi.Mode().IsRegular()
+		if !modeOverride {
+			atomicMode = fi.Mode().Perm()
+		}
+	}
+
 	open := func() (out io.WriteCloser, err error) {
 		// Ensure file parent dirs
 		if err = hackpadfs.MkdirAll(fsys, filepath.Dir(filename), dirMode); err != nil {
 			return nil, fmt.Errorf("mkdirAll %q: %w", filename, err)
 		}
 
+		// back up the existing file only once it's known to be changing
+		if backupSuffix != "" {
+			err = backupOutFile(fsys, filename, backupSuffix)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if atomic {
+			out, err = iohelpers.AtomicFile(fsys, filename, atomicMode)
+
+			// when the directory isn't writable, the file may still be
+			if !errors.Is(err, fs.ErrPermission) {
+				return out, err
+			}
+		}
+
 		f, err := hackpadfs.OpenFile(fsys, filename, os.O_RDWR|os.O_CREATE|os.O_TRUNC, mode)
 		if err != nil {
 			return out, fmt.Errorf("failed to open output file '%s' for writing: %w", filename, err)
@@ -489,3 +563,39 @@ func createOutFile(ctx context.Context, filename string, dirMode, mode os.FileMo
 
 	return out, err
 }
+
+// backupOutFile copies the content of an existing output file to a backup file
+// with the given suffix, replacing any previous backup. The backup is given the
+// same mode as the output file, but is owned by the current user.
+func backupOutFile(fsys fs.FS, filename, suffix string) error {
+	fi, err := fs.Stat(fsys, filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to back up output file %q: %w", filename, err)
+	}
+
+	b, err := fs.ReadFile(fsys, filename)
+	if err != nil {
+		return fmt.Errorf("failed to back up output file %q: %w", filename, err)
+	}
+
+	w, err := iohelpers.AtomicFile(fsys, filename+suffix, fi.Mode().Perm())
+	if err != nil {
+		return fmt.Errorf("failed to back up output file %q: %w", filename, err)
+	}
+
+	_, err = w.Write(b)
+	if err != nil {
+		_ = iohelpers.Abort(w)
+		return fmt.Errorf("failed to back up output file %q: %w", filename, err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return fmt.Errorf("failed to back up output file %q: %w", filename, err)
+	}
+
+	return nil
+}
@@ -30,7 +30,7 @@ func TestOpenOutFile(t *testing.T) {
 	ctx := datafs.ContextWithFSProvider(context.Background(), datafs.WrappedFSProvider(fsys, "file"))
 
 	cfg := &config.Config{Stdout: &bytes.Buffer{}}
-	f, err := openOutFile(ctx, "/tmp/foo", 0o755, 0o644, false, nil, false)
+	f, err := openOutFile(ctx, "/tmp/foo", 0o755, 0o644, false, "", nil, false)
 	require.NoError(t, err)
 
 	wc, ok := f.(io.WriteCloser)
@@ -44,7 +44,7 @@ func TestOpenOutFile(t *testing.T) {
 
 	out := &bytes.Buffer{}
 
-	f, err = openOutFile(ctx, "-", 0o755, 0o644, false, out, false)
+	f, err = openOutFile(ctx, "-", 0o755, 0o644, false, "", out, false)
 	require.NoError(t, err)
 	assert.Equal(t, cfg.Stdout, f)
 }
@@ -100,6 +100,7 @@ func TestGatherTemplates(t *testing.T) {
 
 	_, err = templates[0].Writer.Write([]byte("hello world"))
 	require.NoError(t, err)
+	require.NoError(t, templates[0].Writer.(io.Closer).Close())
 
 	info, err := hackpadfs.Stat(fsys, "out")
 	require.NoError(t, err)
@@ -120,6 +121,7 @@ func TestGatherTemplates(t *testing.T) {
 
 	_, err = templates[0].Writer.Write([]byte("hello world"))
 	require.NoError(t, err)
+	require.NoError(t, templates[0].Writer.(io.Closer).Close())
 
 	info, err = hackpadfs.Stat(fsys, "out")
 	require.NoError(t, err)
@@ -141,6 +143,7 @@ func TestGatherTemplates(t *testing.T) {
 
 	_, err = templates[0].Writer.Write([]byte("hello world"))
 	require.NoError(t, err)
+	require.NoError(t, templates[0].Writer.(io.Closer).Close())
 
 	info, err = hackpadfs.Stat(fsys, "out")
 	require.NoError(t, err)
@@ -163,11 +166,56 @@ func TestCreateOutFile(t *testing.T) {
 
 	ctx := datafs.ContextWithFSProvider(context.Background(), datafs.WrappedFSProvider(fsys, "file"))
 
-	_, err := createOutFile(ctx, "in", 0o755, 0o644, false)
+	_, err := createOutFile(ctx, "in", 0o755, 0o644, false, "")
 	assert.Error(t, err)
 	assert.IsType(t, &fs.PathError{}, err)
 }
 
+func TestCreateOutFileBackup(t *testing.T) {
+	fsys, _ := mem.NewFS()
+	require.NoError(t, hackpadfs.WriteFullFile(fsys, "out", []byte("old"), 0o600))
+
+	ctx := datafs.ContextWithFSProvider(context.Background(), datafs.WrappedFSProvider(fsys, "file"))
+
+	write := func(filename, content string) {
+		t.Helper()
+
+		w, err := createOutFile(ctx, filename, 0o755, 0o644, false, ".bak")
+		require.NoError(t, err)
+
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	}
+
+	write("out", "new")
+
+	b, err := fs.ReadFile(fsys, "out")
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(b))
+
+	b, err = fs.ReadFile(fsys, "out.bak")
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(b))
+
+	fi, err := fs.Stat(fsys, "out.bak")
+	require.NoError(t, err)
+	assert.Equal(t, iohelpers.NormalizeFileMode(0o600), fi.Mode())
+
+	// unchanged files aren't backed up again
+	write("out", "new")
+
+	b, err = fs.ReadFile(fsys, "out.bak")
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(b))
+
+	// new files have nothing to back up
+	write("other", "hello")
+
+	_, err = fs.Stat(fsys, "other.bak")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
 func TestParseNestedTemplates(t *testing.T) {
 	wd, _ := os.Getwd()
 	t.Cleanup(func() {
@@ -197,7 +245,7 @@ func TestParseNestedTemplates(t *testing.T) {
 	assert.Equal(t, "hello world", out.String())
 
 	// test with directory of templates
-	fsys["dir/"] = &fstest.MapFile{Mode: 0o777 | os.ModeDir}
+	fsys["dir"] = &fstest.MapFile{Mode: 0o777 | os.ModeDir}
 	fsys["dir/foo.t"] = &fstest.MapFile{Data: []byte("foo"), Mode: 0o600}
 	fsys["dir/bar.t"] = &fstest.MapFile{Data: []byte("bar"), Mode: 0o600}
 
@@ -213,4 +261,18 @@ func TestParseNestedTemplates(t *testing.T) {
 	err = tmpl.Execute(&out, nil)
 	require.NoError(t, err)
 	assert.Equal(t, "foo bar", out.String())
+
+	// subdirectories are included recursively
+	fsys["dir/sub"] = &fstest.MapFile{Mode: 0o777 | os.ModeDir}
+	fsys["dir/sub/baz.t"] = &fstest.MapFile{Data: []byte("baz"), Mode: 0o600}
+
+	tmpl, _ = template.New("root").Parse(`{{ template "dir/foo.t" }} {{ template "dir/sub/baz.t" }}`)
+
+	err = parseNestedTemplates(ctx, nested, tmpl)
+	require.NoError(t, err)
+
+	out = bytes.Buffer{}
+	err = tmpl.Execute(&out, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "foo baz", out.String())
 }
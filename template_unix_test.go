@@ -4,10 +4,14 @@ package gomplate
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"syscall"
 	"testing"
 
 	"github.com/hack-pad/hackpadfs"
 	"github.com/hack-pad/hackpadfs/mem"
+	osfs "github.com/hack-pad/hackpadfs/os"
 	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 
@@ -56,3 +60,35 @@ func TestWalkDir_UNIX(t *testing.T) {
 		assert.Equal(t, expected[i].Text, tmpl.Text)
 	}
 }
+
+func TestCreateOutFileBackupMode_UNIX(t *testing.T) {
+	// the umask would otherwise remove the group write bit
+	oldUmask := syscall.Umask(0o022)
+	defer syscall.Umask(oldUmask)
+
+	dir := t.TempDir()
+	fsys := datafs.WrapWdFS(osfs.NewFS())
+	ctx := datafs.ContextWithFSProvider(context.Background(), datafs.WrappedFSProvider(fsys, "file"))
+
+	out := filepath.Join(dir, "out")
+	require.NoError(t, os.WriteFile(out, []byte("old"), 0o600))
+	require.NoError(t, os.Chmod(out, 0o664))
+
+	w, err := createOutFile(ctx, out, 0o755, 0o644, false, ".bak")
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("new"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	// both the backup and the new output keep the original file's mode
+	for _, name := range []string{out, out + ".bak"} {
+		fi, err := os.Stat(name)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o664), fi.Mode().Perm(), name)
+	}
+
+	b, err := os.ReadFile(out + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(b))
+}
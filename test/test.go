@@ -3,6 +3,7 @@
 package test
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -32,7 +33,7 @@ func Required(message string, value interface{}) (interface{}, error) {
 	}
 
 	if s, ok := value.(string); value == nil || (ok && s == "") {
-		return nil, fmt.Errorf(message)
+		return nil, errors.New(message)
 	}
 
 	return value, nil
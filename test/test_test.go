@@ -22,6 +22,8 @@ func TestFail(t *testing.T) {
 	assert.Error(t, err)
 	err = Fail("msg")
 	assert.EqualError(t, err, "template generation failed: msg")
+	err = Fail("100% broken")
+	assert.EqualError(t, err, "template generation failed: 100% broken")
 }
 
 func TestRequired(t *testing.T) {
@@ -38,6 +40,11 @@ func TestRequired(t *testing.T) {
 	assert.EqualError(t, err, "foo")
 	assert.Nil(t, v)
 
+	// messages are not format strings
+	v, err = Required("need 100% of foo", "")
+	assert.EqualError(t, err, "need 100% of foo")
+	assert.Nil(t, v)
+
 	v, err = Required("", 0)
 	require.NoError(t, err)
 	assert.Equal(t, v, 0)
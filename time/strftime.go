@@ -0,0 +1,48 @@
+package time
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/lestrrat-go/strftime"
+)
+
+// strftimeSpecs extends the default conversions with the common extensions
+// that github.com/lestrrat-go/strftime doesn't support out of the box
+//
+//nolint:gochecknoglobals
+var strftimeSpecs = newStrftimeSpecs()
+
+func newStrftimeSpecs() strftime.SpecificationSet {
+	ss := strftime.NewSpecificationSet()
+
+	// these can't fail, as the set is mutable
+	_ = ss.Set('L', strftime.Milliseconds())
+	_ = ss.Set('N', strftime.AppendFunc(nanoseconds))
+	_ = ss.Set('P', strftime.StdlibFormat("pm"))
+	_ = ss.Set('s', strftime.UnixSeconds())
+
+	return ss
+}
+
+// nanoseconds appends the zero-padded 9-digit nanoseconds of the time
+func nanoseconds(b []byte, t time.Time) []byte {
+	n := strconv.Itoa(t.Nanosecond())
+	for i := len(n); i < 9; i++ {
+		b = append(b, '0')
+	}
+
+	return append(b, n...)
+}
+
+// Strftime - format a time according to a strftime(3)-style format string,
+// using github.com/lestrrat-go/strftime.
+//
+// All conversion specifications defined by POSIX are supported, along with
+// the common extensions %k, %l, %P, %s, and %v, and %L and %N for
+// milliseconds and nanoseconds. Padding can be suppressed for numeric
+// conversions with the '-' flag (e.g. %-d). Names are always in English (the
+// "C" locale). An error is returned for unknown conversions.
+func Strftime(format string, t time.Time) (string, error) {
+	return strftime.Format(format, t, strftime.WithSpecificationSet(strftimeSpecs))
+}
@@ -0,0 +1,54 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrftime(t *testing.T) {
+	// Sunday, the first day of 2017 (ISO week 52 of 2016)
+	t1 := time.Date(2017, time.January, 1, 0, 5, 9, 123456789, time.UTC)
+	// Monday, the last day of 2018 (ISO week 1 of 2019)
+	t2 := time.Date(2018, time.December, 31, 13, 0, 0, 0, time.FixedZone("EST", -5*60*60))
+
+	testdata := []struct {
+		t        time.Time
+		format   string
+		expected string
+	}{
+		{t1, "", ""},
+		{t1, "no conversions", "no conversions"},
+		{t1, "%Y-%m-%d %H:%M:%S", "2017-01-01 00:05:09"},
+		{t1, "%F %T %z %Z", "2017-01-01 00:05:09 +0000 UTC"},
+		{t1, "%a %A %b %h %B", "Sun Sunday Jan Jan January"},
+		{t1, "%c", "Sun Jan  1 00:05:09 2017"},
+		{t1, "%D|%x|%X|%R|%r", "01/01/17|01/01/17|00:05:09|00:05|12:05:09 AM"},
+		{t1, "%e|%k|%l|%I|%p|%P", " 1| 0|12|12|AM|am"},
+		{t1, "%C %y %j", "20 17 001"},
+		{t1, "%g %G %V %U %W %u %w", "16 2016 52 01 00 7 0"},
+		{t1, "%s", "1483229109"},
+		{t1, "%L %N", "123 123456789"},
+		{t1, "%%|%n|%t", "%|\n|\t"},
+		{t1, "%-d|%-m|%-H|%-j|%e|%k", "1|1|0|1| 1| 0"},
+		{t1, "%v", " 1-Jan-2017"},
+		{t2, "%F %T %z %Z", "2018-12-31 13:00:00 -0500 EST"},
+		{t2, "%I %l %p %r", "01  1 PM 01:00:00 PM"},
+		{t2, "%g %G %V %U %W %u %w %j", "19 2019 01 52 53 1 1 365"},
+	}
+
+	for _, d := range testdata {
+		out, err := Strftime(d.format, d.t)
+		require.NoError(t, err, d.format)
+		assert.Equal(t, d.expected, out, d.format)
+	}
+
+	// unknown conversions and stray '%'s are errors
+	_, err := Strftime("%Q", t1)
+	require.Error(t, err)
+
+	_, err = Strftime("100%", t1)
+	require.Error(t, err)
+}
@@ -2,11 +2,30 @@
 package time
 
 import (
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/hairyhenderson/gomplate/v4/env"
 )
 
+// Now - returns the current time. If the SOURCE_DATE_EPOCH environment
+// variable is set, the (UTC) time it represents is returned instead, so that
+// output can be reproducible. See
+// https://reproducible-builds.org/specs/source-date-epoch/.
+func Now() (time.Time, error) {
+	if s := env.Getenv("SOURCE_DATE_EPOCH"); s != "" {
+		sec, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH value %q: must be a UNIX timestamp", s)
+		}
+
+		return time.Unix(sec, 0).UTC(), nil
+	}
+
+	return time.Now(), nil
+}
+
 // ZoneName - a convenience function for determining the current timezone's name
 func ZoneName() string {
 	n, _ := zone()
@@ -12,3 +12,20 @@ func TestZoneFuncs(t *testing.T) {
 	assert.Equal(t, name, ZoneName())
 	assert.Equal(t, offset, ZoneOffset())
 }
+
+func TestNow(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "")
+	before := time.Now()
+	now, err := Now()
+	assert.NoError(t, err)
+	assert.False(t, now.Before(before))
+
+	t.Setenv("SOURCE_DATE_EPOCH", "1483228800")
+	now, err = Now()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC), now)
+
+	t.Setenv("SOURCE_DATE_EPOCH", "yesterday")
+	_, err = Now()
+	assert.Error(t, err)
+}
@@ -62,8 +62,12 @@ func (t *Template) inline(name, in string, ctx interface{}) (string, error) {
 // Exec - execute (render) a template - this is the built-in `template` action, except with output...
 func (t *Template) Exec(name string, tmplcontext ...interface{}) (string, error) {
 	ctx := t.defaultCtx
-	if len(tmplcontext) == 1 {
+	switch len(tmplcontext) {
+	case 0:
+	case 1:
 		ctx = tmplcontext[0]
+	default:
+		return "", fmt.Errorf("wrong number of args for tmpl.Exec: want 1 or 2 - got %d", len(tmplcontext)+1)
 	}
 	tmpl := t.root.Lookup(name)
 	if tmpl == nil {
@@ -111,7 +115,7 @@ func (t *Template) parseArgs(args ...interface{}) (name, in string, ctx interfac
 		var ok bool
 		in, ok = args[1].(string)
 		if !ok {
-			return "", "", nil, fmt.Errorf("wrong input: second arg (in) must be string, got %T", args[0])
+			return "", "", nil, fmt.Errorf("wrong input: second arg (in) must be string, got %T", args[1])
 		}
 		ctx = args[2]
 	}
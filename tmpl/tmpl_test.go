@@ -47,7 +47,7 @@ func TestParseArgs(t *testing.T) {
 	assert.Error(t, err)
 
 	_, _, _, err = tmpl.parseArgs("", 42, 42)
-	assert.Error(t, err)
+	assert.EqualError(t, err, "wrong input: second arg (in) must be string, got int")
 
 	name, in, ctx, err = tmpl.parseArgs("foo", "bar")
 	require.NoError(t, err)
@@ -88,6 +88,30 @@ func TestExec(t *testing.T) {
 
 	_, err = tmpl.Exec("bogus")
 	assert.Error(t, err)
+
+	_, err = tmpl.Exec("T1", "world", "extra")
+	assert.EqualError(t, err, "wrong number of args for tmpl.Exec: want 1 or 2 - got 3")
+}
+
+func TestInlineFromData(t *testing.T) {
+	// a template string coming from data can refer to other values in the
+	// same context, for two-stage rendering
+	ctx := map[string]interface{}{
+		"name":     "world",
+		"greeting": "hello, {{ .name }}",
+	}
+	tmpl := New(template.New("root"), ctx, "")
+
+	out, err := tmpl.Inline(ctx["greeting"])
+	require.NoError(t, err)
+	assert.Equal(t, "hello, world", out)
+
+	out, err = tmpl.Inline(ctx["greeting"], map[string]string{"name": "gomplate"})
+	require.NoError(t, err)
+	assert.Equal(t, "hello, gomplate", out)
+
+	_, err = tmpl.Inline("{{ .name ")
+	assert.Error(t, err)
 }
 
 func TestPath(t *testing.T) {
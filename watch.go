@@ -0,0 +1,414 @@
+package gomplate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/rs/zerolog"
+)
+
+// DefaultWatchInterval - how often files are checked for changes in watch
+// mode with polling, when not configured
+const DefaultWatchInterval = time.Second
+
+// watchDebounce is how long to wait for further filesystem notifications
+// before re-rendering, since saving a file often causes several events
+const watchDebounce = 100 * time.Millisecond
+
+// Watch renders all templates specified by the given configuration (like
+// Run), and then watches the input templates, nested templates, and local
+// file datasources for changes, re-rendering whenever any of them change.
+// Rendering errors are logged, and don't stop watching. Watch returns when the
+// context is cancelled.
+//
+// When onRender is not nil, it's called after each successful render (for
+// example to start or reload a process which uses the rendered output). An
+// error from onRender stops watching, and is returned.
+//
+// Changes are detected with filesystem notifications. When cfg.WatchPoll is
+// set, or notifications aren't available, the files' modification times and
+// sizes are checked every cfg.WatchInterval instead. Sending the process
+// SIGHUP forces all templates to be re-rendered, with datasources re-read
+// instead of using cached content.
+//
+// Experimental: subject to breaking changes before the next major release
+func Watch(ctx context.Context, cfg *config.Config, onRender func(context.Context) error) error {
+	refresh := make(chan os.Signal, 1)
+	signal.Notify(refresh, syscall.SIGHUP)
+	defer signal.Stop(refresh)
+
+	return watch(ctx, cfg, refresh, func(ctx context.Context) error {
+		return Run(ctx, cfg)
+	}, onRender)
+}
+
+// watch calls render once, and then again whenever a watched file changes, or
+// a refresh is requested
+func watch(ctx context.Context, cfg *config.Config, refresh <-chan os.Signal, render, onRender func(context.Context) error) error {
+	log := zerolog.Ctx(ctx)
+
+	cfg.ApplyDefaults()
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("failed to validate config: %w\n%+v", err, cfg)
+	}
+
+	// stops watching for changes when watch returns
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// start watching before rendering, so changes made during rendering
+	// aren't missed
+	paths := watchPaths(cfg)
+	changes := watchChanges(wctx, cfg, paths)
+
+	renderAll := func(ctx context.Context) error {
+		if err := render(ctx); err != nil {
+			log.Error().Err(err).Msg("rendering failed, waiting for changes")
+			return nil
+		}
+		if onRender != nil {
+			return onRender(ctx)
+		}
+		return nil
+	}
+	if err := renderAll(ctx); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return nil
+			}
+			return ctx.Err()
+		case sig := <-refresh:
+			log.Info().Stringer("signal", sig).Msg("refresh requested, re-reading datasources and re-rendering")
+
+			// everything is re-rendered, so pending changes can be dropped
+			drain(changes)
+			if err := renderAll(config.SetDatasourceRefresh(ctx)); err != nil {
+				return err
+			}
+		case changed := <-changes:
+			log.Info().Strs("changed", changed).Msg("changes detected, re-rendering")
+			if err := renderAll(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watchChanges returns a channel which receives the paths which changed,
+// detected with filesystem notifications, or by polling when configured or
+// when notifications aren't available. Watching stops when the context is
+// done.
+func watchChanges(ctx context.Context, cfg *config.Config, paths []string) <-chan []string {
+	log := zerolog.Ctx(ctx)
+
+	if !cfg.WatchPoll {
+		changes, err := notifyChanges(ctx, paths)
+		if err == nil {
+			log.Debug().Strs("paths", paths).Msg("watching for changes")
+			return changes
+		}
+
+		log.Warn().Err(err).Msg("filesystem notifications unavailable, polling for changes instead")
+	}
+
+	interval := cfg.WatchInterval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	log.Debug().Strs("paths", paths).Dur("interval", interval).Msg("polling for changes")
+
+	return pollChanges(ctx, paths, interval)
+}
+
+// drain discards any changes waiting to be received
+func drain(changes <-chan []string) {
+	for {
+		select {
+		case <-changes:
+		default:
+			return
+		}
+	}
+}
+
+// watchPaths returns the local paths that the rendered output depends on:
+// the input templates, nested templates, and file datasources
+func watchPaths(cfg *config.Config) []string {
+	paths := map[string]struct{}{}
+
+	for _, f := range cfg.InputFiles {
+		if f != "-" {
+			paths[f] = struct{}{}
+		}
+	}
+	if cfg.InputDir != "" {
+		paths[cfg.InputDir] = struct{}{}
+	}
+
+	for _, sources := range []map[string]config.DataSource{cfg.DataSources, cfg.Context, cfg.Templates} {
+		for _, ds := range sources {
+			if p := localPath(ds.URL); p != "" {
+				paths[p] = struct{}{}
+			}
+		}
+	}
+
+	out := make([]string, 0, len(paths))
+	for p := range paths {
+		out = append(out, p)
+	}
+	sort.Strings(out)
+
+	return out
+}
+
+// localPath returns the local filesystem path referenced by the URL, if any
+func localPath(u *url.URL) string {
+	if u == nil || (u.Scheme != "" && u.Scheme != "file") {
+		return ""
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// snapshot records the state of all files in the given paths. Directories are
+// walked recursively, and missing paths are ignored (so they're detected when
+// they're created).
+func snapshot(paths []string) map[string]fileState {
+	states := map[string]fileState{}
+	for _, p := range paths {
+		_ = filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				//nolint:nilerr
+				return nil
+			}
+			fi, err := d.Info()
+			if err != nil {
+				//nolint:nilerr
+				return nil
+			}
+			states[path] = fileState{modTime: fi.ModTime(), size: fi.Size()}
+			return nil
+		})
+	}
+	return states
+}
+
+// changedPaths returns the paths which were added, removed, or modified
+// between the two snapshots
+func changedPaths(prev, next map[string]fileState) []string {
+	changed := []string{}
+	for p, s := range next {
+		if ps, ok := prev[p]; !ok || !ps.modTime.Equal(s.modTime) || ps.size != s.size {
+			changed = append(changed, p)
+		}
+	}
+	for p := range prev {
+		if _, ok := next[p]; !ok {
+			changed = append(changed, p)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// pollChanges checks the paths for changes every interval, sending the
+// changed paths to the returned channel
+func pollChanges(ctx context.Context, paths []string, interval time.Duration) <-chan []string {
+	changes := make(chan []string)
+	prev := snapshot(paths)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			next := snapshot(paths)
+			changed := changedPaths(prev, next)
+			if len(changed) == 0 {
+				continue
+			}
+			prev = next
+
+			select {
+			case <-ctx.Done():
+				return
+			case changes <- changed:
+			}
+		}
+	}()
+
+	return changes
+}
+
+// notifier watches paths for changes with filesystem notifications. Files
+// are watched through their parent directories, so that files which are
+// replaced (as many editors do when saving) or created later are noticed.
+// Directories are watched recursively.
+type notifier struct {
+	w *fsnotify.Watcher
+	// paths are the absolute paths being watched
+	paths []string
+}
+
+// notifyChanges watches the paths with filesystem notifications, sending the
+// changed paths to the returned channel once no more changes have been seen
+// for a short time
+func notifyChanges(ctx context.Context, paths []string) (<-chan []string, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	n := &notifier{w: w}
+	for _, p := range paths {
+		p, err = filepath.Abs(p)
+		if err != nil {
+			_ = w.Close()
+			return nil, err
+		}
+
+		n.paths = append(n.paths, p)
+
+		if err := n.add(p); err != nil {
+			_ = w.Close()
+			return nil, fmt.Errorf("failed to watch %s: %w", p, err)
+		}
+	}
+
+	changes := make(chan []string)
+	go n.run(ctx, changes)
+
+	return changes, nil
+}
+
+// add watches the given path - directories are watched recursively, and files
+// (including missing files) are watched through their parent directory
+func (n *notifier) add(p string) error {
+	fi, err := os.Stat(p)
+	if err == nil && fi.IsDir() {
+		return n.addDir(p)
+	}
+
+	dir := filepath.Dir(p)
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		// nothing to watch until the directory is created
+		return nil
+	}
+
+	return n.w.Add(dir)
+}
+
+// addDir watches the directory and all of its subdirectories
+func (n *notifier) addDir(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		return n.w.Add(path)
+	})
+}
+
+// watched returns true when the changed path is one of the watched paths, or
+// is within a watched directory
+func (n *notifier) watched(name string) bool {
+	for _, p := range n.paths {
+		if name == p || strings.HasPrefix(name, p+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *notifier) run(ctx context.Context, changes chan<- []string) {
+	log := zerolog.Ctx(ctx)
+	defer n.w.Close()
+
+	pending := map[string]struct{}{}
+
+	// fires once no more events have been seen for watchDebounce
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err, ok := <-n.w.Errors:
+			if !ok {
+				return
+			}
+			log.Warn().Err(err).Msg("error watching for changes")
+		case ev, ok := <-n.w.Events:
+			if !ok {
+				return
+			}
+
+			// permission changes don't affect rendering
+			if ev.Op == fsnotify.Chmod || !n.watched(ev.Name) {
+				continue
+			}
+
+			// watch new subdirectories of watched directories
+			if ev.Has(fsnotify.Create) {
+				if fi, err := os.Stat(ev.Name); err == nil && fi.IsDir() {
+					if err := n.addDir(ev.Name); err != nil {
+						log.Warn().Err(err).Str("path", ev.Name).Msg("failed to watch new directory")
+					}
+				}
+			}
+
+			pending[ev.Name] = struct{}{}
+			timer.Reset(watchDebounce)
+		case <-timer.C:
+			if len(pending) == 0 {
+				continue
+			}
+
+			changed := make([]string, 0, len(pending))
+			for p := range pending {
+				changed = append(changed, p)
+			}
+			sort.Strings(changed)
+			pending = map[string]struct{}{}
+
+			select {
+			case <-ctx.Done():
+				return
+			case changes <- changed:
+			}
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package gomplate
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchPaths(t *testing.T) {
+	mustURL := func(s string) *url.URL {
+		u, err := url.Parse(s)
+		require.NoError(t, err)
+		return u
+	}
+
+	cfg := &config.Config{
+		InputFiles: []string{"-", "b.tmpl", "a.tmpl"},
+		DataSources: map[string]config.DataSource{
+			"foo": {URL: mustURL("foo.json")},
+			"bar": {URL: mustURL("file:///data/bar.yaml")},
+			"baz": {URL: mustURL("https://example.com/baz.json")},
+		},
+		Context: map[string]config.DataSource{
+			"ctx": {URL: mustURL("foo.json")},
+			"env": {URL: mustURL("env:///HOME")},
+		},
+		Templates: map[string]config.DataSource{
+			"t": {URL: mustURL("templates/")},
+		},
+	}
+
+	assert.Equal(t, []string{
+		filepath.FromSlash("/data/bar.yaml"),
+		"a.tmpl", "b.tmpl", "foo.json",
+		filepath.FromSlash("templates/"),
+	}, watchPaths(cfg))
+
+	cfg = &config.Config{InputDir: "in"}
+	assert.Equal(t, []string{"in"}, watchPaths(cfg))
+}
+
+func TestSnapshotAndChangedPaths(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.tmpl")
+	sub := filepath.Join(dir, "sub")
+	b := filepath.Join(sub, "b.tmpl")
+
+	require.NoError(t, os.WriteFile(a, []byte("a"), 0o600))
+	require.NoError(t, os.Mkdir(sub, 0o755))
+	require.NoError(t, os.WriteFile(b, []byte("b"), 0o600))
+
+	missing := filepath.Join(dir, "missing.json")
+	paths := []string{a, sub, missing}
+
+	prev := snapshot(paths)
+	assert.Len(t, prev, 2)
+	assert.Contains(t, prev, a)
+	assert.Contains(t, prev, b)
+
+	assert.Empty(t, changedPaths(prev, snapshot(paths)))
+
+	// modified
+	require.NoError(t, os.WriteFile(b, []byte("bb"), 0o600))
+	// created
+	require.NoError(t, os.WriteFile(missing, []byte("{}"), 0o600))
+	// removed
+	require.NoError(t, os.Remove(a))
+
+	assert.Equal(t, []string{a, missing, b}, changedPaths(prev, snapshot(paths)))
+}
+
+func TestNotifyChanges(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.tmpl")
+	sub := filepath.Join(dir, "sub")
+	missing := filepath.Join(dir, "missing.json")
+
+	require.NoError(t, os.WriteFile(a, []byte("a"), 0o600))
+	require.NoError(t, os.Mkdir(sub, 0o755))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	changes, err := notifyChanges(ctx, []string{a, sub, missing})
+	require.NoError(t, err)
+
+	next := func() []string {
+		select {
+		case changed := <-changes:
+			return changed
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for changes")
+			return nil
+		}
+	}
+
+	// unwatched files in the same directory are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.txt"), []byte("x"), 0o600))
+	require.NoError(t, os.WriteFile(a, []byte("aa"), 0o600))
+	assert.Equal(t, []string{a}, next())
+
+	// missing files are noticed when they're created
+	require.NoError(t, os.WriteFile(missing, []byte("{}"), 0o600))
+	assert.Equal(t, []string{missing}, next())
+
+	// files replaced by renaming are noticed
+	tmp := filepath.Join(dir, "a.tmpl.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte("aaa"), 0o600))
+	require.NoError(t, os.Rename(tmp, a))
+	assert.Equal(t, []string{a}, next())
+
+	// new subdirectories of watched directories are watched too
+	nested := filepath.Join(sub, "nested")
+	require.NoError(t, os.Mkdir(nested, 0o755))
+	assert.Equal(t, []string{nested}, next())
+
+	b := filepath.Join(nested, "b.tmpl")
+	require.NoError(t, os.WriteFile(b, []byte("b"), 0o600))
+	assert.Equal(t, []string{b}, next())
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "in.tmpl")
+	require.NoError(t, os.WriteFile(in, []byte("hello"), 0o600))
+
+	cfg := &config.Config{
+		InputFiles:    []string{in},
+		OutputFiles:   []string{filepath.Join(dir, "out")},
+		Watch:         true,
+		WatchInterval: 10 * time.Millisecond,
+	}
+
+	for _, poll := range []bool{false, true} {
+		cfg.WatchPoll = poll
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		renders := 0
+		err := watch(ctx, cfg, nil, func(context.Context) error {
+			renders++
+			switch renders {
+			case 1:
+				// change the template after the first render
+				require.NoError(t, os.WriteFile(in, []byte("hello world"), 0o600))
+			case 2:
+				cancel()
+			}
+			return nil
+		}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 2, renders, "poll: %t", poll)
+	}
+
+	// onRender is only called after successful renders, and its errors stop
+	// watching
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	renders := 0
+	reloads := 0
+	err := watch(ctx, cfg, nil, func(context.Context) error {
+		renders++
+		require.NoError(t, os.WriteFile(in, []byte(strings.Repeat("x", renders)), 0o600))
+		if renders == 1 {
+			return errors.New("render failed")
+		}
+		return nil
+	}, func(context.Context) error {
+		reloads++
+		if reloads == 2 {
+			return errors.New("reload failed")
+		}
+		return nil
+	})
+	require.EqualError(t, err, "reload failed")
+	assert.Equal(t, 3, renders)
+	assert.Equal(t, 2, reloads)
+
+	// a refresh re-renders without any changes, re-reading datasources
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	refresh := make(chan os.Signal, 1)
+	refreshed := []bool{}
+	err = watch(ctx, cfg, refresh, func(ctx context.Context) error {
+		refreshed = append(refreshed, config.DatasourceRefresh(ctx))
+		if len(refreshed) == 1 {
+			refresh <- syscall.SIGHUP
+		} else {
+			cancel()
+		}
+		return nil
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false, true}, refreshed)
+
+	// invalid config is rejected before rendering
+	cfg = &config.Config{Watch: true}
+	err = watch(context.Background(), cfg, nil, func(context.Context) error {
+		t.Fatal("should not render")
+		return nil
+	}, nil)
+	assert.Error(t, err)
+}